@@ -2,62 +2,157 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"cooperations/internal/gui"
 	"cooperations/internal/logging"
+	"cooperations/internal/metrics"
 	"cooperations/internal/orchestrator"
+	"cooperations/internal/server"
 	"cooperations/internal/tui"
 	"cooperations/internal/tui/demo"
+	"cooperations/internal/tui/session"
 	"cooperations/internal/tui/stream"
+	"cooperations/internal/tui/styles"
+	"cooperations/internal/types"
 
 	"github.com/joho/godotenv"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
 var (
-	verbose      bool
-	dryRun       bool
-	maxCycles    int
-	workflowType string
-	outputFile   string
+	verbose              bool
+	dryRun               bool
+	maxCycles            int
+	workflowType         string
+	outputFile           string
+	outputMode           string
+	outputAppend         bool
+	noApply              bool
+	workspaceDir         string
+	cleanOnKill          bool
+	maxCostUSD           float64
+	hardBudget           bool
+	hardMaxCost          float64
+	hardMaxTokens        int
+	convergenceThreshold float64
+	taskTags             []string
+	progressMode         bool
+	forceQuitGrace       time.Duration
+	quiet                bool
+	logFile              string
+	diagramOutput        string
+	diagramFormat        string
+	metricsAddr          string
+	gitCommit            bool
+	gitBranch            string
+	modelOverride        string
+	roleModelOverrides   []string
 )
 
 func main() {
 	// Load .env file if present
 	_ = godotenv.Load()
 
-	// Setup logging
 	logLevel := os.Getenv("LOG_LEVEL")
 	if logLevel == "" {
 		logLevel = "info"
 	}
-	logging.Setup(logLevel)
 
 	// Root command
 	rootCmd := &cobra.Command{
 		Use:   "coop",
 		Short: "Cooperations - AI mob programming orchestrator",
 		Long:  "Coordinates Claude Opus 4.5 and Codex 5.2 as collaborative mob programmers.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := logging.SetupWithOutput(logLevel, quiet, logFile); err != nil {
+				return fmt.Errorf("setup logging: %w", err)
+			}
+			return nil
+		},
 	}
+	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "Silence non-error logs (the run summary still prints)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write structured logs to this file instead of stderr")
 
 	// Run command
 	runCmd := &cobra.Command{
 		Use:   "run <task>",
 		Short: "Execute a task through the mob programming workflow",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runTask,
+		Long: "Executes a task through the mob programming workflow.\n\n" +
+			"Exit codes:\n" +
+			"  0  success\n" +
+			"  1  generic error (bad flags, setup failure, or another error before/outside the workflow run)\n" +
+			"  2  workflow failed (agent error, no agent for role, or an unclassified abort)\n" +
+			"  3  workflow killed or interrupted\n" +
+			"  4  workflow aborted on a budget, cycle, or timeout limit",
+		Args: cobra.ExactArgs(1),
+		RunE: runTask,
 	}
 	runCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed output")
 	runCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show routing decision without executing")
 	runCmd.Flags().IntVar(&maxCycles, "max-cycles", 0, "Override max review cycles")
 	runCmd.Flags().StringVar(&workflowType, "workflow", "", "Force workflow type (feature, bugfix, review)")
 	runCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write generated code to file")
+	runCmd.Flags().StringVar(&outputMode, "output-mode", "overwrite", "How to write --output: overwrite, append, or error-if-exists")
+	runCmd.Flags().BoolVar(&outputAppend, "output-append", false, "Shorthand for --output-mode=append")
+	runCmd.Flags().BoolVar(&noApply, "no-apply", false, "Preview proposed changes without writing them to disk")
+	runCmd.Flags().StringVar(&workspaceDir, "workspace", "", "Confine generated file writes to this directory instead of the current one")
+	runCmd.Flags().BoolVar(&cleanOnKill, "clean-on-kill", false, "Undo workspace writes made by a run that's killed mid-flight")
+	runCmd.Flags().Float64Var(&maxCostUSD, "max-cost-usd", 0, "Pause (or abort with --hard-budget) once estimated cost crosses this ceiling")
+	runCmd.Flags().BoolVar(&hardBudget, "hard-budget", false, "Abort immediately instead of pausing when --max-cost-usd is crossed")
+	runCmd.Flags().Float64Var(&hardMaxCost, "max-cost", 0, "Abort immediately (never prompting) once estimated cost crosses this ceiling; a safety valve for unattended runs")
+	runCmd.Flags().IntVar(&hardMaxTokens, "max-tokens", 0, "Abort immediately (never prompting) once total tokens used crosses this ceiling; a safety valve for unattended runs")
+	runCmd.Flags().Float64Var(&convergenceThreshold, "convergence-threshold", 0, "Complete review cycles early once consecutive implementer outputs are at least this similar (0-1, 0 disables)")
+	runCmd.Flags().StringSliceVar(&taskTags, "tag", nil, "Attach a tag to this task (repeatable); use to group related work in coop history")
+	runCmd.Flags().BoolVar(&progressMode, "progress", false, "Print a live progress bar to stderr instead of just start/complete lines")
+	runCmd.Flags().DurationVar(&forceQuitGrace, "force-quit-grace", 10*time.Second, "Grace period after the first Ctrl+C before a second one is needed to force quit")
+	runCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Expose Prometheus metrics for this run at this address (e.g. :9090); disabled when empty")
+	runCmd.Flags().BoolVar(&gitCommit, "git-commit", false, "Stage and commit the files written during a successful run; no-op with a warning outside a git repo")
+	runCmd.Flags().StringVar(&gitBranch, "git-branch", "", "Check out (creating if needed) this branch before running; \"{task_id}\" is replaced with the task ID. Only takes effect with --git-commit")
+	runCmd.Flags().StringVar(&modelOverride, "model", "", "Force every role to use this model profile for this run, ignoring the configured per-role profile")
+	runCmd.Flags().StringSliceVar(&roleModelOverrides, "role-model", nil, "Override a single role's model profile as role=profile (repeatable), e.g. --role-model architect=fast. Takes precedence over --model")
+
+	// Route command
+	routeCmd := &cobra.Command{
+		Use:   "route <task>",
+		Short: "Print the router's scored breakdown for a task without running it",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRoute,
+	}
+	routeCmd.Flags().String("router-config", "", "Path to a JSON file overriding the router's per-role keyword weights (see orchestrator.RouterConfig); unset roles keep the built-in patterns")
+
+	// Plan command
+	planCmd := &cobra.Command{
+		Use:   "plan <task>",
+		Short: "Preview the full role sequence and estimated cost for a task without running it",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runPlan,
+	}
+
+	// Undo command
+	undoCmd := &cobra.Command{
+		Use:   "undo <task_id>",
+		Short: "Revert the workspace writes a task made",
+		Long:  "Restores files a task modified to their pre-run content and removes files it created, using the write ledger recorded during the run.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUndo,
+	}
+	var undoDryRun bool
+	undoCmd.Flags().BoolVar(&undoDryRun, "dry-run", false, "Print what undo would do without touching any files")
 
 	// Status command
 	statusCmd := &cobra.Command{
@@ -75,6 +170,34 @@ func main() {
 	}
 	var historyLimit int
 	historyCmd.Flags().IntVar(&historyLimit, "limit", 10, "Number of tasks to show")
+	historyCmd.Flags().String("tag", "", "Show only tasks with this tag")
+	historyCmd.Flags().String("status", "", "Show only tasks with this status (pending, in_progress, completed, failed)")
+	historyCmd.Flags().String("sort", "created", "Sort by: created, updated, or status")
+	historyCmd.Flags().Bool("reverse", false, "Reverse the sort order")
+	historyCmd.Flags().Int("offset", 0, "Number of tasks to skip before the page starts")
+	historyCmd.Flags().Bool("all", false, "Show every matching task, ignoring --limit")
+
+	// Show command
+	showCmd := &cobra.Command{
+		Use:   "show [task_id]",
+		Short: "Show a task's design doc, review feedback, and generated files",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  showGenerated,
+	}
+	showCmd.Flags().Bool("code", false, "Print the generated code instead of the design/review/file-list summary")
+	showCmd.Flags().String("file", "", "Print only this generated file (relative to the task's output directory, e.g. code/main.go)")
+	showCmd.Flags().Int("head", 0, "Show only the first N lines of each file")
+	showCmd.Flags().Int("tail", 0, "Show only the last N lines of each file")
+
+	// Diagram command
+	diagramCmd := &cobra.Command{
+		Use:   "diagram <task_id>",
+		Short: "Export a task's handoff history as a Mermaid or Graphviz flowchart",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDiagram,
+	}
+	diagramCmd.Flags().StringVarP(&diagramOutput, "output", "o", "", "Write the diagram to this file instead of stdout")
+	diagramCmd.Flags().StringVar(&diagramFormat, "format", "mermaid", "Diagram format: mermaid or graphviz")
 
 	// GUI command
 	guiCmd := &cobra.Command{
@@ -97,12 +220,273 @@ func main() {
 	}
 	var tuiDemoMode bool
 	tuiCmd.Flags().BoolVar(&tuiDemoMode, "demo", false, "Run in demo mode with simulated workflow")
+	var tuiStreamLog string
+	tuiCmd.Flags().StringVar(&tuiStreamLog, "stream-log", "", "Write every stream event to an NDJSON file at this path")
+	var tuiNoSavePrefs bool
+	tuiCmd.Flags().BoolVar(&tuiNoSavePrefs, "no-save-prefs", false, "Don't persist dashboard layout preferences on quit")
+	var tuiTheme string
+	tuiCmd.Flags().StringVar(&tuiTheme, "theme", "", "Theme to use (dark, light); overrides the saved preference for this run")
+	var tuiNoBell bool
+	tuiCmd.Flags().BoolVar(&tuiNoBell, "no-bell", false, "Don't ring the terminal bell when a decision is pending")
+	var tuiStallTimeout time.Duration
+	tuiCmd.Flags().DurationVar(&tuiStallTimeout, "stall-timeout", tui.DefaultStallThreshold, "Warn if no stream event arrives for this long while the workflow is running (0 disables)")
+
+	// Sessions command
+	sessionsCmd := &cobra.Command{
+		Use:   "sessions",
+		Short: "Manage saved TUI sessions",
+	}
+	sessionsListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List saved TUI sessions",
+		RunE:  listSessions,
+	}
+	var sessionsRmForce bool
+	sessionsRmCmd := &cobra.Command{
+		Use:   "rm <session-id>",
+		Short: "Delete a saved TUI session (moved to trash, recoverable)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return removeSession(args[0], sessionsRmForce)
+		},
+	}
+	sessionsRmCmd.Flags().BoolVar(&sessionsRmForce, "force", false, "Skip the confirmation prompt")
+	sessionsCmd.AddCommand(sessionsListCmd, sessionsRmCmd)
+
+	// Replay command
+	replayCmd := &cobra.Command{
+		Use:   "replay <file.jsonl>",
+		Short: "Replay a recorded JSONL event log in the TUI",
+		Long:  "Feeds a JSONL event log (as written by --record-events, or a saved TUI session's event log) through the TUI at its original or an adjusted pace.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runReplay,
+	}
+	var replaySpeed float64
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 1.0, "Replay speed multiplier (2 = twice as fast, 0.5 = half speed)")
+	var replayStreamLog string
+	replayCmd.Flags().StringVar(&replayStreamLog, "stream-log", "", "Write every stream event to an NDJSON file at this path")
+	var replayTheme string
+	replayCmd.Flags().StringVar(&replayTheme, "theme", "", "Theme to use (dark, light); overrides the saved preference for this run")
+
+	// Serve command
+	serveCmd := &cobra.Command{
+		Use:   "serve <task>",
+		Short: "Run a task and stream its events to a browser over HTTP",
+		Long: "Runs the workflow and exposes its event stream at /events (Server-Sent Events) and human decisions at /decision (POST), for a lightweight web dashboard to observe without the TUI/GUI.\n\n" +
+			"Uses the same exit codes as `coop run` (see `coop run --help`).",
+		Args: cobra.ExactArgs(1),
+		RunE: runServe,
+	}
+	var serveAddr string
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8090", "Address to listen on; only change this to a non-loopback address if the network between it and its clients is trusted, since /decision and /ws can steer a live workflow's approval gates")
+	serveCmd.Flags().String("token", "", "Shared secret required (as the X-Coop-Token header or a ?token= query param) on /decision and /ws; auto-generated and printed to stderr if unset")
+
+	// Compare command
+	compareCmd := &cobra.Command{
+		Use:   "compare <id-a> <id-b>",
+		Short: "Compare the code and metrics of two TUI sessions or two task runs",
+		Long:  "Loads two saved TUI sessions (default) or two task runs (--task) and prints a unified diff of their final code artifacts, followed by a metrics comparison (tokens, cost, cycles). Useful for judging whether a prompt or model change actually improved output.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runCompare,
+	}
+	var compareByTask bool
+	compareCmd.Flags().BoolVar(&compareByTask, "task", false, "Treat the arguments as task IDs and compare their generated code directories instead of TUI sessions")
+
+	// Keys command
+	keysCmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Print the TUI's effective key bindings",
+		Long:  "Prints the TUI's key bindings after applying any overrides from keybindings.json, grouped by category, followed by any conflicting or unrecognized bindings found.",
+		RunE:  runKeys,
+	}
 
-	rootCmd.AddCommand(runCmd, statusCmd, historyCmd, guiCmd, tuiCmd)
+	rootCmd.AddCommand(runCmd, routeCmd, planCmd, statusCmd, undoCmd, historyCmd, showCmd, diagramCmd, guiCmd, tuiCmd, sessionsCmd, replayCmd, serveCmd, compareCmd, keysCmd)
 
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		code := exitGenericError
+		var ec *exitCodeErr
+		if errors.As(err, &ec) {
+			code = ec.code
+		}
+		os.Exit(code)
+	}
+}
+
+// runRoute prints the router's per-role scored breakdown for a task, plus
+// the role it would ultimately route to, without executing any workflow.
+// With --router-config, it also loads and displays the configured keyword
+// weights, so the flag can be used to sanity-check a config change before
+// wiring it into a real run.
+func runRoute(cmd *cobra.Command, args []string) error {
+	task := args[0]
+	configPath, _ := cmd.Flags().GetString("router-config")
+
+	routerCfg := orchestrator.RouterConfig{}
+	if configPath != "" {
+		loaded, err := orchestrator.LoadRouterConfig(configPath)
+		if err != nil {
+			return fmt.Errorf("load router config: %w", err)
+		}
+		routerCfg = loaded
+	}
+
+	router := orchestrator.NewRouterFromConfig(routerCfg)
+	breakdown := router.RouteBreakdown(task)
+	role, confidence := router.RouteWithConfidence(task)
+
+	roles := []types.Role{types.RoleArchitect, types.RoleReviewer, types.RoleNavigator, types.RoleImplementer}
+
+	if len(routerCfg.Weights) > 0 {
+		fmt.Println("Configured weights:")
+		roleNames := make([]string, 0, len(routerCfg.Weights))
+		for name := range routerCfg.Weights {
+			roleNames = append(roleNames, name)
+		}
+		sort.Strings(roleNames)
+		for _, name := range roleNames {
+			for _, kw := range routerCfg.Weights[name] {
+				fmt.Printf("  %-11s %-20s %.2f\n", name, kw.Keyword, kw.Weight)
+			}
+		}
+	}
+
+	fmt.Println("Scored breakdown:")
+	for _, r := range roles {
+		fmt.Printf("  %-11s %.2f\n", r, breakdown[r])
+	}
+	fmt.Printf("Routed to: %s (confidence: %.0f%%)\n", role, confidence*100)
+	return nil
+}
+
+// runPlan previews the full role sequence a task would take, with per-step
+// and total cost/duration estimates drawn from history, without running the
+// workflow.
+func runPlan(cmd *cobra.Command, args []string) error {
+	config := orchestrator.DefaultWorkflowConfig()
+	orch, err := orchestrator.New(config)
+	if err != nil {
+		return fmt.Errorf("initialize orchestrator: %w", err)
+	}
+
+	plan, err := orch.Plan(args[0])
+	if err != nil {
+		return fmt.Errorf("plan: %w", err)
+	}
+
+	fmt.Printf("Initial route: %s (confidence: %.0f%%)\n", plan.InitialRole, plan.Confidence*100)
+	fmt.Println("Planned steps:")
+	for i, step := range plan.Steps {
+		history := ""
+		if !step.HasHistory {
+			history = " (no history, estimate is 0)"
+		}
+		fmt.Printf("  %d. %-11s ~%d tokens, ~%s%s\n", i+1, step.Role, step.EstimatedTokens, time.Duration(step.EstimatedMS)*time.Millisecond, history)
+	}
+	fmt.Printf("Estimated total: ~%d tokens, ~$%.4f, ~%s\n", plan.EstimatedTotalTokens, plan.EstimatedCostUSD, time.Duration(plan.EstimatedDurationMS)*time.Millisecond)
+	return nil
+}
+
+// runUndo reverts a task's recorded workspace writes: files it modified are
+// restored to their pre-run content, files it created are removed. With
+// --dry-run, nothing is touched and the changes that would be made are
+// printed instead.
+func runUndo(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	config := orchestrator.DefaultWorkflowConfig()
+	orch, err := orchestrator.New(config)
+	if err != nil {
+		return fmt.Errorf("initialize orchestrator: %w", err)
+	}
+
+	changes, err := orch.Undo(args[0], dryRun)
+	if err != nil {
+		return fmt.Errorf("undo: %w", err)
+	}
+	if len(changes) == 0 {
+		fmt.Println("Nothing to undo: no recorded writes for this task")
+		return nil
+	}
+
+	for _, c := range changes {
+		fmt.Printf("  %-20s %s\n", c.Action, c.Path)
+	}
+	if dryRun {
+		fmt.Printf("Dry run: %d file(s) would change\n", len(changes))
+	} else {
+		fmt.Printf("Reverted %d file(s)\n", len(changes))
+	}
+	return nil
+}
+
+// Exit codes for coop run/serve, documented on runCmd's --help so scripts
+// and CI can react to failure type without parsing output:
+//
+//	0  success
+//	1  generic error (bad flags, setup failure, an error unrelated to a
+//	   workflow actually running)
+//	2  workflow failed (agent error, no agent for role, or another
+//	   unclassified abort)
+//	3  workflow killed or interrupted
+//	4  workflow aborted on a budget, cycle, or timeout limit
+const (
+	exitSuccess        = 0
+	exitGenericError   = 1
+	exitWorkflowFailed = 2
+	exitKilled         = 3
+	exitLimitReached   = 4
+)
+
+// exitCodeForAbortReason maps a failed run's AbortReason to one of the
+// process exit codes above.
+func exitCodeForAbortReason(kind types.AbortReasonKind) int {
+	switch kind {
+	case types.AbortReasonKilled, types.AbortReasonContextCanceled:
+		return exitKilled
+	case types.AbortReasonBudgetExceeded, types.AbortReasonTimeout:
+		return exitLimitReached
+	case types.AbortReasonAgentError, types.AbortReasonNoAgent, types.AbortReasonMaxCyclesExceeded:
+		return exitWorkflowFailed
+	default:
+		return exitGenericError
+	}
+}
+
+// parseRoleModelOverrides turns --role-model's "role=profile" entries into
+// a map, erroring on any entry missing the "=" separator so a typo doesn't
+// silently fail to override anything.
+func parseRoleModelOverrides(entries []string) (map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	overrides := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		role, profile, ok := strings.Cut(entry, "=")
+		if !ok || role == "" || profile == "" {
+			return nil, fmt.Errorf("invalid --role-model %q: expected role=profile", entry)
+		}
+		overrides[role] = profile
+	}
+	return overrides, nil
+}
+
+// exitCodeErr lets a RunE handler request a specific process exit code
+// instead of main's default of 1, while still going through cobra's normal
+// error printing.
+type exitCodeErr struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeErr) Error() string { return e.err.Error() }
+func (e *exitCodeErr) Unwrap() error { return e.err }
+
+// withExitCode wraps err, if non-nil, so main exits with code instead of 1.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
 	}
+	return &exitCodeErr{code: code, err: err}
 }
 
 func runTask(cmd *cobra.Command, args []string) error {
@@ -119,15 +503,47 @@ func runTask(cmd *cobra.Command, args []string) error {
 		cycles = maxCycles
 	}
 
+	parsedRoleModels, err := parseRoleModelOverrides(roleModelOverrides)
+	if err != nil {
+		return err
+	}
+
 	config := orchestrator.WorkflowConfig{
-		MaxReviewCycles: cycles,
+		MaxReviewCycles:      cycles,
+		NoApply:              noApply,
+		CleanOnKill:          cleanOnKill,
+		MaxCostUSD:           maxCostUSD,
+		HardBudget:           hardBudget,
+		HardMaxCostUSD:       hardMaxCost,
+		HardMaxTokens:        hardMaxTokens,
+		ConvergenceThreshold: convergenceThreshold,
+		GitCommit:            gitCommit,
+		GitBranch:            gitBranch,
+		ModelOverride:        modelOverride,
+		RoleModelOverrides:   parsedRoleModels,
 	}
 
-	orch, err := orchestrator.New(config)
+	var progressStream *stream.WorkflowStream
+	if progressMode {
+		progressStream = stream.NewWorkflowStream()
+	}
+
+	var orch *orchestrator.Orchestrator
+	if progressStream != nil {
+		orch, err = orchestrator.NewWithStream(config, progressStream)
+	} else {
+		orch, err = orchestrator.New(config)
+	}
 	if err != nil {
 		return fmt.Errorf("initialize orchestrator: %w", err)
 	}
 
+	if workspaceDir != "" {
+		if err := orch.SetWorkspaceRoot(workspaceDir); err != nil {
+			return fmt.Errorf("set workspace root: %w", err)
+		}
+	}
+
 	// Dry run mode
 	if dryRun {
 		role, confidence := orch.DryRun(task)
@@ -135,39 +551,88 @@ func runTask(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if progressStream != nil {
+		progressDone := make(chan struct{})
+		go func() {
+			defer close(progressDone)
+			renderProgress(os.Stderr, progressStream.Progress)
+		}()
+		defer func() {
+			progressStream.Close()
+			<-progressDone
+		}()
+	}
+
 	// Setup context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Handle interrupt signals
+	if metricsAddr != "" {
+		exporter := metrics.New(orch.Events(), orch.RoleProfiles(), metricsAddr)
+		go exporter.Run(ctx)
+		go func() {
+			if err := exporter.ListenAndServe(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "metrics server: %v\n", err)
+			}
+		}()
+		fmt.Printf("[METRICS] Serving Prometheus metrics at %s/metrics\n", metricsAddr)
+	}
+
+	// Handle interrupt signals. The first Ctrl+C cancels the context so the
+	// running agent gets a chance to stop cleanly; a stuck agent may ignore
+	// that, so a second Ctrl+C within forceQuitGrace forces the process to
+	// exit instead of leaving the user stranded.
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
 	go func() {
-		<-sigCh
-		fmt.Println("\nInterrupted, cancelling...")
+		select {
+		case <-sigCh:
+		case <-ctx.Done():
+			return
+		}
+		fmt.Printf("\nInterrupted, cancelling... (press Ctrl+C again within %s to force quit)\n", forceQuitGrace)
 		cancel()
+
+		select {
+		case <-sigCh:
+			fmt.Println("\nForced exit")
+			os.Exit(1)
+		case <-time.After(forceQuitGrace):
+		}
 	}()
 
 	// Run the task
 	fmt.Printf("[START] Running task: %s\n", truncate(task, 60))
-	result, err := orch.Run(ctx, task)
+	if noApply {
+		fmt.Println("[NO-APPLY] Previewing proposed changes without writing to disk")
+	}
+	result, err := orch.RunWithTags(ctx, task, taskTags)
 	if err != nil {
-		return fmt.Errorf("run task: %w", err)
+		fmt.Printf("[FAILED] Task %s failed (%s): %s\n", result.Task.ID, result.AbortReason.Kind, result.Error)
+		return withExitCode(exitCodeForAbortReason(result.AbortReason.Kind), err)
 	}
 
 	// Print result
-	if result.Success {
-		fmt.Printf("[COMPLETE] Task %s completed successfully\n", result.Task.ID)
-	} else {
-		fmt.Printf("[FAILED] Task %s failed: %s\n", result.Task.ID, result.Error)
-	}
+	fmt.Printf("[COMPLETE] Task %s completed successfully\n", result.Task.ID)
 
 	fmt.Printf("Artifacts saved to: .cooperations/handoffs/%s.json\n", result.Task.ID)
 
+	if len(result.FilesChanged) > 0 {
+		fmt.Printf("%d file(s) changed:\n", len(result.FilesChanged))
+		for _, fc := range result.FilesChanged {
+			fmt.Printf("  [%s] %s\n", fc.Action, fc.Path)
+		}
+	}
+
 	// Write code to output file if specified
 	if outputFile != "" && result.Artifacts.Code != "" {
+		mode := outputMode
+		if outputAppend {
+			mode = "append"
+		}
 		code := extractCode(result.Artifacts.Code)
-		if err := os.WriteFile(outputFile, []byte(code), 0644); err != nil {
+		if err := writeOutputFile(outputFile, code, mode, result.Task.ID); err != nil {
 			return fmt.Errorf("write output file: %w", err)
 		}
 		fmt.Printf("Code written to: %s\n", outputFile)
@@ -178,41 +643,164 @@ func runTask(cmd *cobra.Command, args []string) error {
 		fmt.Println(result.Artifacts.Code)
 	}
 
+	if verbose {
+		printWorkflowMetrics(result.Metrics)
+	}
+
 	return nil
 }
 
-// extractCode extracts code from markdown code blocks if present.
-func extractCode(content string) string {
-	// Check if content is wrapped in markdown code block
-	if len(content) > 6 && content[:3] == "```" {
-		// Find the end of the first line (language identifier)
-		start := 3
-		for start < len(content) && content[start] != '\n' {
-			start++
+// renderProgress consumes progress updates for a headless (--progress) run
+// and renders them to w: a single carriage-return-updating bar on a real
+// terminal, or one line per update when w isn't a TTY (e.g. redirected to a
+// file or piped into another process), since carriage returns there just
+// produce a wall of overlapping garbage. Returns once updates stops, which
+// happens when the stream is closed at the end of the run.
+func renderProgress(w *os.File, updates <-chan stream.ProgressUpdate) {
+	tty := isatty.IsTerminal(w.Fd()) || isatty.IsCygwinTerminal(w.Fd())
+	const barWidth = 30
+
+	for u := range updates {
+		filled := int(u.Percent / 100 * barWidth)
+		if filled > barWidth {
+			filled = barWidth
 		}
-		if start < len(content) {
-			start++ // skip the newline
+		bar := strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled)
+
+		if tty {
+			fmt.Fprintf(w, "\r[%s] %3.0f%% %-12s %s", bar, u.Percent, u.Stage, truncate(u.Message, 40))
+		} else {
+			fmt.Fprintf(w, "[%s] %3.0f%% %-12s %s\n", bar, u.Percent, u.Stage, truncate(u.Message, 40))
 		}
+	}
+	if tty {
+		fmt.Fprintln(w)
+	}
+}
+
+// printWorkflowMetrics prints a workflow's aggregate token, cost, and
+// per-role totals for --verbose runs.
+func printWorkflowMetrics(m types.WorkflowMetrics) {
+	fmt.Println("\n--- Metrics ---")
+	fmt.Printf("Tokens: %d (prompt %d, completion %d)\n", m.TotalTokens, m.PromptTokens, m.CompletionTokens)
+	fmt.Printf("Cost: $%.4f\n", m.EstimatedCostUSD)
+	fmt.Printf("Elapsed: %s\n", m.ElapsedTime.Round(time.Second))
+	fmt.Printf("Review cycles: %d\n", m.ReviewCycles)
+	fmt.Printf("Files changed: %d\n", m.FilesChanged)
+	for role, stats := range m.RoleBreakdown {
+		fmt.Printf("  [%s] %d call(s), %d tokens, %dms\n", role, stats.Calls, stats.Tokens, stats.DurationMS)
+	}
+}
+
+// codeFence is one fenced code block extracted from markdown-formatted
+// text, along with the language identifier on its opening line (if any).
+type codeFence struct {
+	Language string
+	Content  string
+}
+
+// extractCodeFences walks content line by line and returns every complete
+// fenced code block it contains. A fence opens on a line of three or more
+// backticks (optionally preceded by whitespace, optionally followed by a
+// language identifier) and closes on the next line whose backtick run is at
+// least as long, mirroring CommonMark's fence-length rule. That length
+// check is what lets a block safely contain a shorter backtick sequence
+// (e.g. a fenced example showing ``` inside its own output) without ending
+// the block early. A fence with no matching close is left as plain text.
+func extractCodeFences(content string) []codeFence {
+	var fences []codeFence
+	lines := strings.Split(content, "\n")
 
-		// Find the closing ```
-		end := len(content) - 1
-		for end > start && content[end] != '`' {
-			end--
+	i := 0
+	for i < len(lines) {
+		fenceLen, rest := fenceMarker(lines[i])
+		if fenceLen < 3 {
+			i++
+			continue
 		}
-		// Move back to before the closing ```
-		if end > start+2 && content[end-1] == '`' && content[end-2] == '`' {
-			end -= 2
+		language := strings.TrimSpace(rest)
+
+		var body []string
+		j := i + 1
+		closed := false
+		for j < len(lines) {
+			closeLen, closeRest := fenceMarker(lines[j])
+			if closeLen >= fenceLen && strings.TrimSpace(closeRest) == "" {
+				closed = true
+				break
+			}
+			body = append(body, lines[j])
+			j++
 		}
-		// Trim trailing newline before ```
-		for end > start && (content[end-1] == '\n' || content[end-1] == '\r') {
-			end--
+
+		if !closed {
+			i++
+			continue
 		}
+		fences = append(fences, codeFence{Language: language, Content: strings.Join(body, "\n")})
+		i = j + 1
+	}
+
+	return fences
+}
+
+// fenceMarker reports the length of a leading run of backticks on line
+// (after stripping leading whitespace) and the text following it.
+func fenceMarker(line string) (length int, rest string) {
+	trimmed := strings.TrimLeft(line, " \t")
+	for length < len(trimmed) && trimmed[length] == '`' {
+		length++
+	}
+	return length, trimmed[length:]
+}
+
+// extractCode extracts code from markdown code blocks if present. When
+// content contains one or more fenced code blocks, their contents are
+// concatenated (each separated by a blank line) so multiple blocks - e.g.
+// one per file - all make it into --output. Content with no fenced block is
+// returned unchanged.
+func extractCode(content string) string {
+	fences := extractCodeFences(content)
+	if len(fences) == 0 {
+		return content
+	}
+
+	blocks := make([]string, len(fences))
+	for i, f := range fences {
+		blocks[i] = f.Content
+	}
+	return strings.Join(blocks, "\n\n")
+}
 
-		if end > start {
-			return content[start:end]
+// writeOutputFile writes code to path according to mode:
+//   - "overwrite" (default): replace the file's contents.
+//   - "append": append after a separator comment naming taskID and the
+//     current time, so repeated runs accumulate snippets instead of
+//     clobbering each other.
+//   - "error-if-exists": fail rather than touch an existing file.
+func writeOutputFile(path, code, mode, taskID string) error {
+	switch mode {
+	case "", "overwrite":
+		return os.WriteFile(path, []byte(code), 0644)
+	case "append":
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
 		}
+		defer f.Close()
+		separator := fmt.Sprintf("\n// --- task %s @ %s ---\n", taskID, time.Now().Format(time.RFC3339))
+		_, err = f.WriteString(separator + code + "\n")
+		return err
+	case "error-if-exists":
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("output file %s already exists", path)
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		return os.WriteFile(path, []byte(code), 0644)
+	default:
+		return fmt.Errorf("invalid --output-mode %q (want overwrite, append, or error-if-exists)", mode)
 	}
-	return content
 }
 
 func showStatus(cmd *cobra.Command, args []string) error {
@@ -259,6 +847,16 @@ func showStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// hasTag reports whether tags contains tag.
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 func showHistory(cmd *cobra.Command, args []string) error {
 	config := orchestrator.DefaultWorkflowConfig()
 	orch, err := orchestrator.New(config)
@@ -271,23 +869,288 @@ func showHistory(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	tag, _ := cmd.Flags().GetString("tag")
+	status, _ := cmd.Flags().GetString("status")
+	if tag != "" || status != "" {
+		filtered := tasks[:0]
+		for _, t := range tasks {
+			if tag != "" && !hasTag(t.Tags, tag) {
+				continue
+			}
+			if status != "" && t.Status != status {
+				continue
+			}
+			filtered = append(filtered, t)
+		}
+		tasks = filtered
+	}
+
 	if len(tasks) == 0 {
 		fmt.Println("No tasks found")
 		return nil
 	}
 
+	sortBy, _ := cmd.Flags().GetString("sort")
+	reverse, _ := cmd.Flags().GetBool("reverse")
+	sortTasks(tasks, sortBy, reverse)
+
+	all, _ := cmd.Flags().GetBool("all")
+	offset, _ := cmd.Flags().GetInt("offset")
 	limit, _ := cmd.Flags().GetInt("limit")
-	start := 0
-	if len(tasks) > limit {
-		start = len(tasks) - limit
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(tasks) {
+		offset = len(tasks)
+	}
+	end := len(tasks)
+	if !all {
+		end = offset + limit
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+	}
+	page := tasks[offset:end]
+
+	fmt.Printf("Tasks %d-%d of %d:\n\n", offset+1, end, len(tasks))
+	for _, t := range page {
+		line := fmt.Sprintf("  %s  [%s]  %s", t.ID, t.Status, truncate(t.Description, 50))
+		if d, ok := taskDuration(t); ok {
+			line += fmt.Sprintf("  (%s)", d.Round(time.Second))
+		}
+		if len(t.Tags) > 0 {
+			line += "  (" + strings.Join(t.Tags, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+// sortTasks sorts tasks in place by field ("created", "updated", or
+// "status"; "created" is used for an unrecognized value), ascending unless
+// reverse is set.
+func sortTasks(tasks []types.Task, field string, reverse bool) {
+	less := func(i, j int) bool {
+		switch field {
+		case "updated":
+			return taskTimeKey(tasks[i].UpdatedAt, tasks[i].CreatedAt) < taskTimeKey(tasks[j].UpdatedAt, tasks[j].CreatedAt)
+		case "status":
+			return tasks[i].Status < tasks[j].Status
+		default:
+			return tasks[i].CreatedAt < tasks[j].CreatedAt
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if reverse {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+// taskTimeKey returns updatedAt, falling back to createdAt when a task
+// hasn't been updated since creation.
+func taskTimeKey(updatedAt, createdAt string) string {
+	if updatedAt != "" {
+		return updatedAt
+	}
+	return createdAt
+}
+
+// taskDuration returns how long a task took (UpdatedAt - CreatedAt), when
+// both timestamps are present and parse as RFC3339.
+func taskDuration(t types.Task) (time.Duration, bool) {
+	if t.UpdatedAt == "" {
+		return 0, false
+	}
+	created, err := time.Parse(time.RFC3339, t.CreatedAt)
+	if err != nil {
+		return 0, false
+	}
+	updated, err := time.Parse(time.RFC3339, t.UpdatedAt)
+	if err != nil {
+		return 0, false
+	}
+	return updated.Sub(created), true
+}
+
+func showGenerated(cmd *cobra.Command, args []string) error {
+	config := orchestrator.DefaultWorkflowConfig()
+	orch, err := orchestrator.New(config)
+	if err != nil {
+		return fmt.Errorf("initialize orchestrator: %w", err)
+	}
+
+	taskID := ""
+	if len(args) > 0 {
+		taskID = args[0]
+	} else {
+		tasks, err := orch.ListTasks()
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			fmt.Println("No tasks found")
+			return nil
+		}
+		taskID = tasks[len(tasks)-1].ID
 	}
 
-	fmt.Printf("Recent tasks (showing %d of %d):\n\n", min(limit, len(tasks)), len(tasks))
-	for i := start; i < len(tasks); i++ {
-		t := tasks[i]
-		fmt.Printf("  %s  [%s]  %s\n", t.ID, t.Status, truncate(t.Description, 50))
+	code, _ := cmd.Flags().GetBool("code")
+	file, _ := cmd.Flags().GetString("file")
+	head, _ := cmd.Flags().GetInt("head")
+	tail, _ := cmd.Flags().GetInt("tail")
+
+	if file != "" {
+		return showGeneratedFile(orch, taskID, file, head, tail)
+	}
+	if code {
+		return showGeneratedCode(orch, taskID, head, tail)
+	}
+	return showGeneratedSummary(orch, taskID, head, tail)
+}
+
+// showGeneratedSummary prints a task's design doc and review feedback (when
+// present) followed by the list of every file it produced, so a user can
+// review completed work without digging through the generated directory.
+func showGeneratedSummary(orch *orchestrator.Orchestrator, taskID string, head, tail int) error {
+	files, err := orch.ListGeneratedFiles(taskID)
+	if err != nil {
+		return fmt.Errorf("list generated files: %w", err)
+	}
+
+	printed := false
+	if data, err := orch.ReadGeneratedFile(taskID, "design.md"); err == nil {
+		fmt.Println("--- design.md ---")
+		fmt.Println(headTailLines(string(data), head, tail))
+		printed = true
+	}
+	if data, err := orch.ReadGeneratedFile(taskID, "review.md"); err == nil {
+		if printed {
+			fmt.Println()
+		}
+		fmt.Println("--- review.md ---")
+		fmt.Println(headTailLines(string(data), head, tail))
+		printed = true
 	}
 
+	if printed {
+		fmt.Println()
+	}
+	if len(files) == 0 {
+		fmt.Println("No generated output found")
+		return nil
+	}
+	fmt.Println("Generated files:")
+	for _, f := range files {
+		fmt.Printf("  %s\n", f)
+	}
+	return nil
+}
+
+// showGeneratedCode prints every file under the task's code/ directory.
+func showGeneratedCode(orch *orchestrator.Orchestrator, taskID string, head, tail int) error {
+	files, err := orch.ListGeneratedFiles(taskID)
+	if err != nil {
+		return fmt.Errorf("list generated files: %w", err)
+	}
+
+	var codeFiles []string
+	for _, f := range files {
+		if strings.HasPrefix(f, "code/") {
+			codeFiles = append(codeFiles, f)
+		}
+	}
+	if len(codeFiles) == 0 {
+		fmt.Println("No generated code found")
+		return nil
+	}
+
+	for i, f := range codeFiles {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("--- %s ---\n", strings.TrimPrefix(f, "code/"))
+		if err := showGeneratedFile(orch, taskID, f, head, tail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// showGeneratedFile prints a single generated file, optionally limited to
+// its first --head or last --tail lines. Files shorter than the requested
+// count are printed in full.
+func showGeneratedFile(orch *orchestrator.Orchestrator, taskID, rel string, head, tail int) error {
+	data, err := orch.ReadGeneratedFile(taskID, rel)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", rel, err)
+	}
+	fmt.Println(headTailLines(string(data), head, tail))
+	return nil
+}
+
+// headTailLines limits content to its first head or last tail lines
+// (whichever is set; tail takes precedence if both are). A count of 0 or a
+// file with fewer lines than requested returns the content unchanged.
+func headTailLines(content string, head, tail int) string {
+	if head <= 0 && tail <= 0 {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	if tail > 0 {
+		if tail < len(lines) {
+			lines = lines[len(lines)-tail:]
+		}
+		return strings.Join(lines, "\n")
+	}
+	if head < len(lines) {
+		lines = lines[:head]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// runDiagram loads a task's saved handoffs and renders them as a Mermaid or
+// Graphviz flowchart, so how the mob collaborated on it can be documented or
+// reviewed after the fact.
+func runDiagram(cmd *cobra.Command, args []string) error {
+	config := orchestrator.DefaultWorkflowConfig()
+	orch, err := orchestrator.New(config)
+	if err != nil {
+		return fmt.Errorf("initialize orchestrator: %w", err)
+	}
+
+	taskID := args[0]
+	task, err := orch.GetTask(taskID)
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+	handoffs, err := orch.GetHandoffs(taskID)
+	if err != nil {
+		return fmt.Errorf("get handoffs: %w", err)
+	}
+
+	result := types.WorkflowResult{Task: *task, Handoffs: handoffs}
+
+	var diagram string
+	switch strings.ToLower(diagramFormat) {
+	case "mermaid":
+		diagram = result.ToMermaid()
+	case "graphviz", "dot":
+		diagram = result.ToGraphviz()
+	default:
+		return fmt.Errorf("unknown diagram format %q (want mermaid or graphviz)", diagramFormat)
+	}
+
+	if diagramOutput == "" {
+		fmt.Print(diagram)
+		return nil
+	}
+	if err := os.WriteFile(diagramOutput, []byte(diagram), 0644); err != nil {
+		return fmt.Errorf("write diagram: %w", err)
+	}
+	fmt.Printf("Wrote %s\n", diagramOutput)
 	return nil
 }
 
@@ -301,22 +1164,191 @@ func runGUI(cmd *cobra.Command, args []string) error {
 
 func runTUI(cmd *cobra.Command, args []string) error {
 	demoMode, _ := cmd.Flags().GetBool("demo")
+	streamLog, _ := cmd.Flags().GetString("stream-log")
+	noSavePrefs, _ := cmd.Flags().GetBool("no-save-prefs")
+	theme, _ := cmd.Flags().GetString("theme")
+	noBell, _ := cmd.Flags().GetBool("no-bell")
+	stallTimeout, _ := cmd.Flags().GetDuration("stall-timeout")
+
+	if theme != "" {
+		if _, ok := styles.ByName(theme); !ok {
+			return fmt.Errorf("unknown theme %q (want one of: %s)", theme, strings.Join(styles.Names(), ", "))
+		}
+	}
 
 	// Create workflow stream for communication
 	workflowStream := stream.NewWorkflowStream()
 	defer workflowStream.Close()
 
+	task := ""
 	if demoMode {
 		// Run demo mode with simulated events
 		go demo.Run(workflowStream)
 	} else if len(args) > 0 {
 		// Run actual workflow with TUI
-		task := args[0]
+		task = args[0]
 		go runTUIWorkflow(workflowStream, task)
 	}
 
 	// Start TUI
-	return tui.Run(workflowStream)
+	return tui.RunWithStallTimeout(workflowStream, task, streamLog, !noSavePrefs, theme, !noBell, stallTimeout)
+}
+
+// runReplay loads a recorded JSONL event log and feeds it through the TUI
+// at the requested speed, reusing session.Manager's replay timing and
+// event-dispatch logic so recorded runs and saved TUI sessions replay
+// identically.
+func runReplay(cmd *cobra.Command, args []string) error {
+	speed, _ := cmd.Flags().GetFloat64("speed")
+	streamLog, _ := cmd.Flags().GetString("stream-log")
+	theme, _ := cmd.Flags().GetString("theme")
+
+	if speed <= 0 {
+		return fmt.Errorf("--speed must be positive")
+	}
+	if theme != "" {
+		if _, ok := styles.ByName(theme); !ok {
+			return fmt.Errorf("unknown theme %q (want one of: %s)", theme, strings.Join(styles.Names(), ", "))
+		}
+	}
+
+	recorded, err := session.LoadEventLogFile(args[0])
+	if err != nil {
+		return fmt.Errorf("load event log: %w", err)
+	}
+
+	workflowStream := stream.NewWorkflowStream()
+	defer workflowStream.Close()
+
+	manager, err := session.NewManager(tuiSessionDir())
+	if err != nil {
+		return fmt.Errorf("open session store: %w", err)
+	}
+
+	go func() {
+		_ = manager.Replay(recorded, workflowStream, speed)
+		workflowStream.SignalDone()
+	}()
+
+	return tui.RunWithThemeOptions(workflowStream, "", streamLog, false, theme)
+}
+
+// runKeys prints the TUI's effective key bindings (defaults merged with any
+// keybindings.json overrides, the same merge NewModelWithTask performs on
+// startup), followed by unrecognized override fields and conflicting keys.
+func runKeys(cmd *cobra.Command, args []string) error {
+	baseDir := os.Getenv("COOPERATIONS_DIR")
+	if baseDir == "" {
+		baseDir = ".cooperations"
+	}
+
+	overrides := tui.LoadKeyOverrides(baseDir)
+	keys := tui.ApplyKeyOverrides(tui.DefaultKeyMap(), overrides)
+
+	for _, cat := range keys.CategorizedHelp() {
+		fmt.Println(cat.Name)
+		for _, b := range cat.Bindings {
+			h := b.Help()
+			if h.Key == "" && h.Desc == "" {
+				continue
+			}
+			fmt.Printf("  %-14s %s\n", h.Key, h.Desc)
+		}
+	}
+
+	if unknown := tui.ValidateKeyOverrides(overrides); len(unknown) > 0 {
+		fmt.Println()
+		fmt.Printf("Unknown keybindings.json fields: %s\n", strings.Join(unknown, ", "))
+	}
+
+	if conflicts := tui.ConflictingBindings(keys); len(conflicts) > 0 {
+		fmt.Println()
+		fmt.Println("Conflicting bindings (same key, multiple fields):")
+		keysSorted := make([]string, 0, len(conflicts))
+		for k := range conflicts {
+			keysSorted = append(keysSorted, k)
+		}
+		sort.Strings(keysSorted)
+		for _, k := range keysSorted {
+			fmt.Printf("  %-14s %s\n", k, strings.Join(conflicts[k], ", "))
+		}
+	}
+
+	return nil
+}
+
+// runServe runs task through the workflow while an HTTP server streams its
+// events over SSE and accepts human decisions, so a browser dashboard can
+// observe and drive a run without the TUI/GUI. It shuts down cleanly on
+// Ctrl+C: the workflow's context is canceled first, then the HTTP server
+// once the workflow returns.
+func runServe(cmd *cobra.Command, args []string) error {
+	task := args[0]
+	addr, _ := cmd.Flags().GetString("addr")
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		generated, err := generateServeToken()
+		if err != nil {
+			return fmt.Errorf("generate auth token: %w", err)
+		}
+		token = generated
+		fmt.Fprintf(os.Stderr, "coop serve: generated auth token (required for /decision and /ws): %s\n", token)
+	}
+
+	workflowStream := stream.NewWorkflowStream()
+	defer workflowStream.Close()
+
+	orch, err := orchestrator.NewWithStream(orchestrator.DefaultWorkflowConfig(), workflowStream)
+	if err != nil {
+		return fmt.Errorf("initialize orchestrator: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	srv := server.New(orch.Events(), workflowStream, addr, token)
+	serverErrCh := make(chan error, 1)
+	go func() {
+		serverErrCh <- srv.ListenAndServe(ctx)
+	}()
+
+	fmt.Printf("Serving events at http://localhost%s/events (decisions at /decision)\n", addr)
+	result, err := orch.RunWithTags(ctx, task, taskTags)
+
+	cancel()
+	if serveErr := <-serverErrCh; serveErr != nil {
+		fmt.Fprintf(os.Stderr, "warning: http server: %v\n", serveErr)
+	}
+
+	if err != nil {
+		fmt.Printf("[FAILED] Task %s failed (%s): %s\n", result.Task.ID, result.AbortReason.Kind, result.Error)
+		return withExitCode(exitCodeForAbortReason(result.AbortReason.Kind), err)
+	}
+	fmt.Printf("[COMPLETE] Task %s completed successfully\n", result.Task.ID)
+	return nil
+}
+
+// generateServeToken returns a random hex-encoded token for `coop serve`'s
+// --token default, so the server never falls back to accepting
+// unauthenticated /decision and /ws requests just because the flag was
+// left unset.
+func generateServeToken() (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
 }
 
 func runTUIWorkflow(s *stream.WorkflowStream, task string) {
@@ -338,6 +1370,31 @@ func runTUIWorkflow(s *stream.WorkflowStream, task string) {
 		return
 	}
 
+	var rolePlan []types.Role
+	if plan, err := orch.Plan(task); err == nil {
+		s.SendPlan(stream.PlanUpdate{Plan: plan})
+
+		decision := s.RequestDecision(stream.DecisionRequest{
+			ID:            "plan-confirm-" + task,
+			Title:         "Confirm role sequence",
+			Prompt:        "Run the previewed role sequence as planned, or edit it (comma-separated roles, e.g. \"implementer,reviewer\" to skip architect)?",
+			Options:       stream.PlainOptions("Run as planned", "Edit sequence"),
+			DefaultAction: stream.DecisionApprove,
+		})
+		switch decision.Action {
+		case stream.DecisionReject:
+			s.SendToast(stream.ToastNotification{Level: "warning", Message: "Run cancelled before starting"})
+			s.SignalDone()
+			return
+		case stream.DecisionEdit:
+			if edited := parseRoleSequence(decision.Edited); len(edited) > 0 {
+				rolePlan = edited
+			} else {
+				s.SendToast(stream.ToastNotification{Level: "warning", Message: "No roles parsed from edit, running as planned"})
+			}
+		}
+	}
+
 	// Setup context
 	ctx := context.Background()
 
@@ -347,8 +1404,9 @@ func runTUIWorkflow(s *stream.WorkflowStream, task string) {
 		Message: "Running task: " + task,
 	})
 
-	// Run the workflow
-	result, err := orch.Run(ctx, task)
+	// Run the workflow, following rolePlan instead of the default
+	// progression when the director edited the previewed sequence.
+	result, err := orch.RunWithPlan(ctx, task, nil, rolePlan)
 	if err != nil {
 		s.SendError(err)
 		return
@@ -369,6 +1427,92 @@ func runTUIWorkflow(s *stream.WorkflowStream, task string) {
 	s.SignalDone()
 }
 
+// parseRoleSequence parses a comma- or whitespace-separated list of role
+// names (as typed into the TUI's plan-edit dialog) into a role sequence for
+// Orchestrator.RunWithPlan. Unrecognized tokens are dropped rather than
+// rejecting the whole edit; RunWithPlan validates the result has an agent
+// for every role before running anything.
+func parseRoleSequence(input string) []types.Role {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	roles := make([]types.Role, 0, len(fields))
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		roles = append(roles, types.Role(f))
+	}
+	return roles
+}
+
+// tuiSessionDir returns the directory the TUI persists sessions in,
+// mirroring the default used by tui.Model.
+func tuiSessionDir() string {
+	dir := os.Getenv("COOPERATIONS_DIR")
+	if dir == "" {
+		dir = ".cooperations"
+	}
+	return filepath.Join(dir, "tui_sessions")
+}
+
+func listSessions(cmd *cobra.Command, args []string) error {
+	manager, err := session.NewManager(tuiSessionDir())
+	if err != nil {
+		return fmt.Errorf("open session store: %w", err)
+	}
+
+	sessions, err := manager.List()
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No sessions found")
+		return nil
+	}
+
+	for _, s := range sessions {
+		fmt.Printf("  %s  [%s]  %s\n", s.ID, s.Status, truncate(s.Task, 50))
+	}
+	return nil
+}
+
+func removeSession(idOrPrefix string, force bool) error {
+	manager, err := session.NewManager(tuiSessionDir())
+	if err != nil {
+		return fmt.Errorf("open session store: %w", err)
+	}
+
+	id, err := manager.ResolveSessionID(idOrPrefix)
+	if err != nil {
+		return err
+	}
+
+	if !force && !confirm(fmt.Sprintf("Delete session %s? [y/N] ", id)) {
+		fmt.Println("Aborted")
+		return nil
+	}
+
+	if err := manager.Delete(id); err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	fmt.Printf("Moved session %s to trash\n", id)
+	return nil
+}
+
+// confirm prompts the user with prompt and returns true for a "y"/"yes" answer.
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 func printTaskInfo(id, status, createdAt, description string) {
 	fmt.Printf("Task: %s\n", id)
 	fmt.Printf("Status: %s\n", status)
@@ -377,8 +1521,152 @@ func printTaskInfo(id, status, createdAt, description string) {
 }
 
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
 		return s
 	}
-	return s[:maxLen] + "..."
+	return string(runes[:maxLen]) + "..."
+}
+
+// runCompare loads two TUI sessions (or, with --task, two task runs) and
+// prints a per-file diff of their final code artifacts followed by a
+// metrics comparison, so a user can quantify the effect of a prompt or
+// model change across runs of the same task.
+func runCompare(cmd *cobra.Command, args []string) error {
+	byTask, _ := cmd.Flags().GetBool("task")
+	if byTask {
+		return compareTasks(args[0], args[1])
+	}
+	return compareSessions(args[0], args[1])
+}
+
+func compareSessions(idA, idB string) error {
+	manager, err := session.NewManager(tuiSessionDir())
+	if err != nil {
+		return fmt.Errorf("open session store: %w", err)
+	}
+
+	a, err := loadSessionByPrefix(manager, idA)
+	if err != nil {
+		return err
+	}
+	b, err := loadSessionByPrefix(manager, idB)
+	if err != nil {
+		return err
+	}
+
+	printFileDiffs(session.FinalCodeArtifacts(a), session.FinalCodeArtifacts(b), a.ID, b.ID)
+
+	fmt.Println("\n--- Metrics ---")
+	fmt.Printf("%-20s %15s %15s\n", "", a.ID, b.ID)
+	fmt.Printf("%-20s %15d %15d\n", "Total tokens", a.Metrics.TotalTokens, b.Metrics.TotalTokens)
+	fmt.Printf("%-20s %15.4f %15.4f\n", "Estimated cost", a.Metrics.EstimatedCostUSD, b.Metrics.EstimatedCostUSD)
+	fmt.Printf("%-20s %15d %15d\n", "Agent cycles", a.Metrics.AgentCycles, b.Metrics.AgentCycles)
+	fmt.Printf("%-20s %15d %15d\n", "Handoffs", a.Metrics.HandoffCount, b.Metrics.HandoffCount)
+	fmt.Printf("%-20s %15s %15s\n", "Duration", a.Metrics.Duration.Round(time.Second), b.Metrics.Duration.Round(time.Second))
+	return nil
+}
+
+func loadSessionByPrefix(manager *session.Manager, idOrPrefix string) (*session.Session, error) {
+	id, err := manager.ResolveSessionID(idOrPrefix)
+	if err != nil {
+		return nil, err
+	}
+	s, err := manager.Load(id)
+	if err != nil {
+		return nil, fmt.Errorf("load session %s: %w", id, err)
+	}
+	return s, nil
+}
+
+func compareTasks(taskA, taskB string) error {
+	config := orchestrator.DefaultWorkflowConfig()
+	orch, err := orchestrator.New(config)
+	if err != nil {
+		return fmt.Errorf("initialize orchestrator: %w", err)
+	}
+
+	codeA, err := readGeneratedCode(orch, taskA)
+	if err != nil {
+		return err
+	}
+	codeB, err := readGeneratedCode(orch, taskB)
+	if err != nil {
+		return err
+	}
+
+	printFileDiffs(codeA, codeB, taskA, taskB)
+	return nil
+}
+
+// readGeneratedCode reads every file under a task's generated code/
+// directory, keyed by its path relative to code/ so it lines up with the
+// other task's files of the same name.
+func readGeneratedCode(orch *orchestrator.Orchestrator, taskID string) (map[string]string, error) {
+	files, err := orch.ListGeneratedFiles(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list generated files for %s: %w", taskID, err)
+	}
+
+	code := make(map[string]string)
+	for _, f := range files {
+		if !strings.HasPrefix(f, "code/") {
+			continue
+		}
+		data, err := orch.ReadGeneratedFile(taskID, f)
+		if err != nil {
+			return nil, fmt.Errorf("read %s for %s: %w", f, taskID, err)
+		}
+		code[strings.TrimPrefix(f, "code/")] = string(data)
+	}
+	return code, nil
+}
+
+// printFileDiffs prints a unified-style diff (same "+"/"-" line convention
+// as the TUI's diff view) for every file touched by either side, over the
+// union of paths present in either.
+func printFileDiffs(a, b map[string]string, labelA, labelB string) {
+	paths := make(map[string]struct{}, len(a)+len(b))
+	for p := range a {
+		paths[p] = struct{}{}
+	}
+	for p := range b {
+		paths[p] = struct{}{}
+	}
+	sortedPaths := make([]string, 0, len(paths))
+	for p := range paths {
+		sortedPaths = append(sortedPaths, p)
+	}
+	sort.Strings(sortedPaths)
+
+	if len(sortedPaths) == 0 {
+		fmt.Println("No code artifacts found in either run")
+		return
+	}
+
+	fmt.Printf("Comparing %s -> %s\n", labelA, labelB)
+	for _, path := range sortedPaths {
+		oldContent, newContent := a[path], b[path]
+		if oldContent == newContent {
+			continue
+		}
+		fmt.Printf("\n--- %s ---\n", path)
+		if oldContent == "" {
+			fmt.Println("(new file)")
+		} else if newContent == "" {
+			fmt.Println("(removed)")
+		}
+		for _, line := range strings.Split(oldContent, "\n") {
+			if line == "" && oldContent == "" {
+				continue
+			}
+			fmt.Println("-" + line)
+		}
+		for _, line := range strings.Split(newContent, "\n") {
+			if line == "" && newContent == "" {
+				continue
+			}
+			fmt.Println("+" + line)
+		}
+	}
 }
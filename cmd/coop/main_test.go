@@ -0,0 +1,194 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		maxLen int
+		want   string
+	}{
+		{
+			name:   "fits",
+			input:  "hello",
+			maxLen: 10,
+			want:   "hello",
+		},
+		{
+			name:   "ascii truncated",
+			input:  "hello world",
+			maxLen: 5,
+			want:   "hello...",
+		},
+		{
+			name:   "CJK truncated keeps whole runes",
+			input:  "你好世界，这是一个测试",
+			maxLen: 4,
+			want:   "你好世界...",
+		},
+		{
+			name:   "emoji truncated keeps whole runes",
+			input:  "🎉🎉🎉🎉🎉 party",
+			maxLen: 3,
+			want:   "🎉🎉🎉...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.input, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.input, tt.maxLen, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "no fence returns content unchanged",
+			content: "just plain text, no code block here",
+			want:    "just plain text, no code block here",
+		},
+		{
+			name:    "single fenced block",
+			content: "Here you go:\n```go\nfmt.Println(\"hi\")\n```\nHope that helps.",
+			want:    "fmt.Println(\"hi\")",
+		},
+		{
+			name: "multiple fenced blocks are concatenated",
+			content: "main.go:\n```go\npackage main\n```\n" +
+				"helper.go:\n```go\npackage helper\n```",
+			want: "package main\n\npackage helper",
+		},
+		{
+			name: "fence with embedded backtick sequence stays intact",
+			content: "````markdown\n" +
+				"Use a fenced block like:\n" +
+				"```\n" +
+				"code here\n" +
+				"```\n" +
+				"````",
+			want: "Use a fenced block like:\n```\ncode here\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractCode(tt.content)
+			if got != tt.want {
+				t.Errorf("extractCode(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteOutputFile(t *testing.T) {
+	t.Run("overwrite replaces existing content", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "out.go")
+		if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeOutputFile(path, "new", "overwrite", "task-1"); err != nil {
+			t.Fatal(err)
+		}
+		got, _ := os.ReadFile(path)
+		if string(got) != "new" {
+			t.Errorf("content = %q, want %q", got, "new")
+		}
+	})
+
+	t.Run("append adds a separator with the task ID", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "out.go")
+		if err := os.WriteFile(path, []byte("first"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeOutputFile(path, "second", "append", "task-42"); err != nil {
+			t.Fatal(err)
+		}
+		got, _ := os.ReadFile(path)
+		if !strings.HasPrefix(string(got), "first") {
+			t.Errorf("append dropped prior content: %q", got)
+		}
+		if !strings.Contains(string(got), "task-42") {
+			t.Errorf("append separator missing task ID: %q", got)
+		}
+		if !strings.HasSuffix(string(got), "second\n") {
+			t.Errorf("appended content missing: %q", got)
+		}
+	})
+
+	t.Run("error-if-exists fails on an existing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "out.go")
+		if err := os.WriteFile(path, []byte("first"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeOutputFile(path, "second", "error-if-exists", "task-1"); err == nil {
+			t.Error("expected error when file already exists, got nil")
+		}
+	})
+
+	t.Run("error-if-exists writes when file is absent", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "out.go")
+		if err := writeOutputFile(path, "content", "error-if-exists", "task-1"); err != nil {
+			t.Fatal(err)
+		}
+		got, _ := os.ReadFile(path)
+		if string(got) != "content" {
+			t.Errorf("content = %q, want %q", got, "content")
+		}
+	})
+
+	t.Run("unknown mode is an error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "out.go")
+		if err := writeOutputFile(path, "content", "bogus", "task-1"); err == nil {
+			t.Error("expected error for unknown mode, got nil")
+		}
+	})
+}
+
+func TestHasTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		tag  string
+		want bool
+	}{
+		{"present", []string{"backend", "urgent"}, "urgent", true},
+		{"absent", []string{"backend"}, "urgent", false},
+		{"empty tags", nil, "urgent", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasTag(tt.tags, tt.tag); got != tt.want {
+				t.Errorf("hasTag(%v, %q) = %v, want %v", tt.tags, tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractCodeFencesLanguage(t *testing.T) {
+	content := "```python\nprint(1)\n```"
+	fences := extractCodeFences(content)
+	if len(fences) != 1 {
+		t.Fatalf("extractCodeFences(%q) returned %d fences, want 1", content, len(fences))
+	}
+	if fences[0].Language != "python" {
+		t.Errorf("fence language = %q, want %q", fences[0].Language, "python")
+	}
+	if strings.TrimSpace(fences[0].Content) != "print(1)" {
+		t.Errorf("fence content = %q, want %q", fences[0].Content, "print(1)")
+	}
+}
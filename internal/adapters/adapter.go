@@ -15,3 +15,15 @@ type CLI interface {
 	// Name returns the CLI identifier.
 	Name() string
 }
+
+// StreamingCLI is implemented by adapters that can surface output as it's
+// produced, instead of only returning a final batch response. onToken is
+// called once per incremental chunk of assistant text, in order; the
+// returned CLIResponse carries the same fields Execute would have returned.
+type StreamingCLI interface {
+	CLI
+
+	// ExecuteStream runs the CLI with the given prompt, invoking onToken for
+	// each incremental chunk of output as it arrives on the CLI's stdout.
+	ExecuteStream(ctx context.Context, prompt string, onToken func(string)) (types.CLIResponse, error)
+}
@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -24,6 +25,12 @@ type ClaudeCLI struct {
 	timeout    time.Duration
 }
 
+func init() {
+	Register("claude-cli", func(repoRoot string, config any) (CLI, error) {
+		return NewClaudeCLI()
+	})
+}
+
 // NewClaudeCLI creates a new Claude CLI executor.
 func NewClaudeCLI() (*ClaudeCLI, error) {
 	binaryPath := os.Getenv("CLAUDE_CLI_PATH")
@@ -99,10 +106,105 @@ func (c *ClaudeCLI) Execute(ctx context.Context, prompt string) (types.CLIRespon
 	resp.Content = cliResp.Result
 	resp.Model = "claude-cli"
 	resp.TokensUsed = cliResp.totalTokens()
+	resp.PromptTokens = cliResp.Usage.InputTokens
+	resp.CompletionTokens = cliResp.Usage.OutputTokens
+
+	return resp, nil
+}
+
+// ExecuteStream runs Claude CLI in streaming JSON mode, invoking onToken
+// for each incremental text chunk as it arrives on stdout.
+func (c *ClaudeCLI) ExecuteStream(ctx context.Context, prompt string, onToken func(string)) (types.CLIResponse, error) {
+	var resp types.CLIResponse
+
+	execCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// Build command:
+	// claude -p "prompt" --output-format stream-json --max-turns 1
+	cmd := exec.CommandContext(execCtx, c.binaryPath,
+		"-p", prompt,
+		"--output-format", "stream-json",
+		"--max-turns", "1",
+	)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return resp, fmt.Errorf("claude CLI stdout pipe: %w", err)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return resp, fmt.Errorf("claude CLI start: %w", err)
+	}
+
+	var final claudeCLIResponse
+	var haveFinal bool
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var event claudeStreamEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			continue // Skip malformed lines
+		}
+
+		switch event.Type {
+		case "assistant":
+			for _, block := range event.Message.Content {
+				if block.Type == "text" && block.Text != "" {
+					onToken(block.Text)
+				}
+			}
+		case "result":
+			if err := json.Unmarshal(line, &final); err == nil {
+				haveFinal = true
+			}
+		}
+	}
+
+	waitErr := cmd.Wait()
+	if waitErr != nil {
+		if execCtx.Err() == context.DeadlineExceeded {
+			return resp, fmt.Errorf("claude CLI timed out after %v", c.timeout)
+		}
+		return resp, fmt.Errorf("claude CLI failed: %w\nstderr: %s", waitErr, stderr.String())
+	}
+
+	if !haveFinal {
+		return resp, fmt.Errorf("claude CLI returned no result event")
+	}
+	if final.IsError || final.Subtype == "error" {
+		return resp, fmt.Errorf("claude CLI returned error: %s", final.Result)
+	}
+
+	resp.Content = final.Result
+	resp.Model = "claude-cli"
+	resp.TokensUsed = final.totalTokens()
+	resp.PromptTokens = final.Usage.InputTokens
+	resp.CompletionTokens = final.Usage.OutputTokens
 
 	return resp, nil
 }
 
+// claudeStreamEvent represents one line of Claude CLI's --output-format
+// stream-json output: either an incremental assistant message or the final
+// result summary (parsed separately into claudeCLIResponse).
+type claudeStreamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	} `json:"message"`
+}
+
 // claudeCLIResponse represents the JSON output from Claude Code CLI.
 type claudeCLIResponse struct {
 	Type        string         `json:"type"`
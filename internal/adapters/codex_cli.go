@@ -27,6 +27,12 @@ type CodexCLI struct {
 	workDir    string // Repository root directory
 }
 
+func init() {
+	Register("codex-cli", func(repoRoot string, config any) (CLI, error) {
+		return NewCodexCLI(repoRoot)
+	})
+}
+
 // NewCodexCLI creates a new Codex CLI executor with full agentic access.
 func NewCodexCLI(workDir string) (*CodexCLI, error) {
 	binaryPath := os.Getenv("CODEX_CLI_PATH")
@@ -73,7 +79,7 @@ func (c *CodexCLI) Execute(ctx context.Context, prompt string) (types.CLIRespons
 		"--full-auto",
 		"--sandbox", "workspace-write",
 		"--ask-for-approval", "never",
-		"-C", c.workDir,                              // Working directory = repo root
+		"-C", c.workDir, // Working directory = repo root
 	)
 
 	var stdout, stderr bytes.Buffer
@@ -95,7 +101,7 @@ func (c *CodexCLI) Execute(ctx context.Context, prompt string) (types.CLIRespons
 	}
 
 	var content strings.Builder
-	var tokensUsed int
+	var tokensUsed, promptTokens, completionTokens int
 
 	scanner := bufio.NewScanner(strings.NewReader(output))
 	for scanner.Scan() {
@@ -121,6 +127,8 @@ func (c *CodexCLI) Execute(ctx context.Context, prompt string) (types.CLIRespons
 		case "turn.completed":
 			// Extract token usage
 			tokensUsed = event.Usage.InputTokens + event.Usage.OutputTokens
+			promptTokens = event.Usage.InputTokens
+			completionTokens = event.Usage.OutputTokens
 		}
 	}
 
@@ -132,6 +140,89 @@ func (c *CodexCLI) Execute(ctx context.Context, prompt string) (types.CLIRespons
 	}
 	resp.Model = "codex-cli"
 	resp.TokensUsed = tokensUsed
+	resp.PromptTokens = promptTokens
+	resp.CompletionTokens = completionTokens
+
+	return resp, nil
+}
+
+// ExecuteStream runs Codex CLI the same way Execute does, but reads its
+// JSONL stdout as the process produces it instead of buffering the whole
+// run, invoking onToken for each agent_message chunk as it's parsed.
+func (c *CodexCLI) ExecuteStream(ctx context.Context, prompt string, onToken func(string)) (types.CLIResponse, error) {
+	var resp types.CLIResponse
+
+	execCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(execCtx, c.binaryPath,
+		"exec", prompt,
+		"--json",
+		"--full-auto",
+		"--sandbox", "workspace-write",
+		"--ask-for-approval", "never",
+		"-C", c.workDir, // Working directory = repo root
+	)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return resp, fmt.Errorf("codex CLI stdout pipe: %w", err)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return resp, fmt.Errorf("codex CLI start: %w", err)
+	}
+
+	var content strings.Builder
+	var tokensUsed, promptTokens, completionTokens int
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		var event codexCLIEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			continue // Skip malformed lines
+		}
+
+		switch event.Type {
+		case "item.completed":
+			if event.Item.Type == "agent_message" && event.Item.Text != "" {
+				chunk := event.Item.Text
+				if content.Len() > 0 {
+					chunk = "\n" + chunk
+				}
+				content.WriteString(chunk)
+				onToken(chunk)
+			}
+		case "turn.completed":
+			tokensUsed = event.Usage.InputTokens + event.Usage.OutputTokens
+			promptTokens = event.Usage.InputTokens
+			completionTokens = event.Usage.OutputTokens
+		}
+	}
+
+	waitErr := cmd.Wait()
+	if waitErr != nil {
+		if execCtx.Err() == context.DeadlineExceeded {
+			return resp, fmt.Errorf("codex CLI timed out after %v", c.timeout)
+		}
+		return resp, fmt.Errorf("codex CLI failed: %w\nstderr: %s", waitErr, stderr.String())
+	}
+
+	if content.Len() == 0 {
+		return resp, fmt.Errorf("codex CLI returned empty output")
+	}
+	resp.Content = content.String()
+	resp.Model = "codex-cli"
+	resp.TokensUsed = tokensUsed
+	resp.PromptTokens = promptTokens
+	resp.CompletionTokens = completionTokens
 
 	return resp, nil
 }
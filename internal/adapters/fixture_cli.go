@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"cooperations/internal/types"
+)
+
+// FixtureCLI implements the CLI interface by replaying pre-recorded
+// responses from disk instead of shelling out to a real model. It is
+// selected with `provider: fixture` in a model profile and a `path`
+// pointing at the fixture directory produced by RecordingCLI.
+//
+// Responses are keyed by role and call order, so replaying a fixture
+// directory drives the orchestrator (hooks, RVR, file writes) through
+// exactly the same sequence of handoffs as the recorded run, offline and
+// deterministically.
+type FixtureCLI struct {
+	dir  string
+	role types.Role
+
+	mu    sync.Mutex
+	calls int
+}
+
+// NewFixtureCLI creates a fixture-backed CLI that replays recordings for
+// role from dir.
+func NewFixtureCLI(role types.Role, dir string) (*FixtureCLI, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("fixture directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("fixture path is not a directory: %s", dir)
+	}
+	return &FixtureCLI{dir: dir, role: role}, nil
+}
+
+// Name returns the CLI identifier.
+func (f *FixtureCLI) Name() string {
+	return "fixture"
+}
+
+// Execute returns the next recorded response for this role, in order.
+func (f *FixtureCLI) Execute(ctx context.Context, prompt string) (types.CLIResponse, error) {
+	f.mu.Lock()
+	f.calls++
+	step := f.calls
+	f.mu.Unlock()
+
+	path := fixtureFilePath(f.dir, f.role, step)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return types.CLIResponse{}, fmt.Errorf("fixture: no recorded response for %s step %d: %w", f.role, step, err)
+	}
+
+	var resp types.CLIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return types.CLIResponse{}, fmt.Errorf("fixture: parse %s: %w", path, err)
+	}
+	return resp, nil
+}
+
+// fixtureFilePath returns the on-disk path for a role's Nth recorded call.
+func fixtureFilePath(dir string, role types.Role, step int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s-%03d.json", role, step))
+}
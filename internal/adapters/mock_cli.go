@@ -0,0 +1,138 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"cooperations/internal/types"
+)
+
+// MockConfig configures a "mock" provider profile: canned or scripted
+// responses returned instead of shelling out to a real model, so the
+// orchestrator can be driven end-to-end offline and deterministically
+// (e.g. in CI, without Claude/Codex CLIs installed).
+type MockConfig struct {
+	// Responses are returned in order, one per call; once exhausted, the
+	// last entry repeats. Leave empty to fall back to a single generic
+	// canned response.
+	Responses []string `json:"responses" yaml:"responses"`
+
+	// NextRole, when set, is appended as a trailing "NEXT: <role>" line so
+	// the mock drives the same handoff routing a real agent's output
+	// would (e.g. "implementer", or "done" to end the workflow).
+	NextRole string `json:"next_role" yaml:"next_role"`
+
+	// TokensPerCall sets CLIResponse.TokensUsed (split 60/40 into
+	// PromptTokens/CompletionTokens) for each call, so token and cost
+	// metrics paths have realistic, non-zero data to exercise. Defaults
+	// to 500 when zero.
+	TokensPerCall int `json:"tokens_per_call" yaml:"tokens_per_call"`
+}
+
+const defaultMockTokensPerCall = 500
+
+func init() {
+	Register("mock", func(repoRoot string, config any) (CLI, error) {
+		cfg, err := decodeMockConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("decode mock provider config: %w", err)
+		}
+		return NewMockCLI(cfg), nil
+	})
+}
+
+// decodeMockConfig round-trips config (typically a map[string]any decoded
+// from YAML) through JSON into a MockConfig. A nil config yields the zero
+// value, i.e. the default canned response.
+func decodeMockConfig(config any) (MockConfig, error) {
+	var cfg MockConfig
+	if config == nil {
+		return cfg, nil
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// MockCLI implements the CLI interface with canned or scripted responses,
+// for exercising the orchestrator's full workflow (routing, RVR, metrics,
+// file writes) without a real model behind it.
+type MockCLI struct {
+	responses []string
+	nextRole  string
+	tokens    int
+
+	mu    sync.Mutex
+	calls int
+}
+
+// NewMockCLI creates a mock CLI adapter from cfg.
+func NewMockCLI(cfg MockConfig) *MockCLI {
+	tokens := cfg.TokensPerCall
+	if tokens == 0 {
+		tokens = defaultMockTokensPerCall
+	}
+	return &MockCLI{responses: cfg.Responses, nextRole: cfg.NextRole, tokens: tokens}
+}
+
+// Name returns the CLI identifier.
+func (m *MockCLI) Name() string {
+	return "mock"
+}
+
+// Execute returns the next scripted response, or a generic canned one when
+// none are configured.
+func (m *MockCLI) Execute(ctx context.Context, prompt string) (types.CLIResponse, error) {
+	m.mu.Lock()
+	step := m.calls
+	m.calls++
+	m.mu.Unlock()
+
+	content := m.responseAt(step)
+
+	return types.CLIResponse{
+		Content:          content,
+		TokensUsed:       m.tokens,
+		Model:            "mock",
+		PromptTokens:     m.tokens * 6 / 10,
+		CompletionTokens: m.tokens * 4 / 10,
+	}, nil
+}
+
+// ExecuteStream implements StreamingCLI by delivering the whole response as
+// a single token, then returning it exactly as Execute would.
+func (m *MockCLI) ExecuteStream(ctx context.Context, prompt string, onToken func(string)) (types.CLIResponse, error) {
+	resp, err := m.Execute(ctx, prompt)
+	if err != nil {
+		return resp, err
+	}
+	if onToken != nil {
+		onToken(resp.Content)
+	}
+	return resp, nil
+}
+
+// responseAt returns the configured response for the given call index,
+// repeating the last one once the scripted list is exhausted, with the
+// configured NEXT line appended.
+func (m *MockCLI) responseAt(step int) string {
+	content := "Mock response."
+	if len(m.responses) > 0 {
+		idx := step
+		if idx >= len(m.responses) {
+			idx = len(m.responses) - 1
+		}
+		content = m.responses[idx]
+	}
+	if m.nextRole != "" {
+		content += fmt.Sprintf("\nNEXT: %s", m.nextRole)
+	}
+	return content
+}
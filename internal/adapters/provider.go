@@ -0,0 +1,54 @@
+package adapters
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ProviderFactory builds a CLI adapter for a model profile. repoRoot is
+// passed through for adapters that need a working directory (e.g. the
+// Codex CLI); config is the provider-specific settings block from that
+// profile.
+type ProviderFactory func(repoRoot string, config any) (CLI, error)
+
+var providers = map[string]ProviderFactory{}
+
+// Register adds a provider factory under name. Built-in providers call this
+// from their own init(), so adding a new backend (an HTTP API, say) is a
+// matter of registering a factory rather than editing the orchestrator's
+// provider switch.
+func Register(name string, factory ProviderFactory) {
+	providers[name] = factory
+}
+
+// NewCLI builds a CLI adapter for the given provider name, or returns an
+// error listing the providers that are actually registered.
+func NewCLI(providerName, repoRoot string, config any) (CLI, error) {
+	factory, ok := providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider: %s (available: %s)", providerName, joinProviders())
+	}
+	return factory(repoRoot, config)
+}
+
+// AvailableProviders returns the names of all registered providers, sorted.
+func AvailableProviders() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func joinProviders() string {
+	names := AvailableProviders()
+	if len(names) == 0 {
+		return "none registered"
+	}
+	out := names[0]
+	for _, name := range names[1:] {
+		out += ", " + name
+	}
+	return out
+}
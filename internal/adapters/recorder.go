@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"cooperations/internal/types"
+)
+
+// RecordingCLI wraps another CLI and writes every response it returns to
+// disk in the same layout FixtureCLI expects, so a real run against Claude
+// or Codex can be captured once and replayed offline later.
+type RecordingCLI struct {
+	cli  CLI
+	dir  string
+	role types.Role
+
+	mu    sync.Mutex
+	calls int
+}
+
+// NewRecordingCLI wraps cli so its responses for role are recorded under dir.
+func NewRecordingCLI(cli CLI, role types.Role, dir string) (*RecordingCLI, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create fixture directory: %w", err)
+	}
+	return &RecordingCLI{cli: cli, dir: dir, role: role}, nil
+}
+
+// Name returns the wrapped CLI's identifier.
+func (r *RecordingCLI) Name() string {
+	return r.cli.Name()
+}
+
+// Execute runs the wrapped CLI and records its response before returning it.
+func (r *RecordingCLI) Execute(ctx context.Context, prompt string) (types.CLIResponse, error) {
+	resp, err := r.cli.Execute(ctx, prompt)
+	if err != nil {
+		return resp, err
+	}
+
+	r.mu.Lock()
+	r.calls++
+	step := r.calls
+	r.mu.Unlock()
+
+	data, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return resp, fmt.Errorf("record response: %w", err)
+	}
+	if err := os.WriteFile(fixtureFilePath(r.dir, r.role, step), data, 0644); err != nil {
+		return resp, fmt.Errorf("write fixture: %w", err)
+	}
+
+	return resp, nil
+}
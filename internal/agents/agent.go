@@ -2,9 +2,11 @@
 package agents
 
 import (
+	"bytes"
 	"context"
 	"regexp"
 	"strings"
+	"text/template"
 
 	"cooperations/internal/types"
 )
@@ -18,6 +20,52 @@ type Agent interface {
 	Execute(ctx context.Context, handoff types.Handoff) (types.AgentResponse, error)
 }
 
+// StreamingAgent is implemented by agents whose underlying CLI can stream
+// output incrementally. Callers should type-assert an Agent against this
+// interface and fall back to Execute when it doesn't implement it.
+type StreamingAgent interface {
+	Agent
+
+	// ExecuteStream runs the agent, invoking onToken for each incremental
+	// chunk of output as the underlying CLI produces it.
+	ExecuteStream(ctx context.Context, handoff types.Handoff, onToken func(string)) (types.AgentResponse, error)
+}
+
+// PromptContext supplies the template variables available to a
+// user-configured system prompt override (see effectiveSystemPrompt):
+// {{.Task}} and {{.FilesInScope}}.
+type PromptContext struct {
+	Task         string
+	FilesInScope []string
+}
+
+// effectiveSystemPrompt returns builtin unchanged when override is empty
+// (the common case: no config override for this role), otherwise renders
+// override as a Go template against handoff's task context. A template
+// that fails to parse or execute is used verbatim rather than failing the
+// agent run - a malformed override should degrade to a literal (if odd)
+// system prompt, not break the workflow.
+func effectiveSystemPrompt(builtin, override string, handoff types.Handoff) string {
+	if override == "" {
+		return builtin
+	}
+
+	tmpl, err := template.New("system_prompt").Parse(override)
+	if err != nil {
+		return override
+	}
+
+	var buf bytes.Buffer
+	ctx := PromptContext{
+		Task:         handoff.Context.TaskDescription,
+		FilesInScope: handoff.Context.FilesInScope,
+	}
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return override
+	}
+	return buf.String()
+}
+
 // buildClaudePrompt constructs a full prompt for Claude CLI agents.
 // Includes system prompt, artifacts, and task.
 func buildClaudePrompt(systemPrompt string, handoff types.Handoff) string {
@@ -27,6 +75,12 @@ func buildClaudePrompt(systemPrompt string, handoff types.Handoff) string {
 	b.WriteString(systemPrompt)
 	b.WriteString("\n\n")
 
+	if handoff.Context.ReferenceContext != "" {
+		b.WriteString("## Reference Material\n")
+		b.WriteString(handoff.Context.ReferenceContext)
+		b.WriteString("\n")
+	}
+
 	// Previous artifacts
 	if handoff.Artifacts.DesignDoc != "" {
 		b.WriteString("## Design Document\n")
@@ -82,9 +136,24 @@ func buildClaudePrompt(systemPrompt string, handoff types.Handoff) string {
 
 // buildCodexPrompt constructs a direct prompt for Codex CLI.
 // Keep it simple - Codex works best with clear, direct instructions.
-func buildCodexPrompt(handoff types.Handoff) string {
+// systemPrompt, when non-empty (a config override rendered by
+// effectiveSystemPrompt), is prepended ahead of the task - Codex has no
+// separate system-prompt channel, so it just becomes the first thing in
+// the prompt instead.
+func buildCodexPrompt(systemPrompt string, handoff types.Handoff) string {
 	var b strings.Builder
 
+	if systemPrompt != "" {
+		b.WriteString(systemPrompt)
+		b.WriteString("\n\n")
+	}
+
+	if handoff.Context.ReferenceContext != "" {
+		b.WriteString("Reference material:\n")
+		b.WriteString(handoff.Context.ReferenceContext)
+		b.WriteString("\n")
+	}
+
 	// Direct task - no preamble
 	b.WriteString(handoff.Context.TaskDescription)
 
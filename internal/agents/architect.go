@@ -21,16 +21,24 @@ Provide your design in a clear, structured format including:
 1. Overview of the approach
 2. Key interfaces/types (in Go)
 3. File structure if applicable
-4. Any important constraints or considerations`
+4. Any important constraints or considerations
+
+List each concrete requirement the implementer must satisfy as its own
+line: REQUIREMENT: <text>
+List each constraint the implementer must respect as its own line:
+CONSTRAINT: <text>`
 
 // ArchitectAgent handles system design tasks using Claude CLI.
 type ArchitectAgent struct {
-	cli *adapters.ClaudeCLI
+	cli            *adapters.ClaudeCLI
+	promptOverride string
 }
 
 // NewArchitectAgent creates a new Architect agent with Claude CLI.
-func NewArchitectAgent(cli *adapters.ClaudeCLI) *ArchitectAgent {
-	return &ArchitectAgent{cli: cli}
+// promptOverride, when non-empty, replaces architectSystemPrompt; it may
+// reference {{.Task}} and {{.FilesInScope}} (see effectiveSystemPrompt).
+func NewArchitectAgent(cli *adapters.ClaudeCLI, promptOverride string) *ArchitectAgent {
+	return &ArchitectAgent{cli: cli, promptOverride: promptOverride}
 }
 
 // Role returns the agent's role.
@@ -42,7 +50,7 @@ func (a *ArchitectAgent) Role() types.Role {
 func (a *ArchitectAgent) Execute(ctx context.Context, handoff types.Handoff) (types.AgentResponse, error) {
 	start := time.Now()
 
-	prompt := buildClaudePrompt(architectSystemPrompt, handoff)
+	prompt := buildClaudePrompt(effectiveSystemPrompt(architectSystemPrompt, a.promptOverride, handoff), handoff)
 
 	resp, err := a.cli.Execute(ctx, prompt)
 	if err != nil {
@@ -51,11 +59,54 @@ func (a *ArchitectAgent) Execute(ctx context.Context, handoff types.Handoff) (ty
 
 	nextRole := parseNextRole(resp.Content)
 
+	artifacts := map[string]any{"design_doc": resp.Content}
+	if reqs := parseRequirements(resp.Content); len(reqs) > 0 {
+		artifacts["requirements"] = reqs
+	}
+	if cons := parseConstraints(resp.Content); len(cons) > 0 {
+		artifacts["constraints"] = cons
+	}
+
+	return types.AgentResponse{
+		Content:          resp.Content,
+		Artifacts:        artifacts,
+		TokensUsed:       resp.TokensUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		DurationMS:       time.Since(start).Milliseconds(),
+		NextRole:         nextRole,
+	}, nil
+}
+
+// ExecuteStream runs the architect agent, forwarding incremental output
+// through onToken as Claude CLI produces it.
+func (a *ArchitectAgent) ExecuteStream(ctx context.Context, handoff types.Handoff, onToken func(string)) (types.AgentResponse, error) {
+	start := time.Now()
+
+	prompt := buildClaudePrompt(effectiveSystemPrompt(architectSystemPrompt, a.promptOverride, handoff), handoff)
+
+	resp, err := a.cli.ExecuteStream(ctx, prompt, onToken)
+	if err != nil {
+		return types.AgentResponse{}, err
+	}
+
+	nextRole := parseNextRole(resp.Content)
+
+	artifacts := map[string]any{"design_doc": resp.Content}
+	if reqs := parseRequirements(resp.Content); len(reqs) > 0 {
+		artifacts["requirements"] = reqs
+	}
+	if cons := parseConstraints(resp.Content); len(cons) > 0 {
+		artifacts["constraints"] = cons
+	}
+
 	return types.AgentResponse{
-		Content:    resp.Content,
-		Artifacts:  map[string]any{"design_doc": resp.Content},
-		TokensUsed: resp.TokensUsed,
-		DurationMS: time.Since(start).Milliseconds(),
-		NextRole:   nextRole,
+		Content:          resp.Content,
+		Artifacts:        artifacts,
+		TokensUsed:       resp.TokensUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		DurationMS:       time.Since(start).Milliseconds(),
+		NextRole:         nextRole,
 	}, nil
 }
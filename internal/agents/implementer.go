@@ -11,12 +11,16 @@ import (
 
 // ImplementerAgent handles code implementation using Codex CLI with full agentic access.
 type ImplementerAgent struct {
-	cli *adapters.CodexCLI
+	cli            *adapters.CodexCLI
+	promptOverride string
 }
 
 // NewImplementerAgent creates a new Implementer agent with Codex CLI.
-func NewImplementerAgent(cli *adapters.CodexCLI) *ImplementerAgent {
-	return &ImplementerAgent{cli: cli}
+// promptOverride, when non-empty, is rendered (see effectiveSystemPrompt)
+// and prepended to the Codex prompt in place of no system prompt at all;
+// it may reference {{.Task}} and {{.FilesInScope}}.
+func NewImplementerAgent(cli *adapters.CodexCLI, promptOverride string) *ImplementerAgent {
+	return &ImplementerAgent{cli: cli, promptOverride: promptOverride}
 }
 
 // Role returns the agent's role.
@@ -28,7 +32,7 @@ func (a *ImplementerAgent) Role() types.Role {
 func (a *ImplementerAgent) Execute(ctx context.Context, handoff types.Handoff) (types.AgentResponse, error) {
 	start := time.Now()
 
-	prompt := buildCodexPrompt(handoff)
+	prompt := buildCodexPrompt(effectiveSystemPrompt("", a.promptOverride, handoff), handoff)
 
 	resp, err := a.cli.Execute(ctx, prompt)
 	if err != nil {
@@ -55,10 +59,55 @@ func (a *ImplementerAgent) Execute(ctx context.Context, handoff types.Handoff) (
 	}
 
 	return types.AgentResponse{
-		Content:    resp.Content,
-		Artifacts:  artifacts,
-		TokensUsed: resp.TokensUsed,
-		DurationMS: time.Since(start).Milliseconds(),
-		NextRole:   nextRole,
+		Content:          resp.Content,
+		Artifacts:        artifacts,
+		TokensUsed:       resp.TokensUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		DurationMS:       time.Since(start).Milliseconds(),
+		NextRole:         nextRole,
+	}, nil
+}
+
+// ExecuteStream runs the implementer agent, forwarding incremental output
+// through onToken as Codex CLI produces it. Artifacts are still parsed from
+// the fully accumulated content once the run completes.
+func (a *ImplementerAgent) ExecuteStream(ctx context.Context, handoff types.Handoff, onToken func(string)) (types.AgentResponse, error) {
+	start := time.Now()
+
+	prompt := buildCodexPrompt(effectiveSystemPrompt("", a.promptOverride, handoff), handoff)
+
+	resp, err := a.cli.ExecuteStream(ctx, prompt, onToken)
+	if err != nil {
+		return types.AgentResponse{}, err
+	}
+
+	nextRole := parseNextRole(resp.Content)
+	fileBlocks := parseCodexFileBlocks(resp.Content)
+	cleanCode := sanitizeCodexOutput(resp.Content)
+	files := map[string]string{}
+	if len(fileBlocks) > 0 {
+		cleanCode = strings.TrimSpace(fileBlocks[0].content)
+		for _, block := range fileBlocks {
+			if block.path == "" {
+				continue
+			}
+			files[block.path] = strings.TrimRight(block.content, "\n")
+		}
+	}
+
+	artifacts := map[string]any{"code": cleanCode}
+	if len(files) > 0 {
+		artifacts["files"] = files
+	}
+
+	return types.AgentResponse{
+		Content:          resp.Content,
+		Artifacts:        artifacts,
+		TokensUsed:       resp.TokensUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		DurationMS:       time.Since(start).Milliseconds(),
+		NextRole:         nextRole,
 	}, nil
 }
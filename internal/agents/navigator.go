@@ -30,12 +30,15 @@ Output format:
 
 // NavigatorAgent handles context tracking using Claude CLI.
 type NavigatorAgent struct {
-	cli *adapters.ClaudeCLI
+	cli            *adapters.ClaudeCLI
+	promptOverride string
 }
 
 // NewNavigatorAgent creates a new Navigator agent with Claude CLI.
-func NewNavigatorAgent(cli *adapters.ClaudeCLI) *NavigatorAgent {
-	return &NavigatorAgent{cli: cli}
+// promptOverride, when non-empty, replaces navigatorSystemPrompt; it may
+// reference {{.Task}} and {{.FilesInScope}} (see effectiveSystemPrompt).
+func NewNavigatorAgent(cli *adapters.ClaudeCLI, promptOverride string) *NavigatorAgent {
+	return &NavigatorAgent{cli: cli, promptOverride: promptOverride}
 }
 
 // Role returns the agent's role.
@@ -47,7 +50,7 @@ func (a *NavigatorAgent) Role() types.Role {
 func (a *NavigatorAgent) Execute(ctx context.Context, handoff types.Handoff) (types.AgentResponse, error) {
 	start := time.Now()
 
-	prompt := buildClaudePrompt(navigatorSystemPrompt, handoff)
+	prompt := buildClaudePrompt(effectiveSystemPrompt(navigatorSystemPrompt, a.promptOverride, handoff), handoff)
 
 	resp, err := a.cli.Execute(ctx, prompt)
 	if err != nil {
@@ -57,10 +60,37 @@ func (a *NavigatorAgent) Execute(ctx context.Context, handoff types.Handoff) (ty
 	nextRole := parseNextRole(resp.Content)
 
 	return types.AgentResponse{
-		Content:    resp.Content,
-		Artifacts:  map[string]any{"notes": resp.Content},
-		TokensUsed: resp.TokensUsed,
-		DurationMS: time.Since(start).Milliseconds(),
-		NextRole:   nextRole,
+		Content:          resp.Content,
+		Artifacts:        map[string]any{"notes": resp.Content},
+		TokensUsed:       resp.TokensUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		DurationMS:       time.Since(start).Milliseconds(),
+		NextRole:         nextRole,
+	}, nil
+}
+
+// ExecuteStream runs the navigator agent, forwarding incremental output
+// through onToken as Claude CLI produces it.
+func (a *NavigatorAgent) ExecuteStream(ctx context.Context, handoff types.Handoff, onToken func(string)) (types.AgentResponse, error) {
+	start := time.Now()
+
+	prompt := buildClaudePrompt(effectiveSystemPrompt(navigatorSystemPrompt, a.promptOverride, handoff), handoff)
+
+	resp, err := a.cli.ExecuteStream(ctx, prompt, onToken)
+	if err != nil {
+		return types.AgentResponse{}, err
+	}
+
+	nextRole := parseNextRole(resp.Content)
+
+	return types.AgentResponse{
+		Content:          resp.Content,
+		Artifacts:        map[string]any{"notes": resp.Content},
+		TokensUsed:       resp.TokensUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		DurationMS:       time.Since(start).Milliseconds(),
+		NextRole:         nextRole,
 	}, nil
 }
@@ -9,6 +9,65 @@ import (
 
 var nextRolePattern = regexp.MustCompile(`(?i)NEXT:\s*(architect|implementer|reviewer|navigator|done|user)`)
 
+// verdictPattern matches the reviewer's required "Verdict: APPROVED or
+// CHANGES_NEEDED" line (see reviewerSystemPrompt).
+var verdictPattern = regexp.MustCompile(`(?i)Verdict:\s*(APPROVED|CHANGES_NEEDED)`)
+
+// parseReviewApproved extracts the reviewer's approve/changes-needed verdict
+// from its response content. Content with no recognizable verdict line is
+// treated as approved, so a malformed review doesn't loop forever.
+func parseReviewApproved(content string) bool {
+	matches := verdictPattern.FindStringSubmatch(content)
+	if len(matches) < 2 {
+		return true
+	}
+	return strings.EqualFold(matches[1], "APPROVED")
+}
+
+var requirementLinePattern = regexp.MustCompile(`(?im)^\s*REQUIREMENT:\s*(.+)$`)
+var constraintLinePattern = regexp.MustCompile(`(?im)^\s*CONSTRAINT:\s*(.+)$`)
+
+// parseRequirements extracts one entry per REQUIREMENT: line from agent
+// response content (see architectSystemPrompt's output format).
+func parseRequirements(content string) []string {
+	return parseLabeledLines(requirementLinePattern, content)
+}
+
+// parseConstraints extracts one entry per CONSTRAINT: line from agent
+// response content (see architectSystemPrompt's output format).
+func parseConstraints(content string) []string {
+	return parseLabeledLines(constraintLinePattern, content)
+}
+
+func parseLabeledLines(pattern *regexp.Regexp, content string) []string {
+	matches := pattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if line := strings.TrimSpace(match[1]); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+// reviewNextRole determines the reviewer's next role: an explicit NEXT:
+// directive always wins, otherwise a CHANGES_NEEDED verdict routes back to
+// the implementer and an APPROVED (or unrecognized) verdict ends the
+// workflow, so review feedback actually gets acted on.
+func reviewNextRole(content string) *types.Role {
+	if next := parseNextRole(content); next != nil {
+		return next
+	}
+	if !parseReviewApproved(content) {
+		role := types.RoleImplementer
+		return &role
+	}
+	return nil
+}
+
 // parseNextRole extracts the next role from agent response content.
 func parseNextRole(content string) *types.Role {
 	matches := nextRolePattern.FindStringSubmatch(content)
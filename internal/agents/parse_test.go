@@ -80,3 +80,49 @@ func TestParseNextRole(t *testing.T) {
 func rolePtr(r types.Role) *types.Role {
 	return &r
 }
+
+func TestReviewNextRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *types.Role
+	}{
+		{
+			name:     "explicit next wins over verdict",
+			content:  "Looks fine.\n\nVerdict: CHANGES_NEEDED\n\nNEXT: navigator",
+			expected: rolePtr(types.RoleNavigator),
+		},
+		{
+			name:     "changes needed loops to implementer",
+			content:  "Found issues.\n\nVerdict: CHANGES_NEEDED",
+			expected: rolePtr(types.RoleImplementer),
+		},
+		{
+			name:     "approved ends workflow",
+			content:  "Looks good.\n\nVerdict: APPROVED",
+			expected: nil,
+		},
+		{
+			name:     "no verdict treated as approved",
+			content:  "Some review prose with no verdict line.",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := reviewNextRole(tt.content)
+			if tt.expected == nil {
+				if result != nil {
+					t.Errorf("reviewNextRole(%q) = %v, want nil", tt.content, *result)
+				}
+			} else {
+				if result == nil {
+					t.Errorf("reviewNextRole(%q) = nil, want %v", tt.content, *tt.expected)
+				} else if *result != *tt.expected {
+					t.Errorf("reviewNextRole(%q) = %v, want %v", tt.content, *result, *tt.expected)
+				}
+			}
+		})
+	}
+}
@@ -31,12 +31,15 @@ Structure your review as:
 
 // ReviewerAgent handles code review using Claude CLI.
 type ReviewerAgent struct {
-	cli *adapters.ClaudeCLI
+	cli            *adapters.ClaudeCLI
+	promptOverride string
 }
 
 // NewReviewerAgent creates a new Reviewer agent with Claude CLI.
-func NewReviewerAgent(cli *adapters.ClaudeCLI) *ReviewerAgent {
-	return &ReviewerAgent{cli: cli}
+// promptOverride, when non-empty, replaces reviewerSystemPrompt; it may
+// reference {{.Task}} and {{.FilesInScope}} (see effectiveSystemPrompt).
+func NewReviewerAgent(cli *adapters.ClaudeCLI, promptOverride string) *ReviewerAgent {
+	return &ReviewerAgent{cli: cli, promptOverride: promptOverride}
 }
 
 // Role returns the agent's role.
@@ -48,20 +51,47 @@ func (a *ReviewerAgent) Role() types.Role {
 func (a *ReviewerAgent) Execute(ctx context.Context, handoff types.Handoff) (types.AgentResponse, error) {
 	start := time.Now()
 
-	prompt := buildClaudePrompt(reviewerSystemPrompt, handoff)
+	prompt := buildClaudePrompt(effectiveSystemPrompt(reviewerSystemPrompt, a.promptOverride, handoff), handoff)
 
 	resp, err := a.cli.Execute(ctx, prompt)
 	if err != nil {
 		return types.AgentResponse{}, err
 	}
 
-	nextRole := parseNextRole(resp.Content)
+	nextRole := reviewNextRole(resp.Content)
 
 	return types.AgentResponse{
-		Content:    resp.Content,
-		Artifacts:  map[string]any{"review_feedback": resp.Content},
-		TokensUsed: resp.TokensUsed,
-		DurationMS: time.Since(start).Milliseconds(),
-		NextRole:   nextRole,
+		Content:          resp.Content,
+		Artifacts:        map[string]any{"review_feedback": resp.Content},
+		TokensUsed:       resp.TokensUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		DurationMS:       time.Since(start).Milliseconds(),
+		NextRole:         nextRole,
+	}, nil
+}
+
+// ExecuteStream runs the reviewer agent, forwarding incremental output
+// through onToken as Claude CLI produces it.
+func (a *ReviewerAgent) ExecuteStream(ctx context.Context, handoff types.Handoff, onToken func(string)) (types.AgentResponse, error) {
+	start := time.Now()
+
+	prompt := buildClaudePrompt(effectiveSystemPrompt(reviewerSystemPrompt, a.promptOverride, handoff), handoff)
+
+	resp, err := a.cli.ExecuteStream(ctx, prompt, onToken)
+	if err != nil {
+		return types.AgentResponse{}, err
+	}
+
+	nextRole := reviewNextRole(resp.Content)
+
+	return types.AgentResponse{
+		Content:          resp.Content,
+		Artifacts:        map[string]any{"review_feedback": resp.Content},
+		TokensUsed:       resp.TokensUsed,
+		PromptTokens:     resp.PromptTokens,
+		CompletionTokens: resp.CompletionTokens,
+		DurationMS:       time.Since(start).Milliseconds(),
+		NextRole:         nextRole,
 	}, nil
 }
@@ -1,10 +1,18 @@
 package context
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"cooperations/internal/types"
@@ -39,37 +47,70 @@ func (s *Store) tasksFile() string {
 	return filepath.Join(s.baseDir, "tasks.json")
 }
 
-// handoffFile returns the path to a task's handoff file.
+// handoffFile returns the path to a task's legacy handoff file: a single
+// JSON array, rewritten in full on every append. Kept only so LoadHandoffs
+// can still read tasks written before the JSONL switch.
 func (s *Store) handoffFile(taskID string) string {
 	return filepath.Join(s.baseDir, "handoffs", taskID+".json")
 }
 
-// SaveTask saves or updates a task.
-func (s *Store) SaveTask(task types.Task) error {
-	tasks, err := s.LoadTasks()
-	if err != nil && !os.IsNotExist(err) {
-		return err
-	}
+// handoffJSONLFile returns the path to a task's append-only handoff log,
+// one JSON-encoded handoff per line.
+func (s *Store) handoffJSONLFile(taskID string) string {
+	return filepath.Join(s.baseDir, "handoffs", taskID+".jsonl")
+}
 
-	// Update existing or append new
-	found := false
-	for i, t := range tasks {
-		if t.ID == task.ID {
-			tasks[i] = task
-			found = true
-			break
-		}
-	}
-	if !found {
-		tasks = append(tasks, task)
+// withFileLock runs fn while holding an exclusive advisory lock on
+// path+".lock", so concurrent read-modify-write sequences against path (from
+// goroutines in this process or another process entirely) serialize instead
+// of racing. The lock is released via defer on every return path, including
+// a panic unwinding through fn.
+func withFileLock(path string, fn func() error) error {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("open lock file: %w", err)
 	}
+	defer f.Close()
 
-	data, err := json.MarshalIndent(tasks, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal tasks: %w", err)
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("acquire file lock: %w", err)
 	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
 
-	return os.WriteFile(s.tasksFile(), data, 0644)
+	return fn()
+}
+
+// SaveTask saves or updates a task. The read-modify-write against
+// tasks.json is guarded by an advisory file lock so two racing processes
+// (e.g. the TUI and the CLI) can't each load a stale copy and clobber the
+// other's update.
+func (s *Store) SaveTask(task types.Task) error {
+	return withFileLock(s.tasksFile(), func() error {
+		tasks, err := s.LoadTasks()
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		// Update existing or append new
+		found := false
+		for i, t := range tasks {
+			if t.ID == task.ID {
+				tasks[i] = task
+				found = true
+				break
+			}
+		}
+		if !found {
+			tasks = append(tasks, task)
+		}
+
+		data, err := json.MarshalIndent(tasks, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal tasks: %w", err)
+		}
+
+		return os.WriteFile(s.tasksFile(), data, 0644)
+	})
 }
 
 // LoadTasks loads all tasks from storage.
@@ -106,25 +147,43 @@ func (s *Store) GetTask(id string) (*types.Task, error) {
 	return nil, fmt.Errorf("task not found: %s", id)
 }
 
-// SaveHandoff appends a handoff to a task's handoff history.
+// SaveHandoff appends a handoff to a task's append-only JSONL handoff log,
+// guarded by an advisory file lock so concurrent appends (e.g. two agents
+// reporting near-simultaneously) don't interleave. Unlike the legacy
+// whole-array format, appending here is O(1) in the number of prior
+// handoffs and each line is durable on its own if a later append crashes.
 func (s *Store) SaveHandoff(taskID string, handoff types.Handoff) error {
-	handoffs, err := s.LoadHandoffs(taskID)
-	if err != nil && !os.IsNotExist(err) {
-		return err
-	}
-
-	handoffs = append(handoffs, handoff)
+	path := s.handoffJSONLFile(taskID)
+	return withFileLock(path, func() error {
+		data, err := json.Marshal(handoff)
+		if err != nil {
+			return fmt.Errorf("marshal handoff: %w", err)
+		}
 
-	data, err := json.MarshalIndent(handoffs, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal handoffs: %w", err)
-	}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("open handoffs file: %w", err)
+		}
+		defer f.Close()
 
-	return os.WriteFile(s.handoffFile(taskID), data, 0644)
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("append handoff: %w", err)
+		}
+		return nil
+	})
 }
 
-// LoadHandoffs loads all handoffs for a task.
+// LoadHandoffs loads all handoffs for a task, from the JSONL log when one
+// exists, falling back to the legacy whole-array .json file for tasks
+// written before the JSONL switch.
 func (s *Store) LoadHandoffs(taskID string) ([]types.Handoff, error) {
+	jsonlPath := s.handoffJSONLFile(taskID)
+	if _, err := os.Stat(jsonlPath); err == nil {
+		return loadHandoffsJSONL(jsonlPath)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("stat handoffs file: %w", err)
+	}
+
 	data, err := os.ReadFile(s.handoffFile(taskID))
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -141,13 +200,103 @@ func (s *Store) LoadHandoffs(taskID string) ([]types.Handoff, error) {
 	return handoffs, nil
 }
 
+// loadHandoffsJSONL reads one JSON-encoded handoff per line from path.
+func loadHandoffsJSONL(path string) ([]types.Handoff, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open handoffs file: %w", err)
+	}
+	defer f.Close()
+
+	var handoffs []types.Handoff
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var h types.Handoff
+		if err := json.Unmarshal(line, &h); err != nil {
+			return nil, fmt.Errorf("unmarshal handoff line: %w", err)
+		}
+		handoffs = append(handoffs, h)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read handoffs file: %w", err)
+	}
+
+	return handoffs, nil
+}
+
+// writeLedgerFile returns the path to a task's write ledger: the list of
+// workspace writes a run made, recorded so `coop undo` can reverse them
+// after the run that made them has already exited.
+func (s *Store) writeLedgerFile(taskID string) string {
+	return filepath.Join(s.baseDir, "write_ledger", taskID+".json")
+}
+
+// SaveWriteLedger persists a task's write ledger, overwriting any ledger
+// from a previous run of the same task. Guarded by the same file lock
+// pattern as SaveTask, since callers may race with `coop undo` reading it.
+func (s *Store) SaveWriteLedger(taskID string, entries []types.WriteLedgerEntry) error {
+	path := s.writeLedgerFile(taskID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create write ledger directory: %w", err)
+	}
+	return withFileLock(path, func() error {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal write ledger: %w", err)
+		}
+		return writeFileAtomic(path, data, 0644)
+	})
+}
+
+// LoadWriteLedger loads a task's write ledger. It returns an empty, non-nil
+// slice if the task never recorded one (e.g. it made no writes, or predates
+// this feature). Reads aren't lock-guarded since writeFileAtomic's
+// rename-into-place means a reader always sees a complete file.
+func (s *Store) LoadWriteLedger(taskID string) ([]types.WriteLedgerEntry, error) {
+	entries := []types.WriteLedgerEntry{}
+	data, err := os.ReadFile(s.writeLedgerFile(taskID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("read write ledger: %w", err)
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("unmarshal write ledger: %w", err)
+	}
+	return entries, nil
+}
+
+// ClearWriteLedger removes a task's write ledger, e.g. after `coop undo`
+// has applied it and it no longer describes the workspace's current state.
+func (s *Store) ClearWriteLedger(taskID string) error {
+	path := s.writeLedgerFile(taskID)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove write ledger: %w", err)
+	}
+	return nil
+}
+
 // CreateTask creates a new task with the given description.
 func (s *Store) CreateTask(description string) (types.Task, error) {
+	return s.CreateTaskWithTags(description, nil)
+}
+
+// CreateTaskWithTags creates a task the same way CreateTask does, additionally
+// attaching tags (e.g. from the --tag flag on `coop run`) so related work can
+// later be grouped and filtered in `coop history`.
+func (s *Store) CreateTaskWithTags(description string, tags []string) (types.Task, error) {
 	task := types.Task{
 		ID:          generateID(),
 		Description: description,
 		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
 		Status:      types.TaskStatusPending,
+		Tags:        tags,
 	}
 
 	if err := s.SaveTask(task); err != nil {
@@ -157,7 +306,7 @@ func (s *Store) CreateTask(description string) (types.Task, error) {
 	return task, nil
 }
 
-// UpdateTaskStatus updates a task's status.
+// UpdateTaskStatus updates a task's status and UpdatedAt timestamp.
 func (s *Store) UpdateTaskStatus(taskID string, status string) error {
 	task, err := s.GetTask(taskID)
 	if err != nil {
@@ -165,12 +314,116 @@ func (s *Store) UpdateTaskStatus(taskID string, status string) error {
 	}
 
 	task.Status = status
+	task.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
 	return s.SaveTask(*task)
 }
 
-// generateID creates a simple unique ID.
+// roleStatsFile returns the path to the cross-task rolling role-duration
+// stats file.
+func (s *Store) roleStatsFile() string {
+	return filepath.Join(s.baseDir, "role_stats.json")
+}
+
+// RoleDurationStats aggregates how long a role's agent calls have taken and
+// how many tokens they've used across every task run, so an ETA or cost
+// estimate can be projected from history.
+type RoleDurationStats struct {
+	Calls           int   `json:"calls"`
+	TotalDurationMS int64 `json:"total_duration_ms"`
+	TotalTokens     int64 `json:"total_tokens"`
+}
+
+// RecordRoleDuration folds one more call's duration and token usage into a
+// role's rolling stats. Guarded by the same file lock as SaveTask, since
+// it's a read-modify-write over a shared JSON file.
+func (s *Store) RecordRoleDuration(role string, durationMS int64, tokensUsed int) error {
+	path := s.roleStatsFile()
+	return withFileLock(path, func() error {
+		stats := make(map[string]RoleDurationStats)
+		if data, err := os.ReadFile(path); err == nil {
+			if err := json.Unmarshal(data, &stats); err != nil {
+				return fmt.Errorf("unmarshal role stats: %w", err)
+			}
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("read role stats: %w", err)
+		}
+
+		entry := stats[role]
+		entry.Calls++
+		entry.TotalDurationMS += durationMS
+		entry.TotalTokens += int64(tokensUsed)
+		stats[role] = entry
+
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal role stats: %w", err)
+		}
+		return writeFileAtomic(path, data, 0644)
+	})
+}
+
+// AverageRoleDurationMS returns a role's mean call duration across past
+// runs. ok is false when there's no recorded history for the role yet.
+func (s *Store) AverageRoleDurationMS(role string) (avgMS int64, ok bool) {
+	entry, found := s.roleStats(role)
+	if !found || entry.Calls == 0 {
+		return 0, false
+	}
+	return entry.TotalDurationMS / int64(entry.Calls), true
+}
+
+// AverageRoleTokens returns a role's mean tokens used per call across past
+// runs. ok is false when there's no recorded history for the role yet.
+func (s *Store) AverageRoleTokens(role string) (avgTokens int64, ok bool) {
+	entry, found := s.roleStats(role)
+	if !found || entry.Calls == 0 {
+		return 0, false
+	}
+	return entry.TotalTokens / int64(entry.Calls), true
+}
+
+func (s *Store) roleStats(role string) (RoleDurationStats, bool) {
+	data, err := os.ReadFile(s.roleStatsFile())
+	if err != nil {
+		return RoleDurationStats{}, false
+	}
+
+	stats := make(map[string]RoleDurationStats)
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return RoleDurationStats{}, false
+	}
+
+	entry, found := stats[role]
+	return entry, found
+}
+
+// idCounter makes generateID collision-resistant for IDs minted within the
+// same nanosecond tick, which UnixNano alone can't distinguish under rapid,
+// tight-loop task creation.
+var idCounter uint64
+
+// generateID creates a unique, time-sortable ID: a nanosecond timestamp
+// (so IDs still sort chronologically, same as the plain UnixNano IDs this
+// replaces) followed by a monotonic counter and a short random suffix, so
+// two IDs minted in the same tick never collide and remain visually
+// distinguishable. IDs are opaque strings compared for equality, never
+// parsed back into a timestamp, so existing plain-numeric IDs on disk stay
+// perfectly loadable alongside new ones.
 func generateID() string {
-	return fmt.Sprintf("%d", time.Now().UnixNano())
+	seq := atomic.AddUint64(&idCounter, 1)
+	return fmt.Sprintf("%d-%d-%s", time.Now().UnixNano(), seq, randomIDSuffix())
+}
+
+// randomIDSuffix returns a short random hex string for generateID.
+func randomIDSuffix() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand failing means the OS entropy source is broken; the
+		// counter above still guarantees uniqueness, so degrade instead
+		// of failing ID generation outright.
+		return "0000"
+	}
+	return hex.EncodeToString(b[:])
 }
 
 // TaskOutputDir returns the path to a task's generated output directory.
@@ -209,13 +462,39 @@ func (s *Store) SaveGeneratedCode(taskID string, filename string, code string) (
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return "", fmt.Errorf("create code subdirectory: %w", err)
 	}
-	if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+	if err := writeFileAtomic(path, []byte(code), 0644); err != nil {
 		return "", fmt.Errorf("write code file: %w", err)
 	}
 
 	return path, nil
 }
 
+// writeFileAtomic writes data to path by first writing to a temp file in
+// the same directory, then renaming it into place. Rename is atomic on the
+// same filesystem, so a crash or interrupt mid-write leaves either the old
+// content or the new content at path, never a partial file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
 // SaveDesignDoc saves a design document to the task output directory.
 func (s *Store) SaveDesignDoc(taskID string, content string) (string, error) {
 	dir, err := s.EnsureTaskOutputDir(taskID)
@@ -284,3 +563,54 @@ func (s *Store) SaveTaskSummary(taskID string, task types.Task, artifacts types.
 
 	return path, nil
 }
+
+// ListGeneratedFiles returns the paths of every file under a task's output
+// directory, relative to that directory (e.g. "code/main.go", "design.md").
+// It returns an empty slice, not an error, for a task that never produced
+// any output.
+func (s *Store) ListGeneratedFiles(taskID string) ([]string, error) {
+	dir := s.TaskOutputDir(taskID)
+
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list generated files: %w", err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadGeneratedFile reads a file from a task's output directory. rel is
+// rejected if it's absolute or would escape the directory via "../", so
+// callers can pass a path chosen by a user or agent without risking a read
+// outside the task's own output.
+func (s *Store) ReadGeneratedFile(taskID, rel string) ([]byte, error) {
+	clean := filepath.Clean(rel)
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("invalid file path %q: escapes task output directory", rel)
+	}
+
+	path := filepath.Join(s.TaskOutputDir(taskID), clean)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read generated file: %w", err)
+	}
+	return data, nil
+}
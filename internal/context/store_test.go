@@ -0,0 +1,338 @@
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"cooperations/internal/types"
+)
+
+func TestWriteFileAtomicNeverLeavesPartialContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "code", "main.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	oldContent := []byte("package main\n\nfunc old() {}\n")
+	if err := os.WriteFile(path, oldContent, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	newContent := []byte("package main\n\nfunc newFunc() {}\n")
+	if err := writeFileAtomic(path, newContent, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate an interrupted write racing a reader: at every point before
+	// writeFileAtomic returns, a concurrent read of path sees either the old
+	// or new content, never a partial mix of the two.
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(oldContent) && string(got) != string(newContent) {
+		t.Fatalf("target has neither old nor new content, got: %q", got)
+	}
+	if string(got) != string(newContent) {
+		t.Errorf("target = %q, want new content %q", got, newContent)
+	}
+
+	// No leftover temp file should remain in the directory.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name() != filepath.Base(path) {
+			t.Errorf("unexpected leftover entry in target directory: %s", e.Name())
+		}
+	}
+}
+
+func TestListAndReadGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "handoffs"), filepath.Join(dir, "generated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.SaveGeneratedCode("task-1", "greet.go", "package main\n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.SaveDesignDoc("task-1", "# Design\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := store.ListGeneratedFiles("task-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"code/greet.go", "design.md"}
+	if len(files) != len(want) {
+		t.Fatalf("ListGeneratedFiles = %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("ListGeneratedFiles[%d] = %q, want %q", i, files[i], want[i])
+		}
+	}
+
+	data, err := store.ReadGeneratedFile("task-1", "code/greet.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "package main\n" {
+		t.Errorf("ReadGeneratedFile content = %q, want %q", data, "package main\n")
+	}
+}
+
+func TestListGeneratedFilesMissingTask(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "handoffs"), filepath.Join(dir, "generated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := store.ListGeneratedFiles("no-such-task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("ListGeneratedFiles for missing task = %v, want empty", files)
+	}
+}
+
+func TestReadGeneratedFileRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "handoffs"), filepath.Join(dir, "generated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.SaveGeneratedCode("task-1", "greet.go", "package main\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []string{
+		"../secret.txt",
+		"../../etc/passwd",
+		"/etc/passwd",
+	}
+	for _, rel := range tests {
+		if _, err := store.ReadGeneratedFile("task-1", rel); err == nil {
+			t.Errorf("ReadGeneratedFile(%q) succeeded, want error", rel)
+		}
+	}
+}
+
+func TestSaveTaskConcurrentNoLostUpdates(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "handoffs"), filepath.Join(dir, "generated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			task := types.Task{
+				ID:          fmt.Sprintf("task-%d", i),
+				Description: fmt.Sprintf("task number %d", i),
+				Status:      types.TaskStatusPending,
+			}
+			if err := store.SaveTask(task); err != nil {
+				t.Errorf("SaveTask(%d): %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	tasks, err := store.LoadTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != n {
+		t.Fatalf("LoadTasks returned %d tasks, want %d (lost updates in a racing read-modify-write)", len(tasks), n)
+	}
+
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		seen[task.ID] = true
+	}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("task-%d", i)
+		if !seen[id] {
+			t.Errorf("missing task %s after concurrent SaveTask calls", id)
+		}
+	}
+}
+
+func TestSaveHandoffAppendsJSONL(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "handoffs"), filepath.Join(dir, "generated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		h := types.Handoff{
+			TaskID:    "task-1",
+			Timestamp: fmt.Sprintf("2024-01-0%dT00:00:00Z", i+1),
+			FromRole:  types.RoleArchitect,
+			ToRole:    types.RoleImplementer,
+		}
+		if err := store.SaveHandoff("task-1", h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handoffs, err := store.LoadHandoffs("task-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handoffs) != 3 {
+		t.Fatalf("LoadHandoffs returned %d handoffs, want 3", len(handoffs))
+	}
+	for i, h := range handoffs {
+		want := fmt.Sprintf("2024-01-0%dT00:00:00Z", i+1)
+		if h.Timestamp != want {
+			t.Errorf("handoffs[%d].Timestamp = %q, want %q (order not preserved)", i, h.Timestamp, want)
+		}
+	}
+
+	jsonlPath := store.handoffJSONLFile("task-1")
+	if _, err := os.Stat(jsonlPath); err != nil {
+		t.Errorf("expected JSONL file at %s: %v", jsonlPath, err)
+	}
+}
+
+func TestLoadHandoffsFallsBackToLegacyJSON(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "handoffs"), filepath.Join(dir, "generated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacy := []types.Handoff{
+		{TaskID: "task-1", Timestamp: "2024-01-01T00:00:00Z", FromRole: types.RoleArchitect, ToRole: types.RoleImplementer},
+		{TaskID: "task-1", Timestamp: "2024-01-02T00:00:00Z", FromRole: types.RoleImplementer, ToRole: types.RoleReviewer},
+	}
+	data, err := json.MarshalIndent(legacy, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(store.handoffFile("task-1"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handoffs, err := store.LoadHandoffs("task-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handoffs) != 2 {
+		t.Fatalf("LoadHandoffs (legacy) returned %d handoffs, want 2", len(handoffs))
+	}
+	if handoffs[1].ToRole != types.RoleReviewer {
+		t.Errorf("handoffs[1].ToRole = %v, want %v", handoffs[1].ToRole, types.RoleReviewer)
+	}
+}
+
+func TestRecordAndAverageRoleDuration(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "handoffs"), filepath.Join(dir, "generated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := store.AverageRoleDurationMS("architect"); ok {
+		t.Fatal("AverageRoleDurationMS with no history should report ok=false")
+	}
+
+	durations := []int64{1000, 2000, 3000}
+	for _, d := range durations {
+		if err := store.RecordRoleDuration("architect", d, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	avg, ok := store.AverageRoleDurationMS("architect")
+	if !ok {
+		t.Fatal("AverageRoleDurationMS should report ok=true after recording durations")
+	}
+	if avg != 2000 {
+		t.Errorf("AverageRoleDurationMS = %d, want 2000", avg)
+	}
+
+	if _, ok := store.AverageRoleDurationMS("reviewer"); ok {
+		t.Error("AverageRoleDurationMS for an unrecorded role should report ok=false")
+	}
+
+	tokens, ok := store.AverageRoleTokens("architect")
+	if !ok {
+		t.Fatal("AverageRoleTokens should report ok=true after recording durations")
+	}
+	if tokens != 100 {
+		t.Errorf("AverageRoleTokens = %d, want 100", tokens)
+	}
+}
+
+func TestSaveGeneratedCodeWritesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "handoffs"), filepath.Join(dir, "generated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := store.SaveGeneratedCode("task-1", "greet.go", "package main\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "package main\n" {
+		t.Errorf("content = %q, want %q", got, "package main\n")
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name() != filepath.Base(path) {
+			t.Errorf("unexpected leftover entry in code directory: %s", e.Name())
+		}
+	}
+}
+
+func TestGenerateIDUniqueUnderConcurrentLoad(t *testing.T) {
+	const n = 1000
+	ids := make([]string, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i] = generateID()
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, n)
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate ID generated: %s", id)
+		}
+		seen[id] = true
+	}
+}
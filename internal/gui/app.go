@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"gioui.org/app"
+	"gioui.org/io/key"
+	"gioui.org/io/system"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
@@ -31,6 +33,11 @@ type App struct {
 	mu     sync.Mutex
 	stream *stream.WorkflowStream
 
+	// activeDecisionID is the ID of the DecisionRequest currently displayed
+	// in bottomPanel, so handleDecision can correlate its response back to
+	// the request that prompted it.
+	activeDecisionID string
+
 	// Panel widgets
 	sidebar     *widgets.SidebarPanel
 	mainPanel   *widgets.MainPanel
@@ -39,6 +46,7 @@ type App struct {
 	// Current code display
 	currentCode     string
 	currentCodeLang string
+	currentCodePath string
 }
 
 // NewApp creates a new App instance with a window and theme.
@@ -137,7 +145,7 @@ func (a *App) handleDecision(action stream.DecisionAction, comment string) {
 	}
 
 	decision := stream.HumanDecision{
-		RequestID: "", // Would be set from current decision request
+		RequestID: a.activeDecisionID,
 		Action:    action,
 		Comment:   comment,
 	}
@@ -146,11 +154,67 @@ func (a *App) handleDecision(action stream.DecisionAction, comment string) {
 	case ws.Response <- decision:
 		a.state.SetWaitingForInput(false)
 		a.state.AddActivity(fmt.Sprintf("Decision: %s", action))
+		a.activeDecisionID = ""
+		a.bottomPanel.SetText("")
 	default:
 		// Channel full or closed
 	}
 }
 
+// handleKeyEvents polls Gio's input router for the global keyboard shortcuts
+// registered below and dispatches them, mirroring the TUI's approve/reject/
+// edit/quit bindings so muscle memory carries over between the two
+// frontends. It must run once per frame from layout, since gtx.Event only
+// reports events for filters declared during the current frame.
+func (a *App) handleKeyEvents(gtx layout.Context) {
+	for {
+		e, ok := gtx.Event(
+			key.Filter{Name: "A"},
+			key.Filter{Name: key.NameReturn},
+			key.Filter{Name: key.NameEnter},
+			key.Filter{Name: "R"},
+			key.Filter{Name: "E"},
+			key.Filter{Name: "Q"},
+			key.Filter{Name: "T"},
+		)
+		if !ok {
+			return
+		}
+
+		ke, ok := e.(key.Event)
+		if !ok || ke.State != key.Press {
+			continue
+		}
+
+		if ke.Name == "Q" {
+			a.window.Perform(system.ActionClose)
+			continue
+		}
+
+		if ke.Name == "T" {
+			name := CycleTheme()
+			a.state.AddActivity(fmt.Sprintf("Theme: %s", name))
+			continue
+		}
+
+		// Approve/reject/edit only make sense while a decision is pending.
+		if !a.state.Snapshot().WaitingForInput {
+			continue
+		}
+
+		switch ke.Name {
+		case "A", key.NameReturn, key.NameEnter:
+			a.handleDecision(stream.DecisionActionApprove, "")
+		case "R":
+			a.handleDecision(stream.DecisionActionReject, "")
+		case "E":
+			// Edit needs a comment, so focus the bottom panel's editor
+			// instead of submitting immediately.
+			a.bottomPanel.Focus(gtx)
+		}
+	}
+}
+
 func (a *App) eventLoop() error {
 	var ops op.Ops
 
@@ -188,6 +252,7 @@ func (a *App) handleStream(ctx context.Context, ws *stream.WorkflowStream) {
 			a.mu.Lock()
 			a.currentCode = code.Content
 			a.currentCodeLang = code.Language
+			a.currentCodePath = code.Path
 			a.mu.Unlock()
 			a.state.AddActivity(fmt.Sprintf("Code update: %s", code.Path))
 			a.window.Invalidate()
@@ -207,15 +272,68 @@ func (a *App) handleStream(ctx context.Context, ws *stream.WorkflowStream) {
 			a.state.AddActivity(fmt.Sprintf("Tokens: %d total", tokens.TotalTokens))
 			a.window.Invalidate()
 
+		case think, ok := <-ws.Thinking:
+			if !ok {
+				return
+			}
+			a.state.SetStatusLine(fmt.Sprintf("%s: %s", think.AgentRole, think.Stage))
+			a.window.Invalidate()
+
+		case entry, ok := <-ws.AgentLog:
+			if !ok {
+				return
+			}
+			a.state.AddActivity(fmt.Sprintf("[%s] %s", entry.AgentRole, entry.Message))
+			a.window.Invalidate()
+
+		case diff, ok := <-ws.FileDiff:
+			if !ok {
+				return
+			}
+			a.state.AddActivity(fmt.Sprintf("Diff: %s", diff.Path))
+			a.window.Invalidate()
+
+		case tree, ok := <-ws.FileTree:
+			if !ok {
+				return
+			}
+			a.state.UpsertFileTreeEntry(FileTreeEntry{Path: tree.Path, Action: tree.Action, IsDir: tree.IsDir})
+			a.state.AddActivity(fmt.Sprintf("File %s: %s", tree.Action, tree.Path))
+			a.window.Invalidate()
+
+		case metrics, ok := <-ws.Metrics:
+			if !ok {
+				return
+			}
+			a.state.SetMetrics(MetricsDisplay{
+				PromptTokens:     metrics.PromptTokens,
+				CompletionTokens: metrics.CompletionTokens,
+				TotalTokens:      metrics.TotalTokens,
+				EstimatedCostUSD: metrics.EstimatedCostUSD,
+				ElapsedTime:      metrics.ElapsedTime,
+				CurrentAgent:     metrics.CurrentAgent,
+			})
+			a.window.Invalidate()
+
 		case decision, ok := <-ws.Decision:
 			if !ok {
 				return
 			}
+			a.activeDecisionID = decision.ID
 			a.state.SetWaitingForInput(true)
 			a.state.SetStatusLine(fmt.Sprintf("Decision needed: %s", decision.Title))
 			a.bottomPanel.Title = decision.Title
 			a.bottomPanel.Prompt = decision.Prompt
-			a.bottomPanel.Options = decision.Options
+			options := make([]widgets.BottomPanelOption, len(decision.Options))
+			for i, opt := range decision.Options {
+				options[i] = widgets.BottomPanelOption{
+					Key:         opt.Key,
+					Label:       opt.Label,
+					Description: opt.Description,
+					Danger:      opt.Danger,
+				}
+			}
+			a.bottomPanel.Options = options
 			a.window.Invalidate()
 
 		case err, ok := <-ws.Error:
@@ -238,6 +356,8 @@ func (a *App) handleStream(ctx context.Context, ws *stream.WorkflowStream) {
 }
 
 func (a *App) layout(gtx layout.Context) layout.Dimensions {
+	a.handleKeyEvents(gtx)
+
 	snap := a.state.Snapshot()
 
 	// Fill background
@@ -252,6 +372,11 @@ func (a *App) layout(gtx layout.Context) layout.Dimensions {
 			return a.layoutHeader(gtx, &snap)
 		}),
 
+		// Metrics strip: tokens, estimated cost, elapsed time
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return a.layoutMetricsStrip(gtx, &snap)
+		}),
+
 		// Main content area (sidebar + main panel)
 		layout.Flexed(1.0, func(gtx layout.Context) layout.Dimensions {
 			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
@@ -364,6 +489,38 @@ func (a *App) layoutStatusBadge(gtx layout.Context, snap *StateSnapshot) layout.
 	})
 }
 
+// layoutMetricsStrip renders a slim row of live token/cost/elapsed metrics
+// below the header, fed by MetricsSnapshot events (see handleStream). It
+// reuses the same EstimatedCostUSD the TUI's MetricsPanel shows, so the two
+// frontends never disagree on cost for the same run.
+func (a *App) layoutMetricsStrip(gtx layout.Context, snap *StateSnapshot) layout.Dimensions {
+	stripHeight := gtx.Dp(unit.Dp(28))
+	size := image.Pt(gtx.Constraints.Max.X, stripHeight)
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	paint.Fill(gtx.Ops, DefaultTheme.PanelBg)
+
+	borderRect := image.Rect(0, stripHeight-1, size.X, stripHeight)
+	st := clip.Rect(borderRect).Push(gtx.Ops)
+	paint.Fill(gtx.Ops, DefaultTheme.Border)
+	st.Pop()
+
+	m := snap.Metrics
+	text := fmt.Sprintf("Tokens: %d (in:%d out:%d)  •  Est. cost: $%.4f  •  Elapsed: %s",
+		m.TotalTokens, m.PromptTokens, m.CompletionTokens, m.EstimatedCostUSD, m.ElapsedTime.Round(time.Second))
+	if m.CurrentAgent != "" {
+		text = fmt.Sprintf("%s  •  %s", text, m.CurrentAgent)
+	}
+
+	inset := layout.Inset{Left: unit.Dp(20), Top: unit.Dp(6)}
+	inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		lbl := material.Caption(a.theme, text)
+		lbl.Color = DefaultTheme.TextSecondary
+		return lbl.Layout(gtx)
+	})
+
+	return layout.Dimensions{Size: size}
+}
+
 func (a *App) updatePanelData(snap *StateSnapshot) {
 	// Update sidebar with workflow steps
 	steps := make([]widgets.WorkflowStep, len(snap.WorkflowSteps))
@@ -390,6 +547,13 @@ func (a *App) updatePanelData(snap *StateSnapshot) {
 	}
 	a.sidebar.SetHandoffs(handoffs)
 
+	// Update sidebar with the generated file tree
+	files := make([]widgets.FileTreeEntry, len(snap.FileTree))
+	for i, f := range snap.FileTree {
+		files[i] = widgets.FileTreeEntry{Path: f.Path, Action: f.Action, IsDir: f.IsDir}
+	}
+	a.sidebar.SetFileTree(files)
+
 	// Update main panel
 	a.mainPanel.SetActivityLog(snap.ActivityLog)
 
@@ -397,8 +561,9 @@ func (a *App) updatePanelData(snap *StateSnapshot) {
 	a.mu.Lock()
 	code := a.currentCode
 	lang := a.currentCodeLang
+	path := a.currentCodePath
 	a.mu.Unlock()
-	a.mainPanel.SetCode(code, lang)
+	a.mainPanel.SetCode(code, lang, path)
 }
 
 // runRealWorkflow executes the actual orchestrator workflow with stream events.
@@ -588,19 +753,26 @@ func ProcessTask(input string) (string, error) {
 		prevRole = step.role
 	}
 
-	// Human decision prompt
-	if !send(ws.Decision, stream.DecisionRequest{
-		ID:      "final-review",
-		Title:   "Approve Workflow Result",
-		Prompt:  "The workflow has completed. Please review the generated code and approve or request changes.",
-		Options: []string{"Approve", "Request Changes", "Reject"},
-	}) {
-		return
-	}
+	// Human decision prompt. RequestDecision auto-approves after 30s of no
+	// response, so unattended demo runs don't hang.
+	decisionCh := make(chan stream.HumanDecision, 1)
+	go func() {
+		decisionCh <- ws.RequestDecision(stream.DecisionRequest{
+			ID:     "final-review",
+			Title:  "Approve Workflow Result",
+			Prompt: "The workflow has completed. Please review the generated code and approve or request changes.",
+			Options: []stream.DecisionOptionSpec{
+				{Key: "1", Label: "Approve", Description: "Accept the generated code as-is"},
+				{Key: "2", Label: "Request Changes", Description: "Send the workflow back for revisions"},
+				{Key: "3", Label: "Reject", Description: "Discard the generated code", Danger: true},
+			},
+			Timeout:       30 * time.Second,
+			DefaultAction: stream.DecisionActionApprove,
+		})
+	}()
 
-	// Wait for human decision
 	select {
-	case decision := <-ws.Response:
+	case decision := <-decisionCh:
 		if !send(ws.Progress, stream.ProgressUpdate{
 			Stage:   "Complete",
 			Percent: 100,
@@ -610,15 +782,6 @@ func ProcessTask(input string) (string, error) {
 		}
 	case <-ctx.Done():
 		return
-	case <-time.After(30 * time.Second):
-		// Timeout - auto-complete
-		if !send(ws.Progress, stream.ProgressUpdate{
-			Stage:   "Complete",
-			Percent: 100,
-			Message: "Workflow completed (no human response)",
-		}) {
-			return
-		}
 	}
 
 	// Signal completion
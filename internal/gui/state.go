@@ -22,6 +22,23 @@ type WorkflowStepState struct {
 	Subtext  string
 }
 
+// FileTreeEntry tracks one file's presence in the generated file tree.
+type FileTreeEntry struct {
+	Path   string
+	Action string // "add", "modify", "delete"
+	IsDir  bool
+}
+
+// MetricsDisplay holds the latest cost/usage snapshot for display.
+type MetricsDisplay struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	EstimatedCostUSD float64
+	ElapsedTime      time.Duration
+	CurrentAgent     string
+}
+
 // AppState is a thread-safe container for all GUI-relevant state.
 type AppState struct {
 	mu sync.RWMutex
@@ -52,6 +69,12 @@ type AppState struct {
 	// Role handoff tracking
 	HandoffHistory []HandoffEntry
 
+	// Generated file tree, keyed by insertion order (see UpsertFileTreeEntry)
+	FileTree []FileTreeEntry
+
+	// Latest live cost/usage snapshot
+	Metrics MetricsDisplay
+
 	// Error state
 	ErrorMessage string
 
@@ -68,7 +91,7 @@ type AppState struct {
 // NewAppState constructs an AppState with sensible defaults.
 func NewAppState() *AppState {
 	return &AppState{
-		Connected:        false,
+		Connected:         false,
 		TaskDescription:   "",
 		TaskInProgress:    false,
 		Completed:         false,
@@ -80,6 +103,8 @@ func NewAppState() *AppState {
 		CurrentStep:       0,
 		InputText:         "",
 		HandoffHistory:    []HandoffEntry{},
+		FileTree:          []FileTreeEntry{},
+		Metrics:           MetricsDisplay{},
 		ErrorMessage:      "",
 		WaitingForInput:   false,
 		CompletionMessage: "",
@@ -284,6 +309,42 @@ func (s *AppState) ClearHandoffHistory() {
 	s.HandoffHistory = nil
 }
 
+// UpsertFileTreeEntry records a file-tree change. A "delete" action removes
+// the path; any other action adds it or updates its recorded action in place.
+func (s *AppState) UpsertFileTreeEntry(entry FileTreeEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.FileTree {
+		if existing.Path != entry.Path {
+			continue
+		}
+		if entry.Action == "delete" {
+			s.FileTree = append(s.FileTree[:i], s.FileTree[i+1:]...)
+		} else {
+			s.FileTree[i] = entry
+		}
+		return
+	}
+	if entry.Action != "delete" {
+		s.FileTree = append(s.FileTree, entry)
+	}
+}
+
+// ClearFileTree empties the tracked file tree.
+func (s *AppState) ClearFileTree() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.FileTree = nil
+}
+
+// SetMetrics records the latest cost/usage snapshot.
+func (s *AppState) SetMetrics(m MetricsDisplay) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Metrics = m
+}
+
 func (s *AppState) SetError(message string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -328,6 +389,8 @@ type StateSnapshot struct {
 	CurrentStep       int
 	InputText         string
 	HandoffHistory    []HandoffEntry
+	FileTree          []FileTreeEntry
+	Metrics           MetricsDisplay
 	ErrorMessage      string
 	WaitingForInput   bool
 	CompletionMessage string
@@ -351,6 +414,7 @@ func (s *AppState) Snapshot() StateSnapshot {
 		WaitingForInput:   s.WaitingForInput,
 		CompletionMessage: s.CompletionMessage,
 		ShowHelp:          s.ShowHelp,
+		Metrics:           s.Metrics,
 	}
 
 	if s.RequiredInputs != nil {
@@ -381,5 +445,12 @@ func (s *AppState) Snapshot() StateSnapshot {
 		cp.HandoffHistory = []HandoffEntry{}
 	}
 
+	if s.FileTree != nil {
+		cp.FileTree = make([]FileTreeEntry, len(s.FileTree))
+		copy(cp.FileTree, s.FileTree)
+	} else {
+		cp.FileTree = []FileTreeEntry{}
+	}
+
 	return cp
-}
\ No newline at end of file
+}
@@ -1,7 +1,10 @@
 // File: internal/gui/stream/events.go
 package stream
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // DecisionAction describes what a human chose to do in response to a DecisionRequest.
 type DecisionAction string
@@ -21,8 +24,8 @@ type ProgressUpdate struct {
 
 // CodeUpdate represents new or updated code content for a file.
 type CodeUpdate struct {
-	Path    string `json:"path"`
-	Content string `json:"content"`
+	Path     string `json:"path"`
+	Content  string `json:"content"`
 	Language string `json:"language"`
 }
 
@@ -43,10 +46,35 @@ type TokenUpdate struct {
 
 // DecisionRequest asks a human to make a decision with optional suggested options.
 type DecisionRequest struct {
-	ID      string   `json:"id"`
-	Title   string   `json:"title"`
-	Prompt  string   `json:"prompt"`
-	Options []string `json:"options"`
+	ID      string               `json:"id"`
+	Title   string               `json:"title"`
+	Prompt  string               `json:"prompt"`
+	Options []DecisionOptionSpec `json:"options"`
+
+	// Timeout, when non-zero, auto-selects DefaultAction if no human
+	// response arrives in time, so unattended demo runs don't block forever.
+	Timeout       time.Duration  `json:"timeout,omitempty"`
+	DefaultAction DecisionAction `json:"defaultAction,omitempty"`
+}
+
+// DecisionOptionSpec describes one choice offered in a DecisionRequest,
+// carrying enough detail for the panel to render help text and warn before
+// destructive actions.
+type DecisionOptionSpec struct {
+	Key         string `json:"key,omitempty"`
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+	Danger      bool   `json:"danger,omitempty"`
+}
+
+// PlainOptions builds DecisionOptionSpecs from bare labels for callers that
+// don't need per-option descriptions or danger flags, keyed 1, 2, 3, ...
+func PlainOptions(labels ...string) []DecisionOptionSpec {
+	specs := make([]DecisionOptionSpec, len(labels))
+	for i, label := range labels {
+		specs[i] = DecisionOptionSpec{Key: fmt.Sprintf("%d", i+1), Label: label}
+	}
+	return specs
 }
 
 // HumanDecision represents the human's response to a DecisionRequest.
@@ -55,4 +83,63 @@ type HumanDecision struct {
 	Action    DecisionAction `json:"action"`
 	Comment   string         `json:"comment"`
 	Edited    string         `json:"edited"`
-}
\ No newline at end of file
+}
+
+// ThinkingUpdate indicates an agent is processing.
+type ThinkingUpdate struct {
+	AgentRole string        `json:"agentRole"`
+	Stage     string        `json:"stage"` // "analyzing", "generating", "reviewing"
+	Duration  time.Duration `json:"duration"`
+}
+
+// AgentLogEntry is a detailed log entry from an agent.
+type AgentLogEntry struct {
+	Timestamp time.Time      `json:"timestamp"`
+	AgentRole string         `json:"agentRole"`
+	Level     string         `json:"level"` // "info", "debug", "warn", "error"
+	Message   string         `json:"message"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// FileDiff represents a git-style diff for a file.
+type FileDiff struct {
+	Path       string     `json:"path"`
+	OldContent string     `json:"oldContent"`
+	NewContent string     `json:"newContent"`
+	Hunks      []DiffHunk `json:"hunks"`
+}
+
+// DiffHunk represents a section of changes in a diff.
+type DiffHunk struct {
+	OldStart int        `json:"oldStart"`
+	OldCount int        `json:"oldCount"`
+	NewStart int        `json:"newStart"`
+	NewCount int        `json:"newCount"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// DiffLine represents a single line in a diff.
+type DiffLine struct {
+	Type    string `json:"type"` // "add", "remove", "context"
+	Content string `json:"content"`
+}
+
+// FileTreeUpdate represents a change in the generated file tree.
+type FileTreeUpdate struct {
+	Action string `json:"action"` // "add", "modify", "delete"
+	Path   string `json:"path"`
+	IsDir  bool   `json:"isDir"`
+	Size   int64  `json:"size"`
+}
+
+// MetricsSnapshot contains live metrics data.
+type MetricsSnapshot struct {
+	TotalTokens      int           `json:"totalTokens"`
+	PromptTokens     int           `json:"promptTokens"`
+	CompletionTokens int           `json:"completionTokens"`
+	EstimatedCostUSD float64       `json:"estimatedCostUsd"`
+	ElapsedTime      time.Duration `json:"elapsedTime"`
+	APICallsCount    int           `json:"apiCallsCount"`
+	AgentCycles      int           `json:"agentCycles"`
+	CurrentAgent     string        `json:"currentAgent"`
+}
@@ -2,6 +2,7 @@ package stream
 
 import (
 	"sync"
+	"time"
 )
 
 // WorkflowStream provides a set of channels for streaming workflow events to a GUI.
@@ -12,6 +13,15 @@ type WorkflowStream struct {
 	Handoffs chan HandoffEvent
 	Tokens   chan TokenUpdate
 
+	// Thinking, AgentLog, FileDiff, FileTree and Metrics carry the richer
+	// per-agent detail the orchestrator emits alongside the coarser
+	// Progress/Code/Handoffs/Tokens events above.
+	Thinking chan ThinkingUpdate
+	AgentLog chan AgentLogEntry
+	FileDiff chan FileDiff
+	FileTree chan FileTreeUpdate
+	Metrics  chan MetricsSnapshot
+
 	Decision chan DecisionRequest
 	Response chan HumanDecision
 
@@ -30,6 +40,12 @@ func NewWorkflowStream() *WorkflowStream {
 		Handoffs: make(chan HandoffEvent, 10),
 		Tokens:   make(chan TokenUpdate, 10),
 
+		Thinking: make(chan ThinkingUpdate, 10),
+		AgentLog: make(chan AgentLogEntry, 50),
+		FileDiff: make(chan FileDiff, 10),
+		FileTree: make(chan FileTreeUpdate, 20),
+		Metrics:  make(chan MetricsSnapshot, 10),
+
 		Decision: make(chan DecisionRequest, 1),
 		Response: make(chan HumanDecision, 1),
 
@@ -38,6 +54,28 @@ func NewWorkflowStream() *WorkflowStream {
 	}
 }
 
+// RequestDecision sends a decision request and waits for response. If d has
+// a Timeout set and no response arrives in time, it auto-selects
+// d.DefaultAction (defaulting to DecisionActionApprove) rather than
+// blocking forever.
+func (s *WorkflowStream) RequestDecision(d DecisionRequest) HumanDecision {
+	s.Decision <- d
+	if d.Timeout <= 0 {
+		return <-s.Response
+	}
+
+	select {
+	case resp := <-s.Response:
+		return resp
+	case <-time.After(d.Timeout):
+		action := d.DefaultAction
+		if action == "" {
+			action = DecisionActionApprove
+		}
+		return HumanDecision{RequestID: d.ID, Action: action, Comment: "timed out, auto-selected default"}
+	}
+}
+
 // Close closes all channels safely. It is idempotent.
 func (s *WorkflowStream) Close() {
 	if s == nil {
@@ -51,10 +89,16 @@ func (s *WorkflowStream) Close() {
 		close(s.Handoffs)
 		close(s.Tokens)
 
+		close(s.Thinking)
+		close(s.AgentLog)
+		close(s.FileDiff)
+		close(s.FileTree)
+		close(s.Metrics)
+
 		close(s.Decision)
 		close(s.Response)
 
 		close(s.Done)
 		close(s.Error)
 	})
-}
\ No newline at end of file
+}
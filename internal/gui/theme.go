@@ -21,8 +21,8 @@ type Theme struct {
 	Cyan          color.NRGBA
 }
 
-// DefaultTheme is the futuristic dark theme palette.
-var DefaultTheme = Theme{
+// DarkTheme is the futuristic dark theme palette, and the GUI's default.
+var DarkTheme = Theme{
 	Background:    HexToNRGBA("#0a0e17"),
 	PanelBg:       HexToNRGBA("#0d1520"),
 	Border:        HexToNRGBA("#1a3a4a"),
@@ -36,6 +36,88 @@ var DefaultTheme = Theme{
 	Cyan:          HexToNRGBA("#00ffff"),
 }
 
+// LightTheme mirrors the TUI's light theme (see internal/tui/styles.Light)
+// so the two frontends offer matching options, for terminals/displays where
+// the neon palette is hard to read.
+var LightTheme = Theme{
+	Background:    HexToNRGBA("#f5f5f5"),
+	PanelBg:       HexToNRGBA("#e9e9ec"),
+	Border:        HexToNRGBA("#c7ccd1"),
+	BorderActive:  HexToNRGBA("#0086b3"),
+	TextPrimary:   HexToNRGBA("#1a1a1a"),
+	TextSecondary: HexToNRGBA("#6b7280"),
+	Success:       HexToNRGBA("#0a8f4f"),
+	Error:         HexToNRGBA("#cc2222"),
+	Warning:       HexToNRGBA("#a15c00"),
+	Accent:        HexToNRGBA("#a3009c"),
+	Cyan:          HexToNRGBA("#0086b3"),
+}
+
+// NamedTheme pairs a Theme with the name used to select it, matching
+// internal/tui/styles.NamedTheme's role for the TUI.
+type NamedTheme struct {
+	Name  string
+	Theme Theme
+}
+
+// ThemeRegistry lists the themes selectable by name, in Cycle order.
+var ThemeRegistry = []NamedTheme{
+	{Name: "dark", Theme: DarkTheme},
+	{Name: "light", Theme: LightTheme},
+}
+
+// DefaultTheme is the active theme palette. Prefer SetTheme or CycleTheme
+// over assigning this directly, since widgets read it live every frame.
+var DefaultTheme = DarkTheme
+
+// currentThemeName tracks which ThemeRegistry entry DefaultTheme came from.
+var currentThemeName = "dark"
+
+// ThemeByName returns the registered theme with the given name, matched
+// case-insensitively.
+func ThemeByName(name string) (Theme, bool) {
+	for _, nt := range ThemeRegistry {
+		if strings.EqualFold(nt.Name, name) {
+			return nt.Theme, true
+		}
+	}
+	return Theme{}, false
+}
+
+// SetTheme switches DefaultTheme to the named theme. It reports whether
+// name was recognized; unknown names leave the active theme unchanged.
+func SetTheme(name string) bool {
+	t, ok := ThemeByName(name)
+	if !ok {
+		return false
+	}
+	DefaultTheme = t
+	currentThemeName = strings.ToLower(name)
+	return true
+}
+
+// CycleTheme advances DefaultTheme to the next theme in ThemeRegistry,
+// wrapping around, and returns the name of the newly active theme.
+func CycleTheme() string {
+	for i, nt := range ThemeRegistry {
+		if nt.Name == currentThemeName {
+			next := ThemeRegistry[(i+1)%len(ThemeRegistry)]
+			DefaultTheme = next.Theme
+			currentThemeName = next.Name
+			return next.Name
+		}
+	}
+	first := ThemeRegistry[0]
+	DefaultTheme = first.Theme
+	currentThemeName = first.Name
+	return first.Name
+}
+
+// CurrentThemeName returns the name of the active theme.
+func CurrentThemeName() string {
+	return currentThemeName
+}
+
 // HexToNRGBA converts a hex color string into color.NRGBA.
 // Accepts forms: "#RRGGBB", "RRGGBB", "#RRGGBBAA", "RRGGBBAA".
 func HexToNRGBA(hex string) color.NRGBA {
@@ -84,4 +166,4 @@ func HexToNRGBA(hex string) color.NRGBA {
 func WithAlpha(c color.NRGBA, alpha uint8) color.NRGBA {
 	c.A = alpha
 	return c
-}
\ No newline at end of file
+}
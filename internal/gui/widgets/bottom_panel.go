@@ -4,7 +4,9 @@ package widgets
 import (
 	"image"
 	"image/color"
+	"strings"
 
+	"gioui.org/io/key"
 	"gioui.org/layout"
 	"gioui.org/op/clip"
 	"gioui.org/op/paint"
@@ -14,12 +16,22 @@ import (
 	"gioui.org/widget/material"
 )
 
+// BottomPanelOption describes one choice offered alongside a decision
+// prompt, mirroring stream.DecisionOptionSpec without importing the stream
+// package (the widgets package stays decoupled from wire types).
+type BottomPanelOption struct {
+	Key         string
+	Label       string
+	Description string
+	Danger      bool
+}
+
 // BottomPanel displays decision prompts with action buttons and an optional text input.
 // It is only visible when Visible is true (typically when WaitingForInput).
 type BottomPanel struct {
 	Title   string
 	Prompt  string
-	Options []string
+	Options []BottomPanelOption
 	Visible bool
 
 	// Callbacks for button actions
@@ -60,6 +72,25 @@ func NewBottomPanel() *BottomPanel {
 	return bp
 }
 
+// Focus moves keyboard focus to the comment editor, so an "edit" keyboard
+// shortcut can start typing a comment without a mouse click first.
+func (bp *BottomPanel) Focus(gtx layout.Context) {
+	gtx.Execute(key.FocusCmd{Tag: &bp.editor})
+}
+
+// optionFor returns the option spec whose label matches name (case-insensitive),
+// or nil if Options carries no matching entry.
+func (bp *BottomPanel) optionFor(name string) *BottomPanelOption {
+	for i, opt := range bp.Options {
+		if strings.EqualFold(opt.Label, name) {
+			return &bp.Options[i]
+		}
+	}
+	return nil
+}
+
+var dangerColor = color.NRGBA{R: 0xFF, G: 0x44, B: 0x66, A: 0xFF}
+
 // Layout renders the bottom panel. Returns zero dimensions if not visible.
 func (bp *BottomPanel) Layout(gtx layout.Context, th *material.Theme) layout.Dimensions {
 	if !bp.Visible {
@@ -75,6 +106,28 @@ func (bp *BottomPanel) Layout(gtx layout.Context, th *material.Theme) layout.Dim
 		}
 	}
 
+	// Options carries richer per-choice metadata (description, danger) than
+	// the three fixed buttons below; apply danger styling to whichever
+	// button its label matches so a "Reject" marked Danger stays highlighted
+	// even if the orchestrator relabels it.
+	bp.approveBtn.Color = color.NRGBA{R: 0x00, G: 0xFF, B: 0x88, A: 0xFF}
+	bp.rejectBtn.Color = color.NRGBA{R: 0xFF, G: 0x44, B: 0x66, A: 0xFF}
+	bp.editBtn.Color = color.NRGBA{R: 0x00, G: 0xFF, B: 0xFF, A: 0xFF}
+	if opt := bp.optionFor(bp.approveBtn.Text); opt != nil && opt.Danger {
+		bp.approveBtn.Color = dangerColor
+	}
+	if opt := bp.optionFor(bp.editBtn.Text); opt != nil && opt.Danger {
+		bp.editBtn.Color = dangerColor
+	}
+
+	var descriptions []string
+	for _, opt := range bp.Options {
+		if opt.Description != "" {
+			descriptions = append(descriptions, opt.Label+": "+opt.Description)
+		}
+	}
+	helpText := strings.Join(descriptions, "   ")
+
 	// Panel background color (dark with slight transparency)
 	panelBg := color.NRGBA{R: 0x0d, G: 0x15, B: 0x20, A: 0xFF}
 	borderColor := color.NRGBA{R: 0x00, G: 0xFF, B: 0xFF, A: 0xFF}
@@ -121,6 +174,14 @@ func (bp *BottomPanel) Layout(gtx layout.Context, th *material.Theme) layout.Dim
 				lbl.Color = color.NRGBA{R: 0x88, G: 0x99, B: 0xAA, A: 0xFF}
 				return lbl.Layout(gtx)
 			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				if helpText == "" {
+					return layout.Dimensions{}
+				}
+				lbl := material.Caption(th, helpText)
+				lbl.Color = color.NRGBA{R: 0x66, G: 0x77, B: 0x88, A: 0xFF}
+				return lbl.Layout(gtx)
+			}),
 			layout.Rigid(layout.Spacer{Height: unit.Dp(12)}.Layout),
 
 			// Buttons row
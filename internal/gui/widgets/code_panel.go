@@ -23,6 +23,7 @@ import (
 type CodePanel struct {
 	Code     string
 	Language string
+	Path     string
 
 	list widget.List
 
@@ -67,21 +68,96 @@ func (cp *CodePanel) Layout(gtx layout.Context, th *material.Theme) layout.Dimen
 
 	inset := layout.UniformInset(unit.Dp(12))
 	return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-		if cp.errLine != "" {
-			lbl := material.Body2(th, cp.errLine)
-			lbl.Color = color.NRGBA{R: 0xFF, G: 0x66, B: 0x66, A: 0xFF}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return cp.layoutHeader(gtx, th)
+			}),
+			layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+			layout.Flexed(1.0, func(gtx layout.Context) layout.Dimensions {
+				return cp.layoutBody(gtx, th)
+			}),
+		)
+	})
+}
+
+// layoutHeader renders the source path and language, mirroring the TUI's
+// CodeBlock header (filename, then a muted "[language]" tag).
+func (cp *CodePanel) layoutHeader(gtx layout.Context, th *material.Theme) layout.Dimensions {
+	title := cp.Path
+	if title == "" {
+		title = "CODE"
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body2(th, title)
 			lbl.Font = cp.face
-			lbl.Alignment = text.Start
+			lbl.Color = color.NRGBA{R: 0x00, G: 0xFF, B: 0xFF, A: 0xFF}
+			lbl.MaxLines = 1
 			return lbl.Layout(gtx)
-		}
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if cp.Language == "" {
+				return layout.Dimensions{}
+			}
+			return layout.Inset{Left: unit.Dp(8)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				lbl := material.Caption(th, "["+cp.Language+"]")
+				lbl.Color = color.NRGBA{R: 0x66, G: 0x77, B: 0x88, A: 0xFF}
+				return lbl.Layout(gtx)
+			})
+		}),
+	)
+}
+
+func (cp *CodePanel) layoutBody(gtx layout.Context, th *material.Theme) layout.Dimensions {
+	if cp.errLine != "" {
+		lbl := material.Body2(th, cp.errLine)
+		lbl.Color = color.NRGBA{R: 0xFF, G: 0x66, B: 0x66, A: 0xFF}
+		lbl.Font = cp.face
+		lbl.Alignment = text.Start
+		return lbl.Layout(gtx)
+	}
+
+	if cp.Code == "" {
+		lbl := material.Body2(th, "No code to display")
+		lbl.Color = color.NRGBA{R: 0x66, G: 0x77, B: 0x88, A: 0xFF}
+		return lbl.Layout(gtx)
+	}
 
-		return material.List(th, &cp.list).Layout(gtx, len(cp.lines), func(gtx layout.Context, i int) layout.Dimensions {
-			ln := cp.lines[i]
-			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, cp.runFlexChildren(th, ln)...)
-		})
+	// material.List only builds rows currently in view, so scrolling large
+	// files doesn't re-lay-out the whole buffer each frame.
+	numWidth := lineNumberWidth(len(cp.lines))
+	return material.List(th, &cp.list).Layout(gtx, len(cp.lines), func(gtx layout.Context, i int) layout.Dimensions {
+		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, cp.rowFlexChildren(th, i, numWidth)...)
 	})
 }
 
+func (cp *CodePanel) rowFlexChildren(th *material.Theme, idx, numWidth int) []layout.FlexChild {
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body2(th, fmt.Sprintf("%*d", numWidth, idx+1))
+			lbl.Font = cp.face
+			lbl.Color = color.NRGBA{R: 0x55, G: 0x66, B: 0x77, A: 0xFF}
+			lbl.MaxLines = 1
+			return lbl.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body2(th, " │ ")
+			lbl.Font = cp.face
+			lbl.Color = color.NRGBA{R: 0x2a, G: 0x3a, B: 0x4a, A: 0xFF}
+			lbl.MaxLines = 1
+			return lbl.Layout(gtx)
+		}),
+	}
+	return append(children, cp.runFlexChildren(th, cp.lines[idx])...)
+}
+
+// lineNumberWidth returns how many characters the largest line number needs,
+// so the gutter stays a fixed width instead of shifting as digits grow.
+func lineNumberWidth(numLines int) int {
+	return len(fmt.Sprintf("%d", numLines))
+}
+
 func (cp *CodePanel) runFlexChildren(th *material.Theme, ln codeLine) []layout.FlexChild {
 	if len(ln.Runs) == 0 {
 		return []layout.FlexChild{
@@ -211,4 +287,4 @@ func chromaColourToNRGBA(c chroma.Colour) color.NRGBA {
 	// chroma.Colour is uint32 with RGBA packed
 	// If brightness is 0, the color might be unset, use full alpha
 	return color.NRGBA{R: c.Red(), G: c.Green(), B: c.Blue(), A: 0xFF}
-}
\ No newline at end of file
+}
@@ -18,6 +18,7 @@ type MainPanel struct {
 	ActivityLog []string
 	CodeContent string
 	CodeLang    string
+	CodePath    string
 
 	activityList widget.List
 	codePanel    *CodePanel
@@ -140,26 +141,12 @@ func (mp *MainPanel) layoutSeparator(gtx layout.Context) layout.Dimensions {
 }
 
 func (mp *MainPanel) layoutCodeSection(gtx layout.Context, th *material.Theme) layout.Dimensions {
-	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-		// Section header
-		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-			title := "CODE"
-			if mp.CodeLang != "" {
-				title = "CODE (" + mp.CodeLang + ")"
-			}
-			lbl := material.Caption(th, title)
-			lbl.Color = color.NRGBA{R: 0x00, G: 0xFF, B: 0xFF, A: 0xFF}
-			return lbl.Layout(gtx)
-		}),
-		layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
-
-		// Code panel
-		layout.Flexed(1.0, func(gtx layout.Context) layout.Dimensions {
-			mp.codePanel.Code = mp.CodeContent
-			mp.codePanel.Language = mp.CodeLang
-			return mp.codePanel.Layout(gtx, th)
-		}),
-	)
+	// CodePanel renders its own path/language header, so this section is
+	// just the panel itself.
+	mp.codePanel.Code = mp.CodeContent
+	mp.codePanel.Language = mp.CodeLang
+	mp.codePanel.Path = mp.CodePath
+	return mp.codePanel.Layout(gtx, th)
 }
 
 // SetActivityLog updates the activity log entries.
@@ -172,10 +159,11 @@ func (mp *MainPanel) SetActivityLog(log []string) {
 	}
 }
 
-// SetCode updates the code display content.
-func (mp *MainPanel) SetCode(content, lang string) {
+// SetCode updates the code display content, language, and source path.
+func (mp *MainPanel) SetCode(content, lang, path string) {
 	mp.CodeContent = content
 	mp.CodeLang = lang
+	mp.CodePath = path
 }
 
 // AppendActivity adds a new activity entry and scrolls to show it.
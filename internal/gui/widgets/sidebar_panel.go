@@ -31,13 +31,24 @@ type HandoffEntry struct {
 	Timestamp time.Time
 }
 
-// SidebarPanel displays workflow steps and handoff history.
+// FileTreeEntry represents one file in the generated file tree for display.
+type FileTreeEntry struct {
+	Path   string
+	Action string // "add", "modify", "delete"
+	IsDir  bool
+}
+
+// SidebarPanel displays workflow steps, the generated file tree, and
+// handoff history. Live cost/usage metrics are shown in the header strip
+// instead (see App.layoutMetricsStrip).
 type SidebarPanel struct {
 	Steps          []WorkflowStep
+	FileTree       []FileTreeEntry
 	HandoffHistory []HandoffEntry
 	CurrentStep    int
 
 	stepsList   widget.List
+	fileList    widget.List
 	handoffList widget.List
 
 	// Progress widgets for each step (reused)
@@ -50,6 +61,9 @@ func NewSidebarPanel() *SidebarPanel {
 		stepsList: widget.List{
 			List: layout.List{Axis: layout.Vertical},
 		},
+		fileList: widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
 		handoffList: widget.List{
 			List: layout.List{Axis: layout.Vertical},
 		},
@@ -87,13 +101,26 @@ func (sp *SidebarPanel) Layout(gtx layout.Context, th *material.Theme) layout.Di
 			}),
 			layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
 
-			// Workflow steps list (takes 60% of space)
-			layout.Flexed(0.6, func(gtx layout.Context) layout.Dimensions {
+			// Workflow steps list
+			layout.Flexed(0.4, func(gtx layout.Context) layout.Dimensions {
 				return sp.layoutSteps(gtx, th)
 			}),
 
 			layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
 
+			// Section header: Files
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return sp.sectionHeader(gtx, th, "FILES")
+			}),
+			layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
+
+			// Generated file tree
+			layout.Flexed(0.3, func(gtx layout.Context) layout.Dimensions {
+				return sp.layoutFileTree(gtx, th)
+			}),
+
+			layout.Rigid(layout.Spacer{Height: unit.Dp(16)}.Layout),
+
 			// Section header: Handoffs
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				return sp.sectionHeader(gtx, th, "HANDOFFS")
@@ -101,7 +128,7 @@ func (sp *SidebarPanel) Layout(gtx layout.Context, th *material.Theme) layout.Di
 			layout.Rigid(layout.Spacer{Height: unit.Dp(8)}.Layout),
 
 			// Handoff history list (takes remaining space)
-			layout.Flexed(0.4, func(gtx layout.Context) layout.Dimensions {
+			layout.Flexed(0.3, func(gtx layout.Context) layout.Dimensions {
 				return sp.layoutHandoffs(gtx, th)
 			}),
 		)
@@ -213,6 +240,35 @@ func (sp *SidebarPanel) statusDot(gtx layout.Context, status string, isCurrent b
 	return layout.Dimensions{Size: size}
 }
 
+func (sp *SidebarPanel) layoutFileTree(gtx layout.Context, th *material.Theme) layout.Dimensions {
+	if len(sp.FileTree) == 0 {
+		lbl := material.Body2(th, "No files yet")
+		lbl.Color = color.NRGBA{R: 0x66, G: 0x77, B: 0x88, A: 0xFF}
+		return lbl.Layout(gtx)
+	}
+
+	return material.List(th, &sp.fileList).Layout(gtx, len(sp.FileTree), func(gtx layout.Context, i int) layout.Dimensions {
+		return sp.layoutFileEntry(gtx, th, sp.FileTree[i])
+	})
+}
+
+func (sp *SidebarPanel) layoutFileEntry(gtx layout.Context, th *material.Theme, f FileTreeEntry) layout.Dimensions {
+	return layout.Inset{Bottom: unit.Dp(4)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		lbl := material.Body2(th, f.Path)
+		switch f.Action {
+		case "add":
+			lbl.Color = color.NRGBA{R: 0x00, G: 0xFF, B: 0x88, A: 0xFF} // Green
+		case "modify":
+			lbl.Color = color.NRGBA{R: 0xFF, G: 0xAA, B: 0x00, A: 0xFF} // Orange
+		case "delete":
+			lbl.Color = color.NRGBA{R: 0xFF, G: 0x44, B: 0x44, A: 0xFF} // Red
+		default:
+			lbl.Color = color.NRGBA{R: 0x88, G: 0x99, B: 0xAA, A: 0xFF}
+		}
+		return lbl.Layout(gtx)
+	})
+}
+
 func (sp *SidebarPanel) layoutHandoffs(gtx layout.Context, th *material.Theme) layout.Dimensions {
 	if len(sp.HandoffHistory) == 0 {
 		lbl := material.Body2(th, "No handoffs yet")
@@ -269,6 +325,11 @@ func (sp *SidebarPanel) SetHandoffs(history []HandoffEntry) {
 	sp.HandoffHistory = history
 }
 
+// SetFileTree updates the generated file tree display.
+func (sp *SidebarPanel) SetFileTree(files []FileTreeEntry) {
+	sp.FileTree = files
+}
+
 // SetCurrentStep sets the currently active step index.
 func (sp *SidebarPanel) SetCurrentStep(idx int) {
 	sp.CurrentStep = idx
@@ -2,13 +2,26 @@
 package logging
 
 import (
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
 )
 
-// Setup initializes the global logger with the specified level.
+// Setup initializes the global logger with the specified level, writing to
+// stderr.
 func Setup(level string) {
+	_ = SetupWithOutput(level, false, "")
+}
+
+// SetupWithOutput initializes the global logger with the specified level and
+// destination. quiet raises the effective level to error-only regardless of
+// level, so routine progress logging doesn't compete with a plain CLI run's
+// own start/complete/summary output. When logFile is non-empty, logs are
+// appended there instead of stderr, so structured output can be captured
+// for debugging without polluting the terminal.
+func SetupWithOutput(level string, quiet bool, logFile string) error {
 	var logLevel slog.Level
 	switch strings.ToLower(level) {
 	case "debug":
@@ -20,11 +33,24 @@ func Setup(level string) {
 	default:
 		logLevel = slog.LevelInfo
 	}
+	if quiet && logLevel < slog.LevelError {
+		logLevel = slog.LevelError
+	}
+
+	var out io.Writer = os.Stderr
+	if logFile != "" {
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("open log file: %w", err)
+		}
+		out = f
+	}
 
-	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+	handler := slog.NewTextHandler(out, &slog.HandlerOptions{
 		Level: logLevel,
 	})
 	slog.SetDefault(slog.New(handler))
+	return nil
 }
 
 // Route logs a routing decision.
@@ -0,0 +1,156 @@
+// Package metrics exposes a running workflow's broker events as Prometheus
+// metrics, for scraping by an external monitoring stack instead of reading
+// the TUI/GUI.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"cooperations/internal/tui/stream"
+)
+
+// eventBuffer is the exporter's broker subscription buffer. Metrics are
+// best-effort observers, so a slow scrape interval drops old events rather
+// than backpressuring the workflow.
+const eventBuffer = 64
+
+// Exporter subscribes to an orchestrator's event broker and maintains
+// Prometheus counters, gauges, and histograms from what it sees, served at
+// /metrics.
+type Exporter struct {
+	roleModel   map[string]string
+	http        *http.Server
+	events      <-chan stream.Event
+	unsubscribe func()
+
+	tasksRun      prometheus.Counter
+	tasksFailed   prometheus.Counter
+	reviewCycles  prometheus.Histogram
+	costUSD       prometheus.Gauge
+	tokensByModel *prometheus.CounterVec
+	agentDuration *prometheus.HistogramVec
+}
+
+// New creates an Exporter that labels per-role metrics with the model
+// profile roleModel maps that role to (falling back to the bare role name
+// for roles missing from the map), and serves /metrics on addr.
+func New(broker *stream.Broker, roleModel map[string]string, addr string) *Exporter {
+	reg := prometheus.NewRegistry()
+	factory := promauto.With(reg)
+
+	events, unsubscribe := broker.Subscribe(eventBuffer, stream.PolicyDropOldest)
+	e := &Exporter{
+		roleModel:   roleModel,
+		events:      events,
+		unsubscribe: unsubscribe,
+		tasksRun: factory.NewCounter(prometheus.CounterOpts{
+			Name: "cooperations_tasks_run_total",
+			Help: "Total number of workflow runs completed (successful or not).",
+		}),
+		tasksFailed: factory.NewCounter(prometheus.CounterOpts{
+			Name: "cooperations_tasks_failed_total",
+			Help: "Total number of workflow runs that finished unsuccessfully.",
+		}),
+		reviewCycles: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "cooperations_review_cycles",
+			Help:    "Number of review cycles a completed workflow run went through.",
+			Buckets: []float64{0, 1, 2, 3, 4, 5, 8},
+		}),
+		costUSD: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "cooperations_last_run_cost_usd",
+			Help: "Estimated cost in USD of the most recently completed workflow run.",
+		}),
+		tokensByModel: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "cooperations_tokens_total",
+			Help: "Total tokens used, by model profile.",
+		}, []string{"model"}),
+		agentDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cooperations_agent_duration_seconds",
+			Help:    "Duration of individual agent calls, by role.",
+			Buckets: []float64{0.5, 1, 2.5, 5, 10, 20, 40, 80, 160},
+		}, []string{"role"}),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	e.http = &http.Server{Addr: addr, Handler: mux}
+
+	return e
+}
+
+// ListenAndServe runs the HTTP server until ctx is canceled, then shuts it
+// down gracefully.
+func (e *Exporter) ListenAndServe(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if err := e.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := e.http.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("shutdown metrics server: %w", err)
+		}
+		<-errCh
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// Run updates metrics from every broker event received (subscribed back in
+// New, so no event published after construction is missed) until ctx is
+// canceled.
+func (e *Exporter) Run(ctx context.Context) {
+	defer e.unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt, ok := <-e.events:
+			if !ok {
+				return
+			}
+			e.observe(evt)
+		}
+	}
+}
+
+// modelFor returns the model profile a role runs against, falling back to
+// the bare role name when it isn't in roleModel (e.g. the "user" pseudo-role
+// on the initial handoff).
+func (e *Exporter) modelFor(role string) string {
+	if model, ok := e.roleModel[role]; ok && model != "" {
+		return model
+	}
+	return role
+}
+
+func (e *Exporter) observe(evt stream.Event) {
+	switch p := evt.Payload.(type) {
+	case stream.AgentCompletion:
+		e.tokensByModel.WithLabelValues(e.modelFor(p.Role)).Add(float64(p.TokensUsed))
+		e.agentDuration.WithLabelValues(p.Role).Observe(float64(p.DurationMS) / 1000)
+	case stream.WorkflowSummary:
+		e.tasksRun.Inc()
+		if !p.Success {
+			e.tasksFailed.Inc()
+		}
+		e.reviewCycles.Observe(float64(p.ReviewCycles))
+		e.costUSD.Set(p.EstimatedCostUSD)
+	}
+}
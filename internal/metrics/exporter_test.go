@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cooperations/internal/tui/stream"
+)
+
+func TestExporterObservesAgentCompletionAndSummary(t *testing.T) {
+	broker := stream.NewBroker()
+	e := New(broker, map[string]string{"architect": "gpt-5"}, ":0")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	broker.Publish(context.Background(), "agent_complete", stream.AgentCompletion{
+		Role: "architect", DurationMS: 2500, TokensUsed: 120,
+	})
+	broker.Publish(context.Background(), "summary", stream.WorkflowSummary{
+		Success: false, ReviewCycles: 2, EstimatedCostUSD: 0.42,
+	})
+
+	// Give the Run goroutine a moment to drain the buffered events.
+	time.Sleep(50 * time.Millisecond)
+
+	srv := httptest.NewServer(e.http.Handler)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(body)
+
+	for _, want := range []string{
+		`cooperations_tokens_total{model="gpt-5"} 120`,
+		`cooperations_tasks_run_total 1`,
+		`cooperations_tasks_failed_total 1`,
+		`cooperations_last_run_cost_usd 0.42`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics output missing %q\nfull output:\n%s", want, out)
+		}
+	}
+}
+
+func TestModelForFallsBackToRoleName(t *testing.T) {
+	e := New(stream.NewBroker(), map[string]string{"architect": "gpt-5"}, ":0")
+
+	if got := e.modelFor("architect"); got != "gpt-5" {
+		t.Errorf("modelFor(architect) = %q, want gpt-5", got)
+	}
+	if got := e.modelFor("user"); got != "user" {
+		t.Errorf("modelFor(user) = %q, want fallback to role name", got)
+	}
+}
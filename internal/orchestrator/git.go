@@ -0,0 +1,72 @@
+package orchestrator
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+)
+
+// runGit runs a git subcommand rooted at dir and returns its combined
+// stdout+stderr, wrapped with the command line on failure so callers get an
+// actionable error without needing to shell out themselves.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return out.String(), fmt.Errorf("git %v: %w: %s", args, err, out.String())
+	}
+	return out.String(), nil
+}
+
+// isGitRepo reports whether dir is inside a git working tree.
+func isGitRepo(dir string) bool {
+	out, err := runGit(dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil && bytes.Contains([]byte(out), []byte("true"))
+}
+
+// gitCheckoutBranch switches to branch, creating it from the current HEAD
+// if it doesn't already exist.
+func gitCheckoutBranch(dir, branch string) error {
+	if !isGitRepo(dir) {
+		return fmt.Errorf("%s is not a git repository", dir)
+	}
+	if _, err := runGit(dir, "checkout", branch); err == nil {
+		return nil
+	}
+	_, err := runGit(dir, "checkout", "-b", branch)
+	return err
+}
+
+// gitCommitChanges stages exactly the given paths (relative to dir) and
+// commits them with message. Pre-existing staged changes to other files
+// are left untouched: paths are added individually rather than via a
+// blanket `git add -A`, and nothing outside paths is touched or reset. It's
+// a no-op, not an error, when none of paths have anything to commit (e.g.
+// they were reverted back to their original content during the run).
+func gitCommitChanges(dir string, paths []string, message string) error {
+	if !isGitRepo(dir) {
+		return fmt.Errorf("%s is not a git repository", dir)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	args := append([]string{"add", "--"}, paths...)
+	if _, err := runGit(dir, args...); err != nil {
+		return fmt.Errorf("stage changes: %w", err)
+	}
+
+	diffArgs := append([]string{"diff", "--cached", "--quiet", "--"}, paths...)
+	if _, err := runGit(dir, diffArgs...); err == nil {
+		// Exit status 0 means no staged differences among these paths.
+		return nil
+	}
+
+	if _, err := runGit(dir, "commit", "-m", message); err != nil {
+		return fmt.Errorf("commit changes: %w", err)
+	}
+	return nil
+}
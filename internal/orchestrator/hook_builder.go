@@ -0,0 +1,112 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"cooperations/internal/types"
+)
+
+// HookBuilder wraps a HookController with ergonomic On* registration
+// helpers, so library users don't need to know which HookPhase constant
+// corresponds to which point in the workflow.
+type HookBuilder struct {
+	hooks *HookController
+}
+
+// NewHookBuilder wraps hooks with the On* registration helpers.
+func NewHookBuilder(hooks *HookController) *HookBuilder {
+	return &HookBuilder{hooks: hooks}
+}
+
+// OnWorkflowStart registers fn to run once, before the first agent executes.
+func (b *HookBuilder) OnWorkflowStart(priority int, fn Hook) string {
+	return b.hooks.Register(HookPhaseWorkflowStart, priority, fn)
+}
+
+// OnPreAgent registers fn to run immediately before an agent executes.
+func (b *HookBuilder) OnPreAgent(priority int, fn Hook) string {
+	return b.hooks.Register(HookPhasePreAgent, priority, fn)
+}
+
+// OnMidAgent registers fn to run as a streaming agent's partial output
+// arrives; event.Metadata["partial_output"] and event.Metadata["chunk"]
+// carry what's been produced so far.
+func (b *HookBuilder) OnMidAgent(priority int, fn Hook) string {
+	return b.hooks.Register(HookPhaseMidAgent, priority, fn)
+}
+
+// OnPostAgent registers fn to run after an agent's response is available;
+// event.Response holds it.
+func (b *HookBuilder) OnPostAgent(priority int, fn Hook) string {
+	return b.hooks.Register(HookPhasePostAgent, priority, fn)
+}
+
+// OnPreHandoff registers fn to run before a handoff is passed to the next role.
+func (b *HookBuilder) OnPreHandoff(priority int, fn Hook) string {
+	return b.hooks.Register(HookPhasePreHandoff, priority, fn)
+}
+
+// OnPostHandoff registers fn to run after a handoff has been passed on.
+func (b *HookBuilder) OnPostHandoff(priority int, fn Hook) string {
+	return b.hooks.Register(HookPhasePostHandoff, priority, fn)
+}
+
+// OnWorkflowEnd registers fn to run once the workflow completes or aborts.
+func (b *HookBuilder) OnWorkflowEnd(priority int, fn Hook) string {
+	return b.hooks.Register(HookPhaseWorkflowEnd, priority, fn)
+}
+
+// Continue lets the workflow proceed unmodified.
+func Continue() HookResult {
+	return HookResult{Continue: true}
+}
+
+// Skip continues the workflow but skips the current agent.
+func Skip() HookResult {
+	return HookResult{Continue: true, Skip: true}
+}
+
+// Pause halts the workflow at this hook point until resumed.
+func Pause() HookResult {
+	return HookResult{Continue: false}
+}
+
+// Kill aborts the workflow with err as the reported cause.
+func Kill(err error) HookResult {
+	return HookResult{Kill: true, Error: err}
+}
+
+// ReplaceHandoff continues the workflow with h in place of the handoff that
+// was about to be passed on.
+func ReplaceHandoff(h types.Handoff) HookResult {
+	return HookResult{Continue: true, ModifiedHandoff: &h}
+}
+
+// ReplaceResponse continues the workflow with r in place of the agent's
+// response.
+func ReplaceResponse(r types.AgentResponse) HookResult {
+	return HookResult{Continue: true, ModifiedResponse: &r}
+}
+
+// awsKeyPattern matches the common AKIA-prefixed AWS access key ID format.
+var awsKeyPattern = regexp.MustCompile(`AKIA[0-9A-Z]{16}`)
+
+// KillOnLeakedSecrets returns a hook that aborts the workflow if an agent's
+// response looks like it contains an AWS access key. Register it as a
+// post-agent hook:
+//
+//	builder := orchestrator.NewHookBuilder(orch.Hooks())
+//	builder.OnPostAgent(0, orchestrator.KillOnLeakedSecrets())
+func KillOnLeakedSecrets() Hook {
+	return func(ctx context.Context, event HookEvent) HookResult {
+		if event.Response == nil {
+			return Continue()
+		}
+		if awsKeyPattern.MatchString(event.Response.Content) {
+			return Kill(fmt.Errorf("response from %s contains a likely AWS access key, aborting", event.CurrentRole))
+		}
+		return Continue()
+	}
+}
@@ -2,29 +2,68 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"cooperations/internal/adapters"
 	"cooperations/internal/agents"
 	coopctx "cooperations/internal/context"
+	"cooperations/internal/logging"
 	"cooperations/internal/rvr"
+	"cooperations/internal/tasklock"
 	"cooperations/internal/tui/stream"
 	"cooperations/internal/types"
 )
 
 // Orchestrator coordinates agents to complete tasks.
 type Orchestrator struct {
-	router        *Router
-	agents        map[types.Role]agents.Agent
-	store         *coopctx.Store
-	config        WorkflowConfig
-	stream        *stream.WorkflowStream // Optional stream for GUI events
-	roleProfiles  map[types.Role]string
-	modelProfiles map[string]ModelProfile
-	roleTaskTypes map[types.Role]string
-	rvrConfig     *rvr.RVRConfig
-	hooks         *HookController // Hook controller for workflow control
+	router              *Router
+	agents              map[types.Role]agents.Agent
+	store               *coopctx.Store
+	storeDir            string // Base directory backing store, also used for task locks
+	config              WorkflowConfig
+	stream              *stream.WorkflowStream // Optional stream for GUI events
+	broker              *stream.Broker         // Fan-out event bus; independent consumers (file recorder, metrics exporter, ...) subscribe here instead of racing the TUI/GUI for events off stream
+	recorder            *EventRecorder         // Active JSONL event recorder for the current run, when config.RecordEvents is set
+	roleProfiles        map[types.Role]string
+	modelProfiles       map[string]ModelProfile
+	roleTaskTypes       map[types.Role]string
+	rvrConfig           *rvr.RVRConfig
+	hooks               *HookController    // Hook controller for workflow control
+	workspaceRoot       string             // Root directory that all generated file reads/writes are confined to
+	writeLedger         []writeLedgerEntry // Files written by the current run, for kill cleanup and change summaries
+	lastProgressPercent float64            // Highest percent emitted so far this run, so progress never moves backward
+}
+
+// resolvePrompts turns each configured per-role system prompt override into
+// its final text. An entry starting with "@" names a file (relative paths
+// are resolved against the current working directory) whose contents are
+// used verbatim; anything else is treated as literal prompt text. Referenced
+// files are validated up front so a typo'd path fails fast at construction
+// time rather than surfacing as a confusing prompt mid-run.
+func resolvePrompts(prompts map[types.Role]string) (map[types.Role]string, error) {
+	if len(prompts) == 0 {
+		return prompts, nil
+	}
+
+	resolved := make(map[types.Role]string, len(prompts))
+	for role, value := range prompts {
+		path, isFile := strings.CutPrefix(value, "@")
+		if !isFile {
+			resolved[role] = value
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("load prompt for role %s: %w", role, err)
+		}
+		resolved[role] = string(content)
+	}
+	return resolved, nil
 }
 
 // New creates a new orchestrator with the given configuration.
@@ -34,9 +73,35 @@ func New(config WorkflowConfig) (*Orchestrator, error) {
 	if len(config.RoleTaskTypes) > 0 {
 		appCfg.Workflow.RoleTaskTypes = config.RoleTaskTypes
 	}
+	applyModelOverrides(&appCfg, config.ModelOverride, config.RoleModelOverrides)
 	return NewFromConfig(appCfg)
 }
 
+// applyModelOverrides layers config.ModelOverride/RoleModelOverrides onto
+// appCfg.Roles: modelOverride (from --model) replaces every role's
+// configured profile, then roleOverrides (from --role-model, e.g.
+// "architect=fast") replace individual roles on top of that. Both are
+// optional and a no-op when empty. Overridden profile names aren't
+// validated here - NewFromConfig's normal cfg.Models lookup does that,
+// so an unknown profile still fails fast with the same error a config
+// file typo would produce.
+func applyModelOverrides(appCfg *AppConfig, modelOverride string, roleOverrides map[string]string) {
+	if modelOverride == "" && len(roleOverrides) == 0 {
+		return
+	}
+	if appCfg.Roles == nil {
+		appCfg.Roles = make(map[types.Role]string)
+	}
+	if modelOverride != "" {
+		for _, role := range []types.Role{types.RoleArchitect, types.RoleImplementer, types.RoleReviewer, types.RoleNavigator} {
+			appCfg.Roles[role] = modelOverride
+		}
+	}
+	for role, profile := range roleOverrides {
+		appCfg.Roles[types.Role(role)] = profile
+	}
+}
+
 // NewFromConfig creates a new orchestrator from full app config.
 func NewFromConfig(cfg AppConfig) (*Orchestrator, error) {
 	cfg = ApplyAppDefaults(cfg)
@@ -70,6 +135,11 @@ func NewFromConfig(cfg AppConfig) (*Orchestrator, error) {
 	if err != nil {
 		return nil, err
 	}
+	resolvedPrompts, err := resolvePrompts(cfg.Prompts)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Prompts = resolvedPrompts
 
 	// Initialize CLIs per profile
 	cliCache := make(map[string]adapters.CLI)
@@ -87,54 +157,66 @@ func NewFromConfig(cfg AppConfig) (*Orchestrator, error) {
 
 		cli, ok := cliCache[profileName]
 		if !ok {
-			switch provider {
-			case "claude-cli":
-				created, err := adapters.NewClaudeCLIWithConfig(profile.Claude)
-				if err != nil {
-					return nil, fmt.Errorf("create claude CLI: %w", err)
-				}
-				cli = created
-			case "codex-cli":
-				created, err := adapters.NewCodexCLIWithConfig(repoRoot, profile.Codex)
-				if err != nil {
-					return nil, fmt.Errorf("create codex CLI: %w", err)
-				}
-				cli = created
-			default:
-				return nil, fmt.Errorf("unsupported provider: %s", provider)
+			created, err := adapters.NewCLI(provider, repoRoot, profile)
+			if err != nil {
+				return nil, fmt.Errorf("create CLI for profile %s: %w", profileName, err)
 			}
+			cli = created
 			cliCache[profileName] = cli
 		}
 
 		taskType := roleTaskTypes[role]
+		promptOverride := cfg.Prompts[role]
 		switch role {
 		case types.RoleArchitect:
-			agentMap[role] = agents.NewArchitectAgent(cli, &cfg.RVR, taskType)
+			agentMap[role] = agents.NewArchitectAgent(cli, &cfg.RVR, taskType, promptOverride)
 		case types.RoleImplementer:
-			agentMap[role] = agents.NewImplementerAgent(cli, &cfg.RVR, taskType)
+			agentMap[role] = agents.NewImplementerAgent(cli, &cfg.RVR, taskType, promptOverride)
 		case types.RoleReviewer:
-			agentMap[role] = agents.NewReviewerAgent(cli, &cfg.RVR, taskType)
+			agentMap[role] = agents.NewReviewerAgent(cli, &cfg.RVR, taskType, promptOverride)
 		case types.RoleNavigator:
-			agentMap[role] = agents.NewNavigatorAgent(cli, &cfg.RVR, taskType)
+			agentMap[role] = agents.NewNavigatorAgent(cli, &cfg.RVR, taskType, promptOverride)
 		case types.RoleHuman:
 			// Human agent not configured here
 		}
 	}
 
 	return &Orchestrator{
-		router:        NewRouter(),
+		router:        NewRouterFromConfig(cfg.Router),
 		agents:        agentMap,
 		store:         store,
+		storeDir:      storeDir,
 		config:        cfg.Workflow,
 		stream:        nil,
+		broker:        stream.NewBroker(),
 		roleProfiles:  roleProfiles,
 		modelProfiles: cfg.Models,
 		roleTaskTypes: roleTaskTypes,
 		rvrConfig:     &cfg.RVR,
 		hooks:         NewHookController(),
+		workspaceRoot: repoRoot,
 	}, nil
 }
 
+// SetWorkspaceRoot confines all generated file reads and writes to root
+// instead of the current working directory, so a run can be sandboxed into
+// a scratch directory (or several parallel runs kept from clobbering each
+// other's output).
+func (o *Orchestrator) SetWorkspaceRoot(root string) error {
+	if root == "" {
+		return fmt.Errorf("workspace root must not be empty")
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return fmt.Errorf("create workspace root: %w", err)
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("resolve workspace root: %w", err)
+	}
+	o.workspaceRoot = abs
+	return nil
+}
+
 // NewWithStream creates a new orchestrator that emits events to the given stream.
 func NewWithStream(config WorkflowConfig, ws *stream.WorkflowStream) (*Orchestrator, error) {
 	orch, err := New(config)
@@ -157,28 +239,79 @@ func NewWithStreamFromConfig(cfg AppConfig, ws *stream.WorkflowStream) (*Orchest
 
 // Run executes a task through the workflow.
 func (o *Orchestrator) Run(ctx context.Context, taskDescription string) (types.WorkflowResult, error) {
-	// Reset hook controller for new workflow
+	return o.RunWithTags(ctx, taskDescription, nil)
+}
+
+// RunWithTags runs a task the same way Run does, additionally attaching
+// tags (see --tag on `coop run`) to the created task so it can later be
+// grouped and filtered in `coop history`.
+func (o *Orchestrator) RunWithTags(ctx context.Context, taskDescription string, tags []string) (types.WorkflowResult, error) {
+	return o.RunWithPlan(ctx, taskDescription, tags, nil)
+}
+
+// RunWithPlan runs a task the same way RunWithTags does, except that
+// rolePlan, when non-empty, overrides the router and the default
+// architect -> implementer -> reviewer progression with an explicit,
+// human-edited role sequence (e.g. from a TUI plan/preview the director
+// reordered or trimmed roles from before confirming). Every role in
+// rolePlan must have a configured agent; pass nil for the normal,
+// router-driven behavior.
+func (o *Orchestrator) RunWithPlan(ctx context.Context, taskDescription string, tags []string, rolePlan []types.Role) (types.WorkflowResult, error) {
+	if err := o.validateRolePlan(rolePlan); err != nil {
+		return types.WorkflowResult{}, err
+	}
+
+	// Reset hook controller and write ledger for new workflow
 	o.hooks.Reset()
+	o.writeLedger = nil
+	o.lastProgressPercent = 0
 
 	// Start control listener if stream is available
 	o.startControlListener(ctx)
 
 	// Create task
-	task, err := o.store.CreateTask(taskDescription)
+	task, err := o.store.CreateTaskWithTags(taskDescription, tags)
 	if err != nil {
 		return types.WorkflowResult{}, fmt.Errorf("create task: %w", err)
 	}
 
+	// Take an advisory lock on the task so a second process attaching to it
+	// (e.g. a TUI replay of the same session/handoffs) doesn't interleave
+	// writes with this run.
+	lock, err := tasklock.Acquire(o.storeDir, task.ID)
+	if err != nil {
+		if errors.Is(err, tasklock.ErrLocked) {
+			return types.WorkflowResult{}, fmt.Errorf("task %s is busy in another process", task.ID)
+		}
+		return types.WorkflowResult{}, fmt.Errorf("acquire task lock: %w", err)
+	}
+	defer lock.Release()
+
 	// Update task status
 	if err := o.store.UpdateTaskStatus(task.ID, types.TaskStatusInProgress); err != nil {
 		return types.WorkflowResult{}, fmt.Errorf("update task status: %w", err)
 	}
 
-	// Route to initial role
+	if o.config.GitCommit && o.config.GitBranch != "" {
+		branch := strings.NewReplacer("{task_id}", task.ID).Replace(o.config.GitBranch)
+		if err := gitCheckoutBranch(o.workspaceRoot, branch); err != nil {
+			logging.Error("failed to check out git branch, continuing on current branch", err, "branch", branch)
+		}
+	}
+
+	// Route to initial role, unless rolePlan already pins one
 	initialRole := o.router.Route(taskDescription)
+	if len(rolePlan) > 0 {
+		initialRole = rolePlan[0]
+	}
+
+	// Start durable event recording, if configured, before the workflow
+	// emits anything so nothing is missed.
+	o.startEventRecording(ctx, task.ID)
+	defer o.stopEventRecording()
 
 	// Execute workflow
-	result, err := o.executeWorkflow(ctx, task, initialRole)
+	result, err := o.executeWorkflow(ctx, task, initialRole, rolePlan)
 
 	// Update final task status
 	finalStatus := types.TaskStatusCompleted
@@ -193,11 +326,64 @@ func (o *Orchestrator) Run(ctx context.Context, taskDescription string) (types.W
 	return result, err
 }
 
+// validateRolePlan checks that every role in a human-edited plan sequence
+// has a configured agent, so an unassignable role is rejected up front
+// instead of aborting mid-run once the workflow reaches it.
+func (o *Orchestrator) validateRolePlan(rolePlan []types.Role) error {
+	for _, role := range rolePlan {
+		if _, ok := o.agents[role]; !ok {
+			return fmt.Errorf("no agent configured for role %q", role)
+		}
+	}
+	return nil
+}
+
 // DryRun shows the routing decision without executing.
 func (o *Orchestrator) DryRun(taskDescription string) (types.Role, float64) {
 	return o.router.RouteWithConfidence(taskDescription)
 }
 
+// Plan previews the full role sequence a task would take: it routes the
+// same way a real run would, then walks the default role progression from
+// there (unlike DryRun, which only reports the first route), estimating
+// each step's tokens/duration from history. It doesn't touch the task
+// store, since nothing about the run is committed yet.
+func (o *Orchestrator) Plan(taskDescription string) (types.WorkflowPlan, error) {
+	if strings.TrimSpace(taskDescription) == "" {
+		return types.WorkflowPlan{}, fmt.Errorf("task description is empty")
+	}
+
+	initialRole, confidence := o.router.RouteWithConfidence(taskDescription)
+
+	plan := types.WorkflowPlan{
+		TaskDescription: taskDescription,
+		InitialRole:     initialRole,
+		Confidence:      confidence,
+	}
+
+	role := &initialRole
+	for hops := 0; role != nil && hops < 10; hops++ {
+		step := types.WorkflowPlanStep{Role: *role}
+		if o.store != nil {
+			if avgMS, ok := o.store.AverageRoleDurationMS(string(*role)); ok {
+				step.EstimatedMS = avgMS
+				step.HasHistory = true
+			}
+			if avgTokens, ok := o.store.AverageRoleTokens(string(*role)); ok {
+				step.EstimatedTokens = int(avgTokens)
+			}
+		}
+		plan.Steps = append(plan.Steps, step)
+		plan.EstimatedTotalTokens += step.EstimatedTokens
+		plan.EstimatedDurationMS += step.EstimatedMS
+
+		role = o.defaultNextRole(*role)
+	}
+	plan.EstimatedCostUSD = estimateCostUSD(plan.EstimatedTotalTokens)
+
+	return plan, nil
+}
+
 // GetTask retrieves a task by ID.
 func (o *Orchestrator) GetTask(id string) (*types.Task, error) {
 	return o.store.GetTask(id)
@@ -213,11 +399,93 @@ func (o *Orchestrator) GetHandoffs(taskID string) ([]types.Handoff, error) {
 	return o.store.LoadHandoffs(taskID)
 }
 
+// ListGeneratedFiles returns the paths of every file a task produced,
+// relative to its output directory.
+func (o *Orchestrator) ListGeneratedFiles(taskID string) ([]string, error) {
+	return o.store.ListGeneratedFiles(taskID)
+}
+
+// ReadGeneratedFile reads one file from a task's output directory.
+func (o *Orchestrator) ReadGeneratedFile(taskID, rel string) ([]byte, error) {
+	return o.store.ReadGeneratedFile(taskID, rel)
+}
+
+// Undo reverses a task's recorded workspace writes: files that didn't exist
+// before the run are removed, files that did are restored to their
+// pre-run content. It's best-effort — a failed revert is logged and the
+// rest of the ledger is still processed. With dryRun set, no files are
+// touched and the returned changes describe what would happen. On success
+// (dryRun false), the task's ledger is cleared so a second undo is a no-op.
+func (o *Orchestrator) Undo(taskID string, dryRun bool) ([]types.FileChange, error) {
+	entries, err := o.store.LoadWriteLedger(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("load write ledger: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	changes := make([]types.FileChange, 0, len(entries))
+	for _, entry := range entries {
+		action := "removed"
+		if entry.Existed {
+			action = "restored"
+		}
+		if dryRun {
+			changes = append(changes, types.FileChange{Path: entry.Path, Action: "would be " + action})
+			continue
+		}
+
+		fullPath := filepath.Join(o.workspaceRoot, entry.Path)
+		if entry.Existed {
+			if err := os.WriteFile(fullPath, []byte(entry.OldContent), 0644); err != nil {
+				logging.Error("failed to restore file on undo", err, "path", entry.Path)
+				continue
+			}
+		} else {
+			if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+				logging.Error("failed to remove file on undo", err, "path", entry.Path)
+				continue
+			}
+		}
+		changes = append(changes, types.FileChange{Path: entry.Path, Action: action})
+	}
+
+	if !dryRun {
+		if err := o.store.ClearWriteLedger(taskID); err != nil {
+			logging.Error("failed to clear write ledger after undo", err, "task_id", taskID)
+		}
+	}
+
+	return changes, nil
+}
+
 // Hooks returns the hook controller for external registration.
 func (o *Orchestrator) Hooks() *HookController {
 	return o.hooks
 }
 
+// RoleProfiles returns the model profile name configured for each role,
+// keyed by role string, for consumers (like the Prometheus exporter) that
+// need to label per-role metrics by the model they were actually run
+// against.
+func (o *Orchestrator) RoleProfiles() map[string]string {
+	out := make(map[string]string, len(o.roleProfiles))
+	for role, profile := range o.roleProfiles {
+		out[string(role)] = profile
+	}
+	return out
+}
+
+// Events returns the orchestrator's fan-out event broker. Independent
+// consumers (a file recorder, a metrics exporter, an HTTP dashboard) call
+// Subscribe on it to receive every event this run emits, each with its own
+// buffer and backpressure policy, so a slow consumer here can't cause the
+// TUI/GUI reading from Run's stream to miss anything.
+func (o *Orchestrator) Events() *stream.Broker {
+	return o.broker
+}
+
 // startControlListener starts a goroutine that forwards stream controls to hooks.
 func (o *Orchestrator) startControlListener(ctx context.Context) {
 	if o.stream == nil || o.hooks == nil {
@@ -0,0 +1,150 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"cooperations/internal/tui/stream"
+)
+
+// eventRecorderBuffer is the broker subscription buffer for an
+// EventRecorder. It uses PolicyBlock (see stream.Broker) so a slow disk
+// never loses an event, only backpressures the publisher briefly.
+const eventRecorderBuffer = 64
+
+// EventRecord is one line of a recorded event log: a broker event plus the
+// time it was received, so a crashed run can be replayed with its original
+// pacing.
+type EventRecord struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Kind      string      `json:"kind"`
+	Payload   interface{} `json:"payload"`
+}
+
+// EventRecorder subscribes to an orchestrator's event broker and appends
+// every event to a JSONL file as it arrives, independent of the TUI
+// session manager. It flushes after every write, so a crash before the run
+// finishes still leaves a durable, tail-able log usable as a Resume
+// recovery source.
+type EventRecorder struct {
+	file        *os.File
+	unsubscribe func()
+	done        chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// StartEventRecorder subscribes to broker and streams every event it
+// publishes to path (created if necessary, along with its parent
+// directory), one JSON object per line. Call Close when the run finishes
+// to stop the subscription and close the file.
+func StartEventRecorder(broker *stream.Broker, path string) (*EventRecorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create events directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open events file: %w", err)
+	}
+
+	events, unsubscribe := broker.Subscribe(eventRecorderBuffer, stream.PolicyBlock)
+	r := &EventRecorder{
+		file:        f,
+		unsubscribe: unsubscribe,
+		done:        make(chan struct{}),
+	}
+
+	go r.run(events)
+
+	return r, nil
+}
+
+// run drains events until the broker closes its channel (on unsubscribe),
+// appending each as a line of JSON and flushing immediately.
+func (r *EventRecorder) run(events <-chan stream.Event) {
+	defer close(r.done)
+	for evt := range events {
+		record := EventRecord{Timestamp: time.Now(), Kind: evt.Kind, Payload: evt.Payload}
+		data, err := json.Marshal(record)
+		if err != nil {
+			r.setErr(fmt.Errorf("marshal event record: %w", err))
+			continue
+		}
+		if _, err := r.file.Write(append(data, '\n')); err != nil {
+			r.setErr(fmt.Errorf("write event record: %w", err))
+			continue
+		}
+		if err := r.file.Sync(); err != nil {
+			r.setErr(fmt.Errorf("sync events file: %w", err))
+		}
+	}
+}
+
+func (r *EventRecorder) setErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// Err returns the first error encountered while writing, if any.
+func (r *EventRecorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close unsubscribes from the broker, waits for the drain goroutine to see
+// its channel close, and closes the underlying file.
+func (r *EventRecorder) Close() error {
+	r.unsubscribe()
+	<-r.done
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("close events file: %w", err)
+	}
+	return r.Err()
+}
+
+// eventsFilePath returns the path a task's recorded event log is written
+// to: .cooperations/events/<taskID>.jsonl alongside storeDir's other
+// per-task state.
+func (o *Orchestrator) eventsFilePath(taskID string) string {
+	return filepath.Join(o.storeDir, "events", taskID+".jsonl")
+}
+
+// startEventRecording starts recording every broker event for the current
+// run to disk when RecordEvents is enabled, logging (rather than failing
+// the run) if the recorder can't be started, since a missing durable log
+// shouldn't stop the workflow itself. ctx is unused today but accepted so
+// a future version can tie recording lifetime to run cancellation instead
+// of only to Close.
+func (o *Orchestrator) startEventRecording(ctx context.Context, taskID string) {
+	if !o.config.RecordEvents {
+		return
+	}
+	rec, err := StartEventRecorder(o.broker, o.eventsFilePath(taskID))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to start event recorder: %v\n", err)
+		return
+	}
+	o.recorder = rec
+}
+
+// stopEventRecording closes any recorder started for the current run.
+func (o *Orchestrator) stopEventRecording() {
+	if o.recorder == nil {
+		return
+	}
+	if err := o.recorder.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: event recorder: %v\n", err)
+	}
+	o.recorder = nil
+}
@@ -2,6 +2,9 @@
 package orchestrator
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
@@ -10,11 +13,97 @@ import (
 )
 
 // Router determines which role should handle a task.
-type Router struct{}
+type Router struct {
+	weights map[types.Role][]WeightedKeyword
+}
+
+// WeightedKeyword is one keyword/weight pair used to score a task
+// description against a role. Weight lets teams push a keyword harder
+// (or softer) than the built-in defaults without touching code.
+type WeightedKeyword struct {
+	Keyword string  `yaml:"keyword" json:"keyword"`
+	Weight  float64 `yaml:"weight" json:"weight"`
+}
+
+// RouterConfig customizes the Router's keyword weights per role. It is
+// loadable from the app config (AppConfig.Router) or, standalone, from a
+// JSON file via LoadRouterConfig; an empty RouterConfig (the zero value)
+// makes the Router fall back to its built-in regex patterns.
+type RouterConfig struct {
+	Weights map[string][]WeightedKeyword `yaml:"weights" json:"weights"`
+}
+
+// Validate checks that a RouterConfig's keywords and weights are usable.
+// A blank keyword or a non-positive weight would make that entry inert
+// or actively harmful, so both are rejected up front rather than
+// silently mis-routing tasks later.
+func (c RouterConfig) Validate() error {
+	for role, keywords := range c.Weights {
+		if _, ok := roleNames[role]; !ok {
+			return fmt.Errorf("router config: unknown role %q", role)
+		}
+		for _, kw := range keywords {
+			if strings.TrimSpace(kw.Keyword) == "" {
+				return fmt.Errorf("router config: role %q has a blank keyword", role)
+			}
+			if kw.Weight <= 0 {
+				return fmt.Errorf("router config: keyword %q for role %q has non-positive weight %v", kw.Keyword, role, kw.Weight)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadRouterConfig reads a RouterConfig from the JSON file at path and
+// validates it, so a bad path or a config typo is reported up front rather
+// than silently falling back to the built-in patterns. Used by `coop route
+// --router-config` and available for AppConfig loading to populate
+// AppConfig.Router the same way.
+func LoadRouterConfig(path string) (RouterConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RouterConfig{}, fmt.Errorf("read router config: %w", err)
+	}
+	var cfg RouterConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return RouterConfig{}, fmt.Errorf("parse router config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return RouterConfig{}, err
+	}
+	return cfg, nil
+}
 
-// NewRouter creates a new router.
+// roleNames lists the role names a RouterConfig may key its weights by.
+var roleNames = map[string]types.Role{
+	"architect":   types.RoleArchitect,
+	"implementer": types.RoleImplementer,
+	"reviewer":    types.RoleReviewer,
+	"navigator":   types.RoleNavigator,
+}
+
+// routePrecedence is the tie-breaking order used whenever two roles score
+// equally, matching the built-in pattern switch's original ordering.
+var routePrecedence = []types.Role{types.RoleArchitect, types.RoleReviewer, types.RoleNavigator, types.RoleImplementer}
+
+// NewRouter creates a new router using the built-in keyword patterns.
 func NewRouter() *Router {
-	return &Router{}
+	return NewRouterFromConfig(RouterConfig{})
+}
+
+// NewRouterFromConfig creates a router using cfg's keyword weights. Roles
+// left out of cfg.Weights (or an entirely empty cfg) keep using the
+// built-in regex patterns for that role.
+func NewRouterFromConfig(cfg RouterConfig) *Router {
+	weights := make(map[types.Role][]WeightedKeyword, len(cfg.Weights))
+	for name, keywords := range cfg.Weights {
+		role, ok := roleNames[name]
+		if !ok {
+			continue
+		}
+		weights[role] = keywords
+	}
+	return &Router{weights: weights}
 }
 
 // Routing patterns
@@ -25,63 +114,77 @@ var (
 	implementerPattern = regexp.MustCompile(`(?i)\b(implement|code|build|create|write|add|fix|bug)\b`)
 )
 
-// Route determines the initial role for a task based on keywords.
-func (r *Router) Route(task string) types.Role {
-	lower := strings.ToLower(task)
+// builtinPatterns maps each role to its default regex, used whenever a
+// role has no configured weighted keywords.
+var builtinPatterns = map[types.Role]*regexp.Regexp{
+	types.RoleArchitect:   architectPattern,
+	types.RoleReviewer:    reviewerPattern,
+	types.RoleNavigator:   navigatorPattern,
+	types.RoleImplementer: implementerPattern,
+}
 
-	var role types.Role
-	var reason string
-
-	switch {
-	case architectPattern.MatchString(lower):
-		role = types.RoleArchitect
-		reason = "matched design/architecture keywords"
-	case reviewerPattern.MatchString(lower):
-		role = types.RoleReviewer
-		reason = "matched review/verification keywords"
-	case navigatorPattern.MatchString(lower):
-		role = types.RoleNavigator
-		reason = "matched help/context keywords"
-	case implementerPattern.MatchString(lower):
-		role = types.RoleImplementer
-		reason = "matched implementation keywords"
-	default:
-		role = types.RoleImplementer
-		reason = "default routing (no specific keywords matched)"
+// score returns role's match score against lower (an already-lowercased
+// task description): the sum of configured keyword weights if role has
+// any, or its built-in pattern's match count otherwise.
+func (r *Router) score(role types.Role, lower string) float64 {
+	if keywords, ok := r.weights[role]; ok {
+		var total float64
+		for _, kw := range keywords {
+			if strings.Contains(lower, strings.ToLower(kw.Keyword)) {
+				total += kw.Weight
+			}
+		}
+		return total
+	}
+	return float64(len(builtinPatterns[role].FindAllString(lower, -1)))
+}
+
+// RouteBreakdown scores task against every role, for debugging and for
+// the `coop route` command. It uses the same scoring `Route` and
+// `RouteWithConfidence` use internally.
+func (r *Router) RouteBreakdown(task string) map[types.Role]float64 {
+	lower := strings.ToLower(task)
+	breakdown := make(map[types.Role]float64, len(routePrecedence))
+	for _, role := range routePrecedence {
+		breakdown[role] = r.score(role, lower)
 	}
+	return breakdown
+}
 
+// Route determines the initial role for a task based on keywords.
+func (r *Router) Route(task string) types.Role {
+	role, reason := r.route(task)
 	logging.Route(task, string(role), reason)
 	return role
 }
 
+func (r *Router) route(task string) (types.Role, string) {
+	breakdown := r.RouteBreakdown(task)
+	for _, role := range routePrecedence {
+		if breakdown[role] > 0 {
+			return role, fmt.Sprintf("matched %s keywords", role)
+		}
+	}
+	return types.RoleImplementer, "default routing (no specific keywords matched)"
+}
+
 // RouteWithConfidence returns the role and a confidence score (0-1).
 func (r *Router) RouteWithConfidence(task string) (types.Role, float64) {
-	lower := strings.ToLower(task)
+	breakdown := r.RouteBreakdown(task)
 
-	// Count keyword matches for confidence
-	archMatches := len(architectPattern.FindAllString(lower, -1))
-	reviewMatches := len(reviewerPattern.FindAllString(lower, -1))
-	navMatches := len(navigatorPattern.FindAllString(lower, -1))
-	implMatches := len(implementerPattern.FindAllString(lower, -1))
-
-	total := archMatches + reviewMatches + navMatches + implMatches
+	var total float64
+	for _, score := range breakdown {
+		total += score
+	}
 	if total == 0 {
 		return types.RoleImplementer, 0.3 // Low confidence default
 	}
 
-	// Find the highest match count
-	maxMatches := max(archMatches, reviewMatches, navMatches, implMatches)
-	confidence := float64(maxMatches) / float64(total)
-
-	// Determine role based on highest matches
-	switch maxMatches {
-	case archMatches:
-		return types.RoleArchitect, confidence
-	case reviewMatches:
-		return types.RoleReviewer, confidence
-	case navMatches:
-		return types.RoleNavigator, confidence
-	default:
-		return types.RoleImplementer, confidence
+	best := routePrecedence[0]
+	for _, role := range routePrecedence {
+		if breakdown[role] > breakdown[best] {
+			best = role
+		}
 	}
+	return best, breakdown[best] / total
 }
@@ -1,6 +1,9 @@
 package orchestrator
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"cooperations/internal/types"
@@ -59,10 +62,10 @@ func TestRouter_RouteWithConfidence(t *testing.T) {
 	router := NewRouter()
 
 	tests := []struct {
-		name           string
-		task           string
-		expectedRole   types.Role
-		minConfidence  float64
+		name          string
+		task          string
+		expectedRole  types.Role
+		minConfidence float64
 	}{
 		{"strong architect signal", "design and architect the system", types.RoleArchitect, 0.5},
 		{"weak signal", "do something", types.RoleImplementer, 0.0},
@@ -81,3 +84,126 @@ func TestRouter_RouteWithConfidence(t *testing.T) {
 		})
 	}
 }
+
+func TestRouterConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RouterConfig
+		wantErr bool
+	}{
+		{"empty config", RouterConfig{}, false},
+		{
+			"valid config",
+			RouterConfig{Weights: map[string][]WeightedKeyword{
+				"architect": {{Keyword: "design", Weight: 2}},
+			}},
+			false,
+		},
+		{
+			"unknown role",
+			RouterConfig{Weights: map[string][]WeightedKeyword{
+				"wizard": {{Keyword: "design", Weight: 2}},
+			}},
+			true,
+		},
+		{
+			"blank keyword",
+			RouterConfig{Weights: map[string][]WeightedKeyword{
+				"architect": {{Keyword: "  ", Weight: 2}},
+			}},
+			true,
+		},
+		{
+			"non-positive weight",
+			RouterConfig{Weights: map[string][]WeightedKeyword{
+				"architect": {{Keyword: "design", Weight: 0}},
+			}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRouterFromConfig(t *testing.T) {
+	cfg := RouterConfig{Weights: map[string][]WeightedKeyword{
+		"architect": {{Keyword: "blueprint", Weight: 5}},
+	}}
+	router := NewRouterFromConfig(cfg)
+
+	// A configured role uses its weighted keywords instead of the built-in
+	// pattern, so a word absent from the built-in architectPattern still
+	// routes there.
+	role := router.Route("draw up a blueprint for the new service")
+	if role != types.RoleArchitect {
+		t.Errorf("Route() = %v, want %v", role, types.RoleArchitect)
+	}
+
+	// A role left out of cfg.Weights keeps using its built-in pattern.
+	role = router.Route("implement the new endpoint")
+	if role != types.RoleImplementer {
+		t.Errorf("Route() = %v, want %v", role, types.RoleImplementer)
+	}
+}
+
+func TestRouter_RouteBreakdown_Weighted(t *testing.T) {
+	router := NewRouterFromConfig(RouterConfig{Weights: map[string][]WeightedKeyword{
+		"architect": {
+			{Keyword: "blueprint", Weight: 3},
+			{Keyword: "schema", Weight: 1},
+		},
+	}})
+
+	breakdown := router.RouteBreakdown("blueprint the schema")
+	if got, want := breakdown[types.RoleArchitect], 4.0; got != want {
+		t.Errorf("RouteBreakdown()[architect] = %v, want %v", got, want)
+	}
+}
+
+func TestLoadRouterConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "router.json")
+	cfg := RouterConfig{Weights: map[string][]WeightedKeyword{
+		"reviewer": {{Keyword: "lint", Weight: 1.5}},
+	}}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	loaded, err := LoadRouterConfig(path)
+	if err != nil {
+		t.Fatalf("LoadRouterConfig() error = %v", err)
+	}
+	if got, want := loaded.Weights["reviewer"][0].Keyword, "lint"; got != want {
+		t.Errorf("Weights[reviewer][0].Keyword = %q, want %q", got, want)
+	}
+}
+
+func TestLoadRouterConfig_InvalidRole(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "router.json")
+	if err := os.WriteFile(path, []byte(`{"weights":{"wizard":[{"keyword":"x","weight":1}]}}`), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if _, err := LoadRouterConfig(path); err == nil {
+		t.Error("LoadRouterConfig() error = nil, want error for unknown role")
+	}
+}
+
+func TestLoadRouterConfig_MissingFile(t *testing.T) {
+	if _, err := LoadRouterConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadRouterConfig() error = nil, want error for missing file")
+	}
+}
@@ -1,7 +1,9 @@
 package orchestrator
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"cooperations/internal/agents"
 	ctx "cooperations/internal/context"
 	"cooperations/internal/logging"
 	"cooperations/internal/tui/stream"
@@ -19,6 +22,124 @@ import (
 type WorkflowConfig struct {
 	MaxReviewCycles int               `yaml:"max_review_cycles"`
 	RoleTaskTypes   map[string]string `yaml:"role_task_types"`
+
+	// RequireApprovalBeforeWrite gates each generated file behind a human
+	// DecisionRequest before it's written to the workspace. It only takes
+	// effect when a stream is attached; headless runs without one always
+	// write straight through.
+	RequireApprovalBeforeWrite bool `yaml:"require_approval_before_write"`
+
+	// NoApply previews a workflow run: CodeUpdate/FileDiff/FileTreeUpdate
+	// events are still emitted so the TUI/GUI can show what would change,
+	// but writeWorkspaceFile and SaveGeneratedCode are both skipped.
+	NoApply bool `yaml:"no_apply"`
+
+	// CleanOnKill undoes every workspace write made by a run that's aborted
+	// via SignalKill: files created by the run are removed, files it
+	// modified are restored to their prior content. When false (default),
+	// an aborted run leaves its partial writes in place.
+	CleanOnKill bool `yaml:"clean_on_kill"`
+
+	// RecordEvents streams every event this run publishes to its broker
+	// into .cooperations/events/<taskID>.jsonl, independent of whether a
+	// TUI session is attached. Off by default since it adds a disk write
+	// per event.
+	RecordEvents bool `yaml:"record_events"`
+
+	// MaxCostUSD, when non-zero, is checked against the running estimated
+	// cost after every agent step. Crossing it pauses the workflow behind a
+	// "Budget exceeded, continue?" decision (or aborts outright if
+	// HardBudget is set) so multi-cycle reviews can't burn tokens unbounded.
+	MaxCostUSD float64 `yaml:"max_cost_usd"`
+
+	// HardBudget aborts the workflow as soon as MaxCostUSD is crossed,
+	// instead of pausing for a human decision. Has no effect when
+	// MaxCostUSD is 0.
+	HardBudget bool `yaml:"hard_budget"`
+
+	// HardMaxCostUSD and HardMaxTokens are non-interactive safety valves for
+	// unattended runs: crossing either aborts the workflow immediately, with
+	// no pause and no decision prompt, unlike MaxCostUSD above. Either is
+	// disabled when 0.
+	HardMaxCostUSD float64 `yaml:"hard_max_cost_usd"`
+	HardMaxTokens  int     `yaml:"hard_max_tokens"`
+
+	// ConvergenceThreshold, when non-zero, lets a review loop complete
+	// early once consecutive implementer outputs stop changing
+	// meaningfully: a line-similarity score at or above this threshold
+	// (0-1) is treated as converged. 0 disables the check, running the
+	// full MaxReviewCycles budget as before.
+	ConvergenceThreshold float64 `yaml:"convergence_threshold"`
+
+	// RVRPolicies maps an RVR task type (as configured in RoleTaskTypes) to
+	// the policy applied when that role's response confidence falls below
+	// the policy's threshold. A task type with no entry is left alone.
+	RVRPolicies map[string]RVRPolicy `yaml:"rvr_policies"`
+
+	// AnnotateUncertainty appends a "Caveats" section to saved design docs
+	// and review feedback, and a leading TODO(uncertain) comment to saved
+	// code, whenever the producing response reported RVR uncertainty. Off
+	// by default so outputs stay exactly as the agent wrote them.
+	AnnotateUncertainty bool `yaml:"annotate_uncertainty"`
+
+	// GitCommit stages the files recorded in the write ledger and creates a
+	// commit for them once the workflow completes successfully. It's a
+	// no-op (with a warning) when the workspace isn't a git repository.
+	GitCommit bool `yaml:"git_commit"`
+
+	// GitBranch, when non-empty, is checked out (creating it if needed)
+	// before the run starts, so GitCommit's commit — and any manual
+	// changes made during the run — land on a dedicated branch instead of
+	// whatever was checked out already. Has no effect unless GitCommit is
+	// also set.
+	GitBranch string `yaml:"git_branch"`
+
+	// ContextFiles lists paths or glob patterns, resolved against the
+	// workspace root, whose contents are read once at workflow start and
+	// prepended to the initial handoff as reference material (e.g.
+	// README.md, a style guide, key interface files). Useful for tasks
+	// that depend on conventions an agent can't infer from the task
+	// description alone.
+	ContextFiles []string `yaml:"context_files"`
+
+	// ModelOverride, when non-empty, forces every role to use this model
+	// profile for this run only, ignoring the profile configured per role.
+	// Handy for A/B comparing models or falling back when one provider is
+	// down, without editing config files. RoleModelOverrides is applied on
+	// top of it, so a role can still be pinned back to a different profile
+	// than the blanket override.
+	ModelOverride string `yaml:"-"`
+
+	// RoleModelOverrides maps a role name (e.g. "architect") to a model
+	// profile, overriding just that role's configured profile for this
+	// run. Takes precedence over ModelOverride for any role it names.
+	RoleModelOverrides map[string]string `yaml:"-"`
+}
+
+// contextFilesByteBudget caps the total size of ContextFiles content
+// injected into a handoff, so a broad glob pattern can't blow out every
+// prompt's size.
+const contextFilesByteBudget = 32 * 1024
+
+// RVRAction is the response to a low-confidence RVR result.
+type RVRAction string
+
+const (
+	// RVRActionIgnore leaves the response as-is but tags its artifacts with
+	// a caveat so downstream readers know to scrutinize it.
+	RVRActionIgnore RVRAction = "ignore"
+	// RVRActionRetry re-runs the agent once for a fresh attempt.
+	RVRActionRetry RVRAction = "retry"
+	// RVRActionAsk requests a human decision via the attached stream,
+	// falling back to RVRActionIgnore when no stream is attached.
+	RVRActionAsk RVRAction = "ask"
+)
+
+// RVRPolicy configures how a workflow reacts when an RVR task type's
+// response confidence falls below Threshold.
+type RVRPolicy struct {
+	Threshold float64   `yaml:"threshold"`
+	Action    RVRAction `yaml:"action"`
 }
 
 // DefaultWorkflowConfig returns the default workflow configuration.
@@ -28,55 +149,128 @@ func DefaultWorkflowConfig() WorkflowConfig {
 	}
 }
 
-// emitProgress sends a progress update to the stream if available.
+// progressResetStages are lifecycle stages allowed to move percent
+// backward (or forward to 100), since they mark the start/end of a run or
+// an explicit pause/resume rather than another step in it.
+var progressResetStages = map[string]bool{
+	"Starting": true,
+	"Aborted":  true,
+	"Paused":   true,
+	"Resumed":  true,
+	"Complete": true,
+}
+
+// emitProgress sends a progress update to the stream if available. percent
+// is clamped to [0,100] and, outside progressResetStages, never allowed to
+// move backward within a run, so re-firing stages during review cycles
+// don't make the progress bar jump around.
 func (o *Orchestrator) emitProgress(stage string, percent float64, message string) {
-	if o.stream == nil {
-		return
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
 	}
-	select {
-	case o.stream.Progress <- stream.ProgressUpdate{
+
+	if progressResetStages[stage] {
+		o.lastProgressPercent = percent
+	} else if percent < o.lastProgressPercent {
+		percent = o.lastProgressPercent
+	} else {
+		o.lastProgressPercent = percent
+	}
+
+	update := stream.ProgressUpdate{
 		Stage:   stage,
 		Percent: percent,
 		Message: message,
-	}:
-	default:
-		// Channel full, skip
 	}
-}
+	o.broker.Publish(context.Background(), "progress", update)
 
-// emitHandoff sends a handoff event to the stream if available.
-func (o *Orchestrator) emitHandoff(from, to, reason string) {
 	if o.stream == nil {
 		return
 	}
 	select {
-	case o.stream.Handoffs <- stream.HandoffEvent{
+	case o.stream.Progress <- update:
+	default:
+		// Channel full, skip
+	}
+}
+
+// progressPhaseWeight maps a role to its target percent within the default
+// architect -> implementer -> reviewer progression. Using fixed weights
+// instead of a raw step count keeps review loops (which revisit reviewer
+// and implementer several times) from pushing the bar past 100%.
+var progressPhaseWeight = map[types.Role]float64{
+	types.RoleNavigator:   15,
+	types.RoleArchitect:   30,
+	types.RoleImplementer: 65,
+	types.RoleReviewer:    90,
+}
+
+// stepProgressPercent returns the target percent for role's phase, or a
+// step-count based fallback (capped below 100, which is reserved for
+// completion) for roles with no configured weight.
+func stepProgressPercent(role types.Role, stepCount int) float64 {
+	if weight, ok := progressPhaseWeight[role]; ok {
+		return weight
+	}
+	percent := float64(stepCount * 20)
+	if percent > 95 {
+		percent = 95
+	}
+	return percent
+}
+
+// emitHandoff sends a handoff event to the stream if available. Handoffs
+// are high-value: this blocks (respecting ctx cancellation) rather than
+// dropping the event under load, so handoff history stays complete for
+// diagrams and audits.
+func (o *Orchestrator) emitHandoff(ctx context.Context, from, to string, reason types.HandoffReason) {
+	event := stream.HandoffEvent{
 		From:      from,
 		To:        to,
 		Reason:    reason,
 		Timestamp: time.Now(),
-	}:
-	default:
 	}
-}
+	o.broker.Publish(ctx, "handoff", event)
 
-// emitTokenChunk sends a streamed text chunk if available.
-func (o *Orchestrator) emitTokenChunk(role, text string, isFinal bool) {
 	if o.stream == nil {
 		return
 	}
+	o.stream.SendHandoffCtx(ctx, event)
+}
+
+// handoffReasonForTransition classifies a transition into the next role:
+// routing into review gets its own kind so handoff history can be filtered
+// to review requests specifically, everything else is a generic role
+// transition.
+func handoffReasonForTransition(nextRole types.Role) types.HandoffReason {
+	kind := types.HandoffReasonRoleTransition
+	if nextRole == types.RoleReviewer {
+		kind = types.HandoffReasonReviewRequested
+	}
+	return types.HandoffReason{Kind: kind, Detail: fmt.Sprintf("Transitioning to %s", roleToLabel(nextRole))}
+}
+
+// emitTokenChunk sends a streamed text chunk if available. Token chunks are
+// high-value: this blocks (respecting ctx cancellation) rather than
+// dropping output under fast streaming.
+func (o *Orchestrator) emitTokenChunk(ctx context.Context, role, text string, isFinal bool) {
 	if text == "" {
 		return
 	}
-	select {
-	case o.stream.Tokens <- stream.TokenChunk{
+	chunk := stream.TokenChunk{
 		AgentRole: role,
 		Token:     text,
 		Timestamp: time.Now(),
 		IsFinal:   isFinal,
-	}:
-	default:
 	}
+	o.broker.Publish(ctx, "token", chunk)
+
+	if o.stream == nil {
+		return
+	}
+	o.stream.SendTokenCtx(ctx, chunk)
 }
 
 // emitThinking sends a thinking update to the stream if available.
@@ -94,30 +288,86 @@ func (o *Orchestrator) emitThinking(role, stage string) {
 	}
 }
 
-// emitMetrics sends a metrics snapshot to the stream if available.
-func (o *Orchestrator) emitMetrics(snapshot stream.MetricsSnapshot) {
+// emitRVR sends one RVR processing event (phase "layer1", "layer2", or
+// "synthesis") to the stream if available.
+func (o *Orchestrator) emitRVR(phase string, chunkID int, confidence, threshold float64, uncertainty string, retrying bool) {
 	if o.stream == nil {
 		return
 	}
-	select {
-	case o.stream.Metrics <- snapshot:
-	default:
-	}
+	o.stream.SendRVR(stream.RVREvent{
+		Phase:       phase,
+		ChunkID:     chunkID,
+		Confidence:  confidence,
+		Threshold:   threshold,
+		Uncertainty: uncertainty,
+		Retrying:    retrying,
+	})
 }
 
-// emitCode sends a code update to the stream if available.
-func (o *Orchestrator) emitCode(path, content, language string) {
+// emitRVRResult sends the final RVR breakdown for one agent response to the
+// stream if available. Responses are currently verified as a single chunk,
+// so the breakdown always carries one item.
+func (o *Orchestrator) emitRVRResult(taskType string, response types.AgentResponse, verified bool) {
 	if o.stream == nil {
 		return
 	}
-	select {
-	case o.stream.Code <- stream.CodeUpdate{
+	var caveats []string
+	if caveat, ok := response.Artifacts["rvr_caveat"].(string); ok {
+		caveats = append(caveats, caveat)
+	}
+	o.stream.SendRVRResult(stream.RVRResultEvent{
+		TaskType: taskType,
+		Overall:  response.Confidence,
+		Breakdown: []stream.RVRBreakdownItem{{
+			ChunkID:     0,
+			Confidence:  response.Confidence,
+			Uncertainty: response.Uncertainty,
+			Verified:    verified,
+		}},
+		Caveats: caveats,
+	})
+}
+
+// emitMetrics sends a metrics snapshot to the stream if available.
+// emitMetrics publishes a metrics snapshot, and reports whether it just
+// crossed one of the CLI's non-interactive hard limits (--max-tokens or
+// --max-cost). Unlike MaxCostUSD/HardBudget above, these never pause for a
+// decision: a non-nil error here means the caller should abort immediately.
+func (o *Orchestrator) emitMetrics(snapshot stream.MetricsSnapshot) error {
+	o.broker.Publish(context.Background(), "metrics", snapshot)
+
+	if o.stream != nil {
+		select {
+		case o.stream.Metrics <- snapshot:
+		default:
+		}
+	}
+
+	switch {
+	case o.config.HardMaxTokens > 0 && snapshot.TotalTokens >= o.config.HardMaxTokens:
+		return fmt.Errorf("workflow aborted: total tokens %d crossed hard limit %d", snapshot.TotalTokens, o.config.HardMaxTokens)
+	case o.config.HardMaxCostUSD > 0 && snapshot.EstimatedCostUSD >= o.config.HardMaxCostUSD:
+		return fmt.Errorf("workflow aborted: estimated cost $%.4f crossed hard limit $%.4f", snapshot.EstimatedCostUSD, o.config.HardMaxCostUSD)
+	default:
+		return nil
+	}
+}
+
+// emitCode sends a code update to the stream if available. Code updates are
+// high-value: this blocks (respecting ctx cancellation) rather than
+// dropping generated code under load.
+func (o *Orchestrator) emitCode(ctx context.Context, path, content, language string) {
+	update := stream.CodeUpdate{
 		Path:     path,
 		Content:  content,
 		Language: language,
-	}:
-	default:
 	}
+	o.broker.Publish(ctx, "code", update)
+
+	if o.stream == nil {
+		return
+	}
+	o.stream.SendCodeCtx(ctx, update)
 }
 
 // emitFileTree sends a file tree update to the stream if available.
@@ -163,6 +413,24 @@ func (o *Orchestrator) emitError(err error) {
 	}
 }
 
+// emitToast sends a toast notification to the stream if available.
+func (o *Orchestrator) emitToast(level, title, message string) {
+	notification := stream.ToastNotification{
+		Level:   level,
+		Title:   title,
+		Message: message,
+	}
+	o.broker.Publish(context.Background(), "toast", notification)
+
+	if o.stream == nil {
+		return
+	}
+	select {
+	case o.stream.Toast <- notification:
+	default:
+	}
+}
+
 // emitDone signals workflow completion to the stream if available.
 func (o *Orchestrator) emitDone() {
 	if o.stream == nil {
@@ -174,8 +442,11 @@ func (o *Orchestrator) emitDone() {
 	}
 }
 
-// executeWorkflow runs the main workflow loop.
-func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initialRole types.Role) (types.WorkflowResult, error) {
+// executeWorkflow runs the main workflow loop. rolePlan, when non-empty,
+// overrides defaultNextRole with an explicit human-edited role sequence
+// (see Orchestrator.RunWithPlan); pass nil for the normal, agent-driven
+// progression.
+func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initialRole types.Role, rolePlan []types.Role) (types.WorkflowResult, error) {
 	state := types.WorkflowState{
 		Task:         task,
 		Handoffs:     []types.Handoff{},
@@ -185,10 +456,15 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 
 	// Create initial handoff context
 	handoffCtx := types.HContext{
-		TaskDescription: task.Description,
-		Requirements:    []string{},
-		Constraints:     []string{},
-		FilesInScope:    []string{},
+		OriginalTaskDescription: task.Description,
+		TaskDescription:         task.Description,
+		Requirements:            []string{},
+		Constraints:             []string{},
+		FilesInScope:            []string{},
+	}
+	handoffCtx.FilesInScope = appendUniqueStrings(handoffCtx.FilesInScope, extractTargetPaths(task.Description)...)
+	if len(o.config.ContextFiles) > 0 {
+		handoffCtx.ReferenceContext = loadContextFiles(o.workspaceRoot, o.config.ContextFiles, contextFilesByteBudget)
 	}
 	artifacts := types.HArtifacts{}
 
@@ -198,10 +474,25 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 	promptTokens := 0
 	completionTokens := 0
 	stepCount := 0
+	roleStats := make(map[types.Role]types.RoleStats)
+	budgetPrompted := false
+
+	// abort and complete close over the running totals so every exit point
+	// in this function reports the same accurate summary, regardless of
+	// how far the workflow got before finishing.
+	abort := func(err error) (types.WorkflowResult, error) {
+		return o.abortWorkflow(task, state.Handoffs, artifacts, classifyAbortReason(err), err, totalTokens, promptTokens, completionTokens, time.Since(workflowStart), roleStats)
+	}
+	abortReason := func(kind types.AbortReasonKind, err error) (types.WorkflowResult, error) {
+		return o.abortWorkflow(task, state.Handoffs, artifacts, kind, err, totalTokens, promptTokens, completionTokens, time.Since(workflowStart), roleStats)
+	}
+	complete := func() (types.WorkflowResult, error) {
+		return o.completeWorkflow(task, state, artifacts, totalTokens, promptTokens, completionTokens, time.Since(workflowStart), roleStats)
+	}
 
 	// Emit initial progress
 	o.emitProgress("Starting", 0, fmt.Sprintf("Starting workflow for task: %s", task.ID))
-	o.emitHandoff("user", string(initialRole), "Initial routing")
+	o.emitHandoff(c, "user", string(initialRole), types.HandoffReason{Kind: types.HandoffReasonInitialRouting})
 
 	// Emit workflow start hook
 	startResult := o.hooks.Emit(c, HookEvent{
@@ -212,22 +503,21 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 	})
 	o.emitHookNotify(HookPhaseWorkflowStart, initialRole, task.ID)
 	if startResult.Kill {
-		return o.abortWorkflow(task, state.Handoffs, startResult.Error)
+		return abortReason(types.AbortReasonKilled, startResult.Error)
 	}
 
 	for {
 		// Check for context cancellation
 		select {
 		case <-c.Done():
-			return o.abortWorkflow(task, state.Handoffs, c.Err())
+			return abort(c.Err())
 		default:
 		}
 
 		// Get the agent for current role
 		agent, ok := o.agents[state.CurrentRole]
 		if !ok {
-			return o.abortWorkflow(task, state.Handoffs,
-				fmt.Errorf("no agent for role: %s", state.CurrentRole))
+			return abortReason(types.AbortReasonNoAgent, fmt.Errorf("no agent for role: %s", state.CurrentRole))
 		}
 
 		// Create handoff for this step
@@ -250,23 +540,23 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 		o.emitHookNotify(HookPhasePreAgent, state.CurrentRole, task.ID)
 
 		if preResult.Kill {
-			return o.abortWorkflow(task, state.Handoffs, preResult.Error)
+			return abortReason(types.AbortReasonKilled, preResult.Error)
 		}
 
 		if preResult.Skip {
 			// Skip this agent, advance to next
-			o.emitProgress("Skipped", float64(stepCount*20),
+			o.emitProgress("Skipped", stepProgressPercent(state.CurrentRole, stepCount),
 				fmt.Sprintf("Skipped %s", roleToLabel(state.CurrentRole)))
-			o.emitTokenChunk(string(state.CurrentRole),
+			o.emitTokenChunk(c, string(state.CurrentRole),
 				fmt.Sprintf("\n[SKIPPED: %s]\n", roleToLabel(state.CurrentRole)), true)
 
-			// Determine next role (use default progression)
-			nextRole := o.defaultNextRole(state.CurrentRole)
+			// Determine next role (use default progression, or rolePlan)
+			nextRole := o.nextRoleAfter(state.CurrentRole, rolePlan)
 			if nextRole == nil {
 				// Workflow complete
-				return o.completeWorkflow(task, state, artifacts)
+				return complete()
 			}
-			o.emitHandoff(string(state.CurrentRole), string(*nextRole), "Skipped to next agent")
+			o.emitHandoff(c, string(state.CurrentRole), string(*nextRole), types.HandoffReason{Kind: types.HandoffReasonSkipped})
 			state.CurrentRole = *nextRole
 			continue
 		}
@@ -282,17 +572,72 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 		// Emit progress before execution
 		roleLabel := roleToLabel(state.CurrentRole)
 		o.emitThinking(string(state.CurrentRole), "analyzing")
-		o.emitProgress(roleLabel, float64(stepCount*20), fmt.Sprintf("%s is working...", roleLabel))
+		progressMessage := fmt.Sprintf("%s is working...", roleLabel)
+		if etaMS, ok := o.estimateRemainingMS(state.CurrentRole); ok {
+			progressMessage = fmt.Sprintf("%s is working... (ETA %s)", roleLabel, time.Duration(etaMS)*time.Millisecond)
+		}
+		o.emitProgress(roleLabel, stepProgressPercent(state.CurrentRole, stepCount), progressMessage)
+
+		streamed := false
+		midAgentKilled := false
+		var response types.AgentResponse
+		var err error
+		if streamer, ok := agent.(agents.StreamingAgent); ok {
+			streamCtx, streamCancel := context.WithCancel(c)
+			defer streamCancel()
 
-		response, err := agent.Execute(c, *handoff)
+			separator := ""
+			if stepCount > 1 {
+				separator = "\n\n"
+			}
+			header := fmt.Sprintf("[%s]", strings.ToUpper(roleLabel))
+			wroteHeader := false
+			var partial strings.Builder
+			response, err = streamer.ExecuteStream(streamCtx, *handoff, func(chunk string) {
+				if chunk == "" {
+					return
+				}
+				partial.WriteString(chunk)
+				if !wroteHeader {
+					chunk = separator + header + "\n" + chunk
+					wroteHeader = true
+				}
+				o.emitTokenChunk(c, string(state.CurrentRole), chunk, false)
+
+				midResult := o.hooks.Emit(c, HookEvent{
+					Phase:       HookPhaseMidAgent,
+					TaskID:      task.ID,
+					CurrentRole: state.CurrentRole,
+					Handoff:     handoff,
+					Metadata: map[string]any{
+						"partial_output": partial.String(),
+						"chunk":          chunk,
+					},
+				})
+				if midResult.Kill {
+					midAgentKilled = true
+					streamCancel()
+				}
+			})
+			streamed = wroteHeader
+		} else {
+			response, err = agent.Execute(c, *handoff)
+		}
+		if midAgentKilled {
+			return abortReason(types.AbortReasonKilled, fmt.Errorf("workflow killed"))
+		}
 		if err != nil {
+			stats := roleStats[state.CurrentRole]
+			stats.Errors++
+			roleStats[state.CurrentRole] = stats
+
 			// Check if it was a kill signal
 			if o.hooks.IsKilled() {
-				return o.abortWorkflow(task, state.Handoffs, fmt.Errorf("workflow killed"))
+				return abortReason(types.AbortReasonKilled, fmt.Errorf("workflow killed"))
 			}
 			logging.Error("agent execution failed", err, "role", state.CurrentRole, "task_id", task.ID)
 			o.emitError(err)
-			return o.abortWorkflow(task, state.Handoffs, err)
+			return abort(err)
 		}
 
 		// Post-agent hook
@@ -306,41 +651,92 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 		o.emitHookNotify(HookPhasePostAgent, state.CurrentRole, task.ID)
 
 		if postResult.Kill {
-			return o.abortWorkflow(task, state.Handoffs, postResult.Error)
+			return abortReason(types.AbortReasonKilled, postResult.Error)
 		}
 
 		if postResult.ModifiedResponse != nil {
 			response = *postResult.ModifiedResponse
 		}
 
+		rvrTaskType := o.roleTaskTypes[state.CurrentRole]
+		rvrThreshold := o.config.RVRPolicies[rvrTaskType].Threshold
+		if response.Confidence != 0 {
+			o.emitRVR("layer1", 0, response.Confidence, rvrThreshold, response.Uncertainty, false)
+		}
+
+		response = o.applyRVRPolicy(c, agent, handoff, rvrTaskType, response)
+
+		if response.Confidence != 0 {
+			o.emitRVR("synthesis", 0, response.Confidence, rvrThreshold, response.Uncertainty, false)
+			o.emitRVRResult(rvrTaskType, response, response.Confidence >= rvrThreshold)
+		}
+
 		logging.AgentComplete(string(state.CurrentRole), task.ID, response.DurationMS, response.TokensUsed)
+		o.broker.Publish(c, "agent_complete", stream.AgentCompletion{
+			Role:       string(state.CurrentRole),
+			DurationMS: response.DurationMS,
+			TokensUsed: response.TokensUsed,
+		})
+		if o.store != nil && response.DurationMS > 0 {
+			if err := o.store.RecordRoleDuration(string(state.CurrentRole), response.DurationMS, response.TokensUsed); err != nil {
+				logging.Error("failed to record role duration", err, "role", state.CurrentRole)
+			}
+		}
 
-		// Emit token and metrics updates
+		// Emit token and metrics updates. Prefer the adapter's real
+		// prompt/completion split; fall back to a 50/50 estimate only when
+		// the CLI didn't report one.
+		responsePrompt, responseCompletion := response.PromptTokens, response.CompletionTokens
+		if responsePrompt == 0 && responseCompletion == 0 && response.TokensUsed > 0 {
+			responsePrompt = response.TokensUsed / 2
+			responseCompletion = response.TokensUsed - responsePrompt
+		}
 		totalTokens += response.TokensUsed
-		promptTokens += response.TokensUsed / 2
-		completionTokens += response.TokensUsed - (response.TokensUsed / 2)
-		o.emitMetrics(stream.MetricsSnapshot{
+		promptTokens += responsePrompt
+		completionTokens += responseCompletion
+
+		stats := roleStats[state.CurrentRole]
+		stats.Calls++
+		stats.Tokens += response.TokensUsed
+		stats.DurationMS += response.DurationMS
+		roleStats[state.CurrentRole] = stats
+		estimatedCost := estimateCostUSD(totalTokens)
+		if err := o.emitMetrics(stream.MetricsSnapshot{
 			TotalTokens:      totalTokens,
 			PromptTokens:     promptTokens,
 			CompletionTokens: completionTokens,
-			EstimatedCostUSD: estimateCostUSD(totalTokens),
+			EstimatedCostUSD: estimatedCost,
 			ElapsedTime:      time.Since(workflowStart),
 			APICallsCount:    stepCount,
 			AgentCycles:      stepCount,
 			CurrentAgent:     string(state.CurrentRole),
-		})
-		if strings.TrimSpace(response.Content) != "" {
+		}); err != nil {
+			return abortReason(types.AbortReasonBudgetExceeded, err)
+		}
+
+		if o.config.MaxCostUSD > 0 && estimatedCost >= o.config.MaxCostUSD && !budgetPrompted {
+			budgetPrompted = true
+			if o.config.HardBudget {
+				return abortReason(types.AbortReasonBudgetExceeded, fmt.Errorf("workflow aborted: estimated cost $%.4f crossed budget ceiling $%.4f", estimatedCost, o.config.MaxCostUSD))
+			}
+			if !o.requestBudgetContinue(estimatedCost) {
+				return abortReason(types.AbortReasonBudgetExceeded, fmt.Errorf("workflow aborted: budget exceeded and user declined to continue"))
+			}
+		}
+
+		if streamed {
+			o.emitTokenChunk(c, string(state.CurrentRole), "\n", true)
+		} else if strings.TrimSpace(response.Content) != "" {
 			separator := ""
 			if stepCount > 1 {
 				separator = "\n\n"
 			}
 			header := fmt.Sprintf("[%s]", strings.ToUpper(roleToLabel(state.CurrentRole)))
-			o.emitTokenChunk(string(state.CurrentRole), separator+header+"\n"+response.Content+"\n", true)
+			o.emitTokenChunk(c, string(state.CurrentRole), separator+header+"\n"+response.Content+"\n", true)
 		}
 
 		// Update handoff with execution metadata
 		modelProvider, modelName, profileName := o.modelInfoForRole(state.CurrentRole)
-		rvrTaskType := o.roleTaskTypes[state.CurrentRole]
 		handoff.Metadata = types.HMetadata{
 			TokensUsed:   response.TokensUsed,
 			Model:        modelProvider,
@@ -358,6 +754,13 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 		// Update handoff with merged artifacts
 		handoff.Artifacts = artifacts
 
+		// Accumulate structured requirements/constraints an agent emitted
+		// (e.g. the architect's REQUIREMENT:/CONSTRAINT: lines) so later
+		// agents see the full evolving spec, not just the previous agent's
+		// prose.
+		handoffCtx.Requirements = appendUniqueStrings(handoffCtx.Requirements, extractStringList(response.Artifacts, "requirements")...)
+		handoffCtx.Constraints = appendUniqueStrings(handoffCtx.Constraints, extractStringList(response.Artifacts, "constraints")...)
+
 		// Save artifacts to generated folder
 		if state.CurrentRole == types.RoleImplementer {
 			files := extractFiles(response.Artifacts)
@@ -368,68 +771,125 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 						logging.Error("invalid file path in response", nil, "task_id", task.ID, "path", rawPath)
 						continue
 					}
+					handoffCtx.FilesInScope = appendUniqueStrings(handoffCtx.FilesInScope, cleanPath)
+
+					if o.config.AnnotateUncertainty && response.Uncertainty != "" {
+						content = annotateUncertaintyCode(cleanPath, content, response.Uncertainty)
+					}
+
+					oldContent, existed := o.readFileIfExists(cleanPath)
+
+					if o.config.NoApply {
+						o.emitCode(c, cleanPath, content, detectLanguage(cleanPath, content))
+						if oldContent != content {
+							action := "add"
+							if existed {
+								action = "modify"
+							}
+							o.emitFileTree(action, cleanPath, false, int64(len(content)))
+							o.emitDiff(cleanPath, oldContent, content, simpleDiffHunks(oldContent, content))
+						}
+						continue
+					}
 
-					oldContent, existed := readFileIfExists(cleanPath)
 					path, err := o.store.SaveGeneratedCode(task.ID, cleanPath, content)
 					if err != nil {
 						logging.Error("failed to save code artifact", err, "task_id", task.ID)
 					} else {
 						logging.Info("saved code artifact", "path", path)
-						o.emitCode(cleanPath, content, detectLanguage(cleanPath))
+						o.emitCode(c, cleanPath, content, detectLanguage(cleanPath, content))
 					}
 
-					if err := writeWorkspaceFile(cleanPath, content); err != nil {
+					writeContent, approved := o.requestFileApproval(cleanPath, oldContent, content)
+					if !approved {
+						logging.Info("skipped workspace write, rejected by human approval", "task_id", task.ID, "path", cleanPath)
+					} else if err := o.writeWorkspaceFile(cleanPath, writeContent); err != nil {
 						logging.Error("failed to write task output", err, "task_id", task.ID, "path", cleanPath)
 					} else {
 						logging.Info("wrote task output", "path", cleanPath)
-						if oldContent != content {
+						if oldContent != writeContent {
 							action := "add"
 							if existed {
 								action = "modify"
 							}
-							o.emitFileTree(action, cleanPath, false, int64(len(content)))
-							o.emitDiff(cleanPath, oldContent, content, simpleDiffHunks(oldContent, content))
+							o.emitFileTree(action, cleanPath, false, int64(len(writeContent)))
+							o.emitDiff(cleanPath, oldContent, writeContent, simpleDiffHunks(oldContent, writeContent))
+							o.recordWrite(cleanPath, existed, oldContent)
 						}
 					}
 				}
 			} else if artifacts.Code != "" {
-				path, err := o.store.SaveGeneratedCode(task.ID, "", artifacts.Code)
-				if err != nil {
-					logging.Error("failed to save code artifact", err, "task_id", task.ID)
-				} else {
-					logging.Info("saved code artifact", "path", path)
-					o.emitCode(path, artifacts.Code, "go")
+				code := artifacts.Code
+				if o.config.AnnotateUncertainty && response.Uncertainty != "" {
+					code = annotateUncertaintyCode("", code, response.Uncertainty)
+				}
+
+				if !o.config.NoApply {
+					path, err := o.store.SaveGeneratedCode(task.ID, "", code)
+					if err != nil {
+						logging.Error("failed to save code artifact", err, "task_id", task.ID)
+					} else {
+						logging.Info("saved code artifact", "path", path)
+						o.emitCode(c, path, code, detectLanguage("", code))
+					}
 				}
 
-				if targetPath := extractTargetPath(task.Description); targetPath != "" {
-					oldContent, existed := readFileIfExists(targetPath)
-					if err := writeWorkspaceFile(targetPath, artifacts.Code); err != nil {
+				if targetPath := o.selectTargetPath(extractTargetPaths(task.Description)); targetPath != "" {
+					oldContent, existed := o.readFileIfExists(targetPath)
+
+					if o.config.NoApply {
+						o.emitCode(c, targetPath, code, detectLanguage(targetPath, code))
+						if oldContent != code {
+							action := "add"
+							if existed {
+								action = "modify"
+							}
+							o.emitFileTree(action, targetPath, false, int64(len(code)))
+							o.emitDiff(targetPath, oldContent, code, simpleDiffHunks(oldContent, code))
+						}
+					} else if writeContent, approved := o.requestFileApproval(targetPath, oldContent, code); !approved {
+						logging.Info("skipped workspace write, rejected by human approval", "task_id", task.ID, "path", targetPath)
+					} else if err := o.writeWorkspaceFile(targetPath, writeContent); err != nil {
 						logging.Error("failed to write task output", err, "task_id", task.ID, "path", targetPath)
 					} else {
 						logging.Info("wrote task output", "path", targetPath)
-						o.emitCode(targetPath, artifacts.Code, detectLanguage(targetPath))
-						if oldContent != artifacts.Code {
+						o.emitCode(c, targetPath, writeContent, detectLanguage(targetPath, writeContent))
+						if oldContent != writeContent {
 							action := "add"
 							if existed {
 								action = "modify"
 							}
-							o.emitFileTree(action, targetPath, false, int64(len(artifacts.Code)))
-							o.emitDiff(targetPath, oldContent, artifacts.Code, simpleDiffHunks(oldContent, artifacts.Code))
+							o.emitFileTree(action, targetPath, false, int64(len(writeContent)))
+							o.emitDiff(targetPath, oldContent, writeContent, simpleDiffHunks(oldContent, writeContent))
+							o.recordWrite(targetPath, existed, oldContent)
 						}
 					}
 				}
 			}
 		}
 		if artifacts.DesignDoc != "" && state.CurrentRole == types.RoleArchitect {
-			path, err := o.store.SaveDesignDoc(task.ID, artifacts.DesignDoc)
+			designDoc := artifacts.DesignDoc
+			if o.config.AnnotateUncertainty && response.Uncertainty != "" {
+				designDoc = annotateUncertaintyMarkdown(designDoc, response.Uncertainty)
+			}
+			path, err := o.store.SaveDesignDoc(task.ID, designDoc)
 			if err != nil {
 				logging.Error("failed to save design doc", err, "task_id", task.ID)
 			} else {
 				logging.Info("saved design doc", "path", path)
 			}
+
+			// Any file paths the architect named in its design go into
+			// scope alongside the target path, so the implementer and
+			// reviewer operate on a consistent file set.
+			handoffCtx.FilesInScope = appendUniqueStrings(handoffCtx.FilesInScope, extractNamedPaths(response.Content)...)
 		}
 		if artifacts.ReviewFeedback != "" && state.CurrentRole == types.RoleReviewer {
-			path, err := o.store.SaveReviewFeedback(task.ID, artifacts.ReviewFeedback)
+			reviewFeedback := artifacts.ReviewFeedback
+			if o.config.AnnotateUncertainty && response.Uncertainty != "" {
+				reviewFeedback = annotateUncertaintyMarkdown(reviewFeedback, response.Uncertainty)
+			}
+			path, err := o.store.SaveReviewFeedback(task.ID, reviewFeedback)
 			if err != nil {
 				logging.Error("failed to save review feedback", err, "task_id", task.ID)
 			} else {
@@ -437,6 +897,27 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 			}
 		}
 
+		// Check for review-cycle convergence: if the implementer's output
+		// hasn't meaningfully changed since its last turn, further review
+		// cycles are unlikely to help, so complete early instead of
+		// burning the rest of MaxReviewCycles.
+		if state.CurrentRole == types.RoleImplementer {
+			if state.PrevImplementerOutput != "" && converged(state.PrevImplementerOutput, response.Content, o.config.ConvergenceThreshold) {
+				logging.Info("implementer output converged, completing early",
+					"task_id", task.ID, "review_cycles", state.ReviewCycles)
+				o.emitToast("info", "Converged", "Implementer output stopped changing meaningfully; completing early")
+				o.hooks.Emit(c, HookEvent{
+					Phase:       HookPhaseWorkflowEnd,
+					TaskID:      task.ID,
+					CurrentRole: state.CurrentRole,
+					Metadata:    map[string]any{"success": true, "converged": true},
+				})
+				o.emitHookNotify(HookPhaseWorkflowEnd, state.CurrentRole, task.ID)
+				return complete()
+			}
+			state.PrevImplementerOutput = response.Content
+		}
+
 		// Determine next role
 		var nextRole *types.Role
 		if response.NextRole != nil {
@@ -446,6 +927,7 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 		// Update handoff with next role
 		if nextRole != nil {
 			handoff.ToRole = *nextRole
+			handoff.Reason = handoffReasonForTransition(*nextRole)
 		}
 
 		// Save handoff
@@ -464,7 +946,7 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 				Metadata:    map[string]any{"success": true},
 			})
 			o.emitHookNotify(HookPhaseWorkflowEnd, state.CurrentRole, task.ID)
-			return o.completeWorkflow(task, state, artifacts)
+			return complete()
 		}
 
 		// Pre-handoff hook
@@ -479,14 +961,14 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 		o.emitHookNotify(HookPhasePreHandoff, state.CurrentRole, task.ID)
 
 		if handoffResult.Kill {
-			return o.abortWorkflow(task, state.Handoffs, handoffResult.Error)
+			return abortReason(types.AbortReasonKilled, handoffResult.Error)
 		}
 
 		if handoffResult.Skip {
-			// Skip handoff, go to default next
-			nextRole = o.defaultNextRole(state.CurrentRole)
+			// Skip handoff, go to default next (or rolePlan)
+			nextRole = o.nextRoleAfter(state.CurrentRole, rolePlan)
 			if nextRole == nil {
-				return o.completeWorkflow(task, state, artifacts)
+				return complete()
 			}
 		}
 
@@ -495,13 +977,7 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 			state.ReviewCycles++
 			if state.ReviewCycles > o.config.MaxReviewCycles {
 				logging.WorkflowComplete(task.ID, false, state.ReviewCycles)
-				return types.WorkflowResult{
-					Task:      task,
-					Handoffs:  state.Handoffs,
-					Success:   false,
-					Error:     fmt.Sprintf("exceeded max review cycles (%d)", o.config.MaxReviewCycles),
-					Artifacts: artifacts,
-				}, nil
+				return abortReason(types.AbortReasonMaxCyclesExceeded, fmt.Errorf("exceeded max review cycles (%d)", o.config.MaxReviewCycles))
 			}
 		}
 
@@ -509,7 +985,16 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 		logging.Handoff(string(state.CurrentRole), string(*nextRole), task.ID)
 
 		// Emit handoff event
-		o.emitHandoff(string(state.CurrentRole), string(*nextRole), fmt.Sprintf("Transitioning to %s", roleToLabel(*nextRole)))
+		o.emitHandoff(c, string(state.CurrentRole), string(*nextRole), handoffReasonForTransition(*nextRole))
+
+		// Pre-populate the file tree with everything already in scope
+		// (target path, architect-named files) before the implementer
+		// actually writes anything.
+		if *nextRole == types.RoleImplementer {
+			for _, p := range handoffCtx.FilesInScope {
+				o.emitFileTree("scope", p, false, 0)
+			}
+		}
 
 		// Post-handoff hook
 		o.hooks.Emit(c, HookEvent{
@@ -520,6 +1005,14 @@ func (o *Orchestrator) executeWorkflow(c context.Context, task types.Task, initi
 		})
 		o.emitHookNotify(HookPhasePostHandoff, *nextRole, task.ID)
 
+		// A reviewer sending work back to the implementer means changes
+		// were requested; inject the review feedback as a requirement so
+		// the implementer actually addresses it instead of re-reading its
+		// own prior output.
+		if state.CurrentRole == types.RoleReviewer && *nextRole == types.RoleImplementer && artifacts.ReviewFeedback != "" {
+			handoffCtx.Requirements = append(handoffCtx.Requirements, "Address reviewer feedback: "+artifacts.ReviewFeedback)
+		}
+
 		state.CurrentRole = *nextRole
 
 		// Update context for next iteration
@@ -564,8 +1057,26 @@ func (o *Orchestrator) modelInfoForRole(role types.Role) (string, string, string
 	}
 }
 
-// abortWorkflow handles workflow termination.
-func (o *Orchestrator) abortWorkflow(task types.Task, handoffs []types.Handoff, err error) (types.WorkflowResult, error) {
+// classifyAbortReason infers an AbortReasonKind from a generic error, for
+// abort paths (context cancellation, an agent call failing outright) that
+// don't already know their own reason kind. Callers that do know it (a kill
+// signal, a missing agent, budget/cycle limits) should pass it directly to
+// abortReason instead of relying on this.
+func classifyAbortReason(err error) types.AbortReasonKind {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return types.AbortReasonTimeout
+	case errors.Is(err, context.Canceled):
+		return types.AbortReasonContextCanceled
+	default:
+		return types.AbortReasonAgentError
+	}
+}
+
+// abortWorkflow handles workflow termination. If CleanOnKill is set, files
+// written by the aborted run are removed or restored to their prior
+// content; otherwise the ledger is only used to report what changed.
+func (o *Orchestrator) abortWorkflow(task types.Task, handoffs []types.Handoff, artifacts types.HArtifacts, reason types.AbortReasonKind, err error, totalTokens, promptTokens, completionTokens int, elapsed time.Duration, roleStats map[types.Role]types.RoleStats) (types.WorkflowResult, error) {
 	errMsg := "workflow aborted"
 	if err != nil {
 		errMsg = err.Error()
@@ -573,21 +1084,34 @@ func (o *Orchestrator) abortWorkflow(task types.Task, handoffs []types.Handoff,
 
 	o.emitProgress("Aborted", 0, errMsg)
 	o.emitError(err)
+
+	filesChanged := o.changedFiles()
+	if o.config.CleanOnKill {
+		filesChanged = o.cleanupWrites()
+	} else {
+		o.persistWriteLedger(task.ID)
+	}
+
+	metrics := buildWorkflowMetrics(roleStats, totalTokens, promptTokens, completionTokens, elapsed, len(filesChanged))
+	o.emitSummary(metrics, false)
 	o.emitDone()
 
 	return types.WorkflowResult{
-		Task:     task,
-		Handoffs: handoffs,
-		Success:  false,
-		Error:    errMsg,
+		Task:         task,
+		Handoffs:     handoffs,
+		Success:      false,
+		Error:        errMsg,
+		AbortReason:  types.AbortReason{Kind: reason, Detail: errMsg},
+		Artifacts:    artifacts,
+		FilesChanged: filesChanged,
+		Metrics:      metrics,
 	}, err
 }
 
 // completeWorkflow handles successful completion.
-func (o *Orchestrator) completeWorkflow(task types.Task, state types.WorkflowState, artifacts types.HArtifacts) (types.WorkflowResult, error) {
+func (o *Orchestrator) completeWorkflow(task types.Task, state types.WorkflowState, artifacts types.HArtifacts, totalTokens, promptTokens, completionTokens int, elapsed time.Duration, roleStats map[types.Role]types.RoleStats) (types.WorkflowResult, error) {
 	logging.WorkflowComplete(task.ID, true, state.ReviewCycles)
 	o.emitProgress("Complete", 100, "Workflow completed successfully")
-	o.emitDone()
 
 	task.Status = types.TaskStatusCompleted
 	if path, err := o.store.SaveTaskSummary(task.ID, task, artifacts); err != nil {
@@ -596,15 +1120,97 @@ func (o *Orchestrator) completeWorkflow(task types.Task, state types.WorkflowSta
 		logging.Info("saved task summary", "path", path)
 	}
 
+	o.persistWriteLedger(task.ID)
+	if o.config.GitCommit {
+		o.gitCommitLedger(task)
+	}
+
+	filesChanged := o.changedFiles()
+	metrics := buildWorkflowMetrics(roleStats, totalTokens, promptTokens, completionTokens, elapsed, len(filesChanged))
+	metrics.ReviewCycles = state.ReviewCycles
+	o.emitSummary(metrics, true)
+	o.emitDone()
+
 	return types.WorkflowResult{
-		Task:      task,
-		Handoffs:  state.Handoffs,
-		Success:   true,
-		Artifacts: artifacts,
+		Task:         task,
+		Handoffs:     state.Handoffs,
+		Success:      true,
+		Artifacts:    artifacts,
+		FilesChanged: filesChanged,
+		Metrics:      metrics,
 	}, nil
 }
 
+// buildWorkflowMetrics assembles a run's totals and live per-role breakdown
+// into a single summary.
+func buildWorkflowMetrics(roleStats map[types.Role]types.RoleStats, totalTokens, promptTokens, completionTokens int, elapsed time.Duration, filesChanged int) types.WorkflowMetrics {
+	return types.WorkflowMetrics{
+		TotalTokens:      totalTokens,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		ElapsedTime:      elapsed,
+		FilesChanged:     filesChanged,
+		RoleBreakdown:    roleStats,
+	}
+}
+
+// emitSummary publishes the final workflow summary to the broker, and to
+// the stream if one is available.
+func (o *Orchestrator) emitSummary(metrics types.WorkflowMetrics, success bool) {
+	roleBreakdown := make(map[string]stream.RoleStats, len(metrics.RoleBreakdown))
+	for role, stats := range metrics.RoleBreakdown {
+		roleBreakdown[string(role)] = stream.RoleStats{
+			Calls:      stats.Calls,
+			Tokens:     stats.Tokens,
+			DurationMS: stats.DurationMS,
+			Errors:     stats.Errors,
+		}
+	}
+
+	summary := stream.WorkflowSummary{
+		TotalTokens:      metrics.TotalTokens,
+		PromptTokens:     metrics.PromptTokens,
+		CompletionTokens: metrics.CompletionTokens,
+		EstimatedCostUSD: metrics.EstimatedCostUSD,
+		ElapsedTime:      metrics.ElapsedTime,
+		ReviewCycles:     metrics.ReviewCycles,
+		FilesChanged:     metrics.FilesChanged,
+		RoleBreakdown:    roleBreakdown,
+		Success:          success,
+	}
+	o.broker.Publish(context.Background(), "summary", summary)
+
+	if o.stream == nil {
+		return
+	}
+	o.stream.SendSummary(summary)
+}
+
 // defaultNextRole returns the default next role in the workflow.
+// estimateRemainingMS projects how long is left in the workflow from
+// current onward, by summing each upcoming role's historical average
+// duration from the store. It walks the same default architect ->
+// implementer -> reviewer progression used to route between agents, so it
+// only approximates runs that take review-cycle detours. ok is false when
+// the store has no history yet for current or any role after it.
+func (o *Orchestrator) estimateRemainingMS(current types.Role) (int64, bool) {
+	if o.store == nil {
+		return 0, false
+	}
+
+	var total int64
+	found := false
+	role := &current
+	for hops := 0; role != nil && hops < 10; hops++ {
+		if avg, ok := o.store.AverageRoleDurationMS(string(*role)); ok {
+			total += avg
+			found = true
+		}
+		role = o.defaultNextRole(*role)
+	}
+	return total, found
+}
+
 func (o *Orchestrator) defaultNextRole(current types.Role) *types.Role {
 	// Default progression: architect -> implementer -> reviewer -> done
 	switch current {
@@ -624,6 +1230,25 @@ func (o *Orchestrator) defaultNextRole(current types.Role) *types.Role {
 	}
 }
 
+// nextRoleAfter returns the role that should follow current when the
+// pre-agent or pre-handoff hook skips a step. With no rolePlan (the normal
+// case) it just defers to defaultNextRole. With a human-edited rolePlan
+// (see Orchestrator.RunWithPlan) it instead follows that explicit
+// sequence, returning nil once current is its last entry or isn't in it
+// at all.
+func (o *Orchestrator) nextRoleAfter(current types.Role, rolePlan []types.Role) *types.Role {
+	if len(rolePlan) == 0 {
+		return o.defaultNextRole(current)
+	}
+	for i, role := range rolePlan {
+		if role == current && i+1 < len(rolePlan) {
+			next := rolePlan[i+1]
+			return &next
+		}
+	}
+	return nil
+}
+
 // emitHookNotify sends a hook notification to the TUI.
 func (o *Orchestrator) emitHookNotify(phase HookPhase, role types.Role, taskID string) {
 	if o.stream == nil {
@@ -690,14 +1315,73 @@ func (o *Orchestrator) waitWhilePaused(ctx context.Context, paused *bool) error
 
 var taskPathPattern = regexp.MustCompile(`(?i)([A-Za-z0-9][A-Za-z0-9_./\\-]*\.[A-Za-z0-9]{1,6})`)
 
-func extractTargetPath(task string) string {
-	match := taskPathPattern.FindStringSubmatch(task)
-	if len(match) < 2 {
-		return ""
+// extractTargetPaths returns every plausible file path referenced in task,
+// in the order they appear, deduplicated. A task like "update foo.go and
+// bar.go" yields both, instead of just the first regex match.
+func extractTargetPaths(task string) []string {
+	return extractPathCandidates(task)
+}
+
+// extractNamedPaths finds file-path-shaped tokens anywhere in content (e.g.
+// an architect's design doc naming files to create or touch) and returns
+// their cleaned, deduplicated relative paths.
+func extractNamedPaths(content string) []string {
+	return extractPathCandidates(content)
+}
+
+// extractPathCandidates finds file-path-shaped tokens anywhere in text and
+// returns their cleaned, deduplicated relative paths. Tokens that look like
+// a version number (e.g. "v1.2") rather than a filename are excluded.
+func extractPathCandidates(text string) []string {
+	matches := taskPathPattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return nil
 	}
+	var paths []string
+	for _, match := range matches {
+		candidate := strings.Trim(match[1], "`\"'()[]{}<>.,;:")
+		if looksLikeVersion(candidate) {
+			continue
+		}
+		if clean := cleanRelativePath(candidate); clean != "" {
+			paths = appendUniqueStrings(paths, clean)
+		}
+	}
+	return paths
+}
+
+// looksLikeVersion reports whether candidate reads more like a version
+// number (e.g. "v1.2", "2.0") than a filename: its final "extension"
+// segment is entirely digits, which no common source file extension is.
+func looksLikeVersion(candidate string) bool {
+	ext := strings.TrimPrefix(filepath.Ext(candidate), ".")
+	if ext == "" {
+		return false
+	}
+	for _, r := range ext {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
 
-	candidate := strings.Trim(match[1], "`\"'()[]{}<>.,;:")
-	return cleanRelativePath(candidate)
+// selectTargetPath picks which of paths an unstructured artifacts.Code blob
+// should be written to. When the implementer's response doesn't name files
+// individually (see extractFiles for when it does), there's no way to know
+// which named path the single blob belongs to, so prefer one that already
+// exists in the workspace - editing a known file is far more likely than
+// creating an oddly-guessed new one - falling back to the first path named.
+func (o *Orchestrator) selectTargetPath(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	for _, p := range paths {
+		if _, existed := o.readFileIfExists(p); existed {
+			return p
+		}
+	}
+	return paths[0]
 }
 
 func extractFiles(artifacts map[string]any) map[string]string {
@@ -724,6 +1408,51 @@ func extractFiles(artifacts map[string]any) map[string]string {
 	}
 }
 
+// extractStringList reads a []string (or []any of strings) out of an
+// artifacts map, mirroring extractFiles' handling of loosely-typed
+// artifact values.
+func extractStringList(artifacts map[string]any, key string) []string {
+	raw, ok := artifacts[key]
+	if !ok {
+		return nil
+	}
+
+	switch v := raw.(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// appendUniqueStrings appends each of additions to base that isn't already
+// present, preserving base's existing order.
+func appendUniqueStrings(base []string, additions ...string) []string {
+	if len(additions) == 0 {
+		return base
+	}
+	seen := make(map[string]struct{}, len(base))
+	for _, s := range base {
+		seen[s] = struct{}{}
+	}
+	for _, s := range additions {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		base = append(base, s)
+	}
+	return base
+}
+
 func cleanRelativePath(candidate string) string {
 	if candidate == "" {
 		return ""
@@ -746,27 +1475,76 @@ func cleanRelativePath(candidate string) string {
 	return clean
 }
 
-func detectLanguage(path string) string {
-	switch strings.ToLower(filepath.Ext(path)) {
-	case ".go":
+// detectLanguage picks the CodeUpdate language for path/content. Extension
+// is authoritative whenever path is non-empty; only an empty path (e.g. a
+// code blob saved under the store's default filename) falls back to
+// content heuristics, since at that point there is no extension to trust.
+func detectLanguage(path, content string) string {
+	if path != "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".go":
+			return "go"
+		case ".js", ".mjs", ".cjs":
+			return "javascript"
+		case ".ts", ".tsx":
+			return "typescript"
+		case ".py":
+			return "python"
+		case ".md":
+			return "markdown"
+		case ".json":
+			return "json"
+		case ".yaml", ".yml":
+			return "yaml"
+		case ".toml":
+			return "toml"
+		default:
+			return "text"
+		}
+	}
+
+	if lang := detectLanguageFromContent(content); lang != "" {
+		return lang
+	}
+	return "text"
+}
+
+// detectLanguageFromContent guesses a language from a shebang line or
+// common declaration keywords, for code emitted without a filename.
+// Returns "" when nothing recognizable is found.
+func detectLanguageFromContent(content string) string {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return ""
+	}
+
+	firstLine := trimmed
+	if idx := strings.IndexByte(trimmed, '\n'); idx >= 0 {
+		firstLine = trimmed[:idx]
+	}
+	if strings.HasPrefix(firstLine, "#!") {
+		switch {
+		case strings.Contains(firstLine, "python"):
+			return "python"
+		case strings.Contains(firstLine, "node"):
+			return "javascript"
+		case strings.Contains(firstLine, "bash"), strings.Contains(firstLine, "sh"):
+			return "bash"
+		}
+	}
+
+	switch {
+	case strings.Contains(content, "package ") && strings.Contains(content, "func "):
 		return "go"
-	case ".js", ".mjs", ".cjs":
-		return "javascript"
-	case ".ts", ".tsx":
-		return "typescript"
-	case ".py":
+	case strings.Contains(content, "def ") && strings.Contains(content, ":"):
 		return "python"
-	case ".md":
-		return "markdown"
-	case ".json":
-		return "json"
-	case ".yaml", ".yml":
-		return "yaml"
-	case ".toml":
-		return "toml"
-	default:
-		return "text"
+	case strings.Contains(content, "fn ") && strings.Contains(content, "->"):
+		return "rust"
+	case strings.Contains(content, "function ") || strings.Contains(content, "=>"):
+		return "javascript"
 	}
+
+	return ""
 }
 
 func estimateCostUSD(totalTokens int) float64 {
@@ -774,8 +1552,10 @@ func estimateCostUSD(totalTokens int) float64 {
 	return float64(totalTokens) / 1_000_000 * costPerMToken
 }
 
-func readFileIfExists(path string) (string, bool) {
-	data, err := os.ReadFile(path)
+// readFileIfExists reads relPath (already validated by cleanRelativePath)
+// under o.workspaceRoot.
+func (o *Orchestrator) readFileIfExists(relPath string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(o.workspaceRoot, relPath))
 	if err != nil {
 		return "", false
 	}
@@ -812,17 +1592,413 @@ func simpleDiffHunks(oldContent, newContent string) []stream.DiffHunk {
 	return []stream.DiffHunk{hunk}
 }
 
-func writeWorkspaceFile(relPath string, content string) error {
-	dir := filepath.Dir(relPath)
+// converged reports whether curr is no longer meaningfully different from
+// prev, judged by line-multiset similarity against threshold (0-1). A
+// threshold of 0 disables convergence detection entirely.
+func converged(prev, curr string, threshold float64) bool {
+	if threshold <= 0 {
+		return false
+	}
+	prev = strings.TrimSpace(prev)
+	curr = strings.TrimSpace(curr)
+	if prev == curr {
+		return true
+	}
+	if prev == "" || curr == "" {
+		return false
+	}
+	return lineSimilarity(prev, curr) >= threshold
+}
+
+// lineSimilarity scores two texts by treating each as a multiset of lines
+// and returning the Jaccard-style ratio |intersection| / |union|.
+func lineSimilarity(a, b string) float64 {
+	aLines := strings.Split(a, "\n")
+	bLines := strings.Split(b, "\n")
+
+	counts := make(map[string]int, len(aLines))
+	for _, line := range aLines {
+		counts[line]++
+	}
+
+	shared := 0
+	for _, line := range bLines {
+		if counts[line] > 0 {
+			counts[line]--
+			shared++
+		}
+	}
+
+	union := len(aLines) + len(bLines) - shared
+	if union == 0 {
+		return 1
+	}
+	return float64(shared) / float64(union)
+}
+
+// requestFileApproval asks a human to approve, reject, or edit a generated
+// file before it's written to the workspace. It returns the content to
+// write and whether the write should proceed. Approval is skipped (write
+// proceeds as-is) when no stream is attached or RequireApprovalBeforeWrite
+// is off, so headless runs behave exactly as before.
+func (o *Orchestrator) requestFileApproval(path, oldContent, newContent string) (string, bool) {
+	if o.stream == nil || !o.config.RequireApprovalBeforeWrite {
+		return newContent, true
+	}
+
+	decision := o.stream.RequestDecision(stream.DecisionRequest{
+		ID:     fmt.Sprintf("approve-write:%s", path),
+		Title:  fmt.Sprintf("Approve write to %s?", path),
+		Prompt: formatDiffPrompt(path, oldContent, newContent),
+		Options: []stream.DecisionOptionSpec{
+			{Key: "1", Label: "Approve", Description: "Write the generated content as-is"},
+			{Key: "2", Label: "Reject", Description: "Skip writing this file", Danger: true},
+			{Key: "3", Label: "Edit", Description: "Supply replacement content"},
+		},
+	})
+
+	switch decision.Action {
+	case stream.DecisionReject:
+		return "", false
+	case stream.DecisionEdit:
+		return decision.Edited, true
+	default:
+		return newContent, true
+	}
+}
+
+// applyRVRPolicy reacts to a response whose RVR confidence falls below the
+// policy configured for its task type: it retries the agent once, asks a
+// human to decide via the attached stream, or leaves a caveat on the
+// artifacts so downstream readers know to scrutinize the output. Task types
+// with no configured policy, or responses that never went through RVR
+// (Confidence == 0), are returned unchanged.
+func (o *Orchestrator) applyRVRPolicy(c context.Context, agent agents.Agent, handoff *types.Handoff, taskType string, response types.AgentResponse) types.AgentResponse {
+	policy, ok := o.config.RVRPolicies[taskType]
+	if !ok || response.Confidence == 0 || response.Confidence >= policy.Threshold {
+		return response
+	}
+
+	switch policy.Action {
+	case RVRActionRetry:
+		o.emitToast("warning", "RVR retry", fmt.Sprintf("%s confidence %.0f%% below threshold %.0f%%, retrying once", taskType, response.Confidence*100, policy.Threshold*100))
+		if retried, err := agent.Execute(c, *handoff); err == nil {
+			return retried
+		}
+		return withRVRCaveat(response, taskType)
+	case RVRActionAsk:
+		if o.stream == nil {
+			return withRVRCaveat(response, taskType)
+		}
+		decision := o.stream.RequestDecision(stream.DecisionRequest{
+			ID:    fmt.Sprintf("rvr-low-confidence:%s", taskType),
+			Title: "Low-confidence response, continue?",
+			Prompt: fmt.Sprintf("%s response confidence is %.0f%%, below the configured threshold of %.0f%%.",
+				taskType, response.Confidence*100, policy.Threshold*100),
+			Options: []stream.DecisionOptionSpec{
+				{Key: "1", Label: "Accept", Description: "Keep this response as-is"},
+				{Key: "2", Label: "Retry", Description: "Re-run the agent for a fresh attempt", Danger: true},
+			},
+		})
+		if decision.Action != stream.DecisionApprove {
+			if retried, err := agent.Execute(c, *handoff); err == nil {
+				return retried
+			}
+		}
+		return withRVRCaveat(response, taskType)
+	default: // RVRActionIgnore and unset
+		return withRVRCaveat(response, taskType)
+	}
+}
+
+// withRVRCaveat tags response's artifacts with a low-confidence note without
+// dropping any existing artifact content.
+func withRVRCaveat(response types.AgentResponse, taskType string) types.AgentResponse {
+	if response.Artifacts == nil {
+		response.Artifacts = map[string]any{}
+	}
+	response.Artifacts["rvr_caveat"] = fmt.Sprintf("low-confidence %s response (%.0f%%); review before trusting", taskType, response.Confidence*100)
+	return response
+}
+
+// annotateUncertaintyMarkdown appends a "Caveats" section reporting
+// uncertainty to a markdown artifact (a design doc or review feedback body).
+func annotateUncertaintyMarkdown(content, uncertainty string) string {
+	if uncertainty == "" {
+		return content
+	}
+	return content + "\n\n## Caveats\n\n" + uncertainty + "\n"
+}
+
+// annotateUncertaintyCode prefixes generated code with a TODO(uncertain)
+// comment reporting uncertainty, using the file's detected line-comment
+// syntax so the annotation doesn't break the source.
+func annotateUncertaintyCode(path, content, uncertainty string) string {
+	if uncertainty == "" {
+		return content
+	}
+	prefix := "//"
+	if detectLanguage(path, content) == "python" {
+		prefix = "#"
+	}
+	return fmt.Sprintf("%s TODO(uncertain): %s\n%s", prefix, uncertainty, content)
+}
+
+// requestBudgetContinue asks a human whether to keep running after the
+// estimated cost crosses WorkflowConfig.MaxCostUSD. With no stream attached
+// there's no one to ask, so headless runs continue rather than blocking
+// forever.
+func (o *Orchestrator) requestBudgetContinue(estimatedCost float64) bool {
+	if o.stream == nil {
+		return true
+	}
+
+	decision := o.stream.RequestDecision(stream.DecisionRequest{
+		ID:     "budget-exceeded",
+		Title:  "Budget exceeded, continue?",
+		Prompt: fmt.Sprintf("Estimated cost $%.4f has crossed the configured budget of $%.4f.", estimatedCost, o.config.MaxCostUSD),
+		Options: []stream.DecisionOptionSpec{
+			{Key: "1", Label: "Continue", Description: "Keep running despite exceeding the budget"},
+			{Key: "2", Label: "Stop", Description: "Abort the workflow now", Danger: true},
+		},
+	})
+
+	return decision.Action == stream.DecisionApprove
+}
+
+// formatDiffPrompt renders a human-readable diff of a proposed workspace
+// write for display in a DecisionRequest prompt.
+func formatDiffPrompt(path, oldContent, newContent string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Proposed write to %s:\n\n", path)
+	for _, hunk := range simpleDiffHunks(oldContent, newContent) {
+		for _, line := range hunk.Lines {
+			switch line.Type {
+			case "add":
+				b.WriteString("+ " + line.Content + "\n")
+			case "remove":
+				b.WriteString("- " + line.Content + "\n")
+			default:
+				b.WriteString("  " + line.Content + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// loadContextFiles resolves patterns (paths or glob patterns) against root
+// and returns their concatenated contents as reference material, each file
+// under its own "## path" heading. Binary files are skipped, since they'd
+// just add noise a text prompt can't use. The total is capped at budget
+// bytes; once the cap is hit, a trailing note records how much was left
+// out instead of silently truncating.
+func loadContextFiles(root string, patterns []string, budget int) string {
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(root, pattern))
+		if err != nil {
+			logging.Error("invalid context file pattern, skipping", err, "pattern", pattern)
+			continue
+		}
+		if len(matches) == 0 {
+			// Not a glob pattern (or matched nothing) - try it as a literal path.
+			matches = []string{filepath.Join(root, pattern)}
+		}
+		paths = append(paths, matches...)
+	}
+
+	var b strings.Builder
+	used := 0
+	omitted := 0
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if isBinary(content) {
+			continue
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+
+		entry := fmt.Sprintf("## %s\n%s\n\n", rel, string(content))
+		if used+len(entry) > budget {
+			omitted++
+			continue
+		}
+		b.WriteString(entry)
+		used += len(entry)
+	}
+
+	if omitted > 0 {
+		b.WriteString(fmt.Sprintf("(%d context file(s) omitted: byte budget of %d exceeded)\n", omitted, budget))
+	}
+
+	return b.String()
+}
+
+// isBinary reports whether content looks like binary data rather than
+// text, using the same heuristic as most diff tools: the presence of a NUL
+// byte in the first 8000 bytes.
+func isBinary(content []byte) bool {
+	sample := content
+	if len(sample) > 8000 {
+		sample = sample[:8000]
+	}
+	return bytes.IndexByte(sample, 0) != -1
+}
+
+// writeWorkspaceFile writes relPath (already validated by cleanRelativePath)
+// under o.workspaceRoot, creating any intermediate directories.
+func (o *Orchestrator) writeWorkspaceFile(relPath string, content string) error {
+	fullPath := filepath.Join(o.workspaceRoot, relPath)
+
+	dir := filepath.Dir(fullPath)
 	if dir != "." {
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return fmt.Errorf("create target directory: %w", err)
 		}
 	}
 
-	if err := os.WriteFile(relPath, []byte(content), 0644); err != nil {
+	if err := writeFileAtomic(fullPath, []byte(content), 0644); err != nil {
 		return fmt.Errorf("write target file: %w", err)
 	}
 
 	return nil
 }
+
+// writeFileAtomic writes data to path by first writing to a temp file in
+// the same directory, then renaming it into place. Rename is atomic on the
+// same filesystem, so a crash or interrupt mid-write leaves either the old
+// content or the new content at path, never a partial file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// writeLedgerEntry records one workspace write made during a run, so an
+// aborted run can be cleaned up and a finished run can report an accurate
+// list of what changed.
+type writeLedgerEntry struct {
+	Path       string // relative to o.workspaceRoot
+	Existed    bool   // true if the file already existed before this write (modified, not created)
+	OldContent string // content before this write, restored on cleanup
+}
+
+// recordWrite appends an entry to the run's write ledger.
+func (o *Orchestrator) recordWrite(relPath string, existed bool, oldContent string) {
+	o.writeLedger = append(o.writeLedger, writeLedgerEntry{
+		Path:       relPath,
+		Existed:    existed,
+		OldContent: oldContent,
+	})
+}
+
+// changedFiles converts the write ledger into the FileChange summary
+// attached to a WorkflowResult.
+func (o *Orchestrator) changedFiles() []types.FileChange {
+	if len(o.writeLedger) == 0 {
+		return nil
+	}
+	changes := make([]types.FileChange, len(o.writeLedger))
+	for i, entry := range o.writeLedger {
+		action := "created"
+		if entry.Existed {
+			action = "modified"
+		}
+		changes[i] = types.FileChange{Path: entry.Path, Action: action}
+	}
+	return changes
+}
+
+// persistWriteLedger saves the run's write ledger to the store so `coop
+// undo` can reverse it after this process has exited. It's best-effort: a
+// failed save is logged but doesn't affect the workflow result.
+func (o *Orchestrator) persistWriteLedger(taskID string) {
+	if len(o.writeLedger) == 0 || o.store == nil {
+		return
+	}
+	entries := make([]types.WriteLedgerEntry, len(o.writeLedger))
+	for i, entry := range o.writeLedger {
+		entries[i] = types.WriteLedgerEntry{
+			Path:       entry.Path,
+			Existed:    entry.Existed,
+			OldContent: entry.OldContent,
+		}
+	}
+	if err := o.store.SaveWriteLedger(taskID, entries); err != nil {
+		logging.Error("failed to save write ledger", err, "task_id", taskID)
+	}
+}
+
+// gitCommitLedger stages the files in the run's write ledger and commits
+// them, when o.workspaceRoot is a git repository. It's best-effort: a
+// failure (not a repo, nothing staged, git not on PATH) is logged as a
+// warning rather than failing the workflow, since the run itself already
+// succeeded.
+func (o *Orchestrator) gitCommitLedger(task types.Task) {
+	if len(o.writeLedger) == 0 {
+		return
+	}
+	if !isGitRepo(o.workspaceRoot) {
+		logging.Info("--git-commit set but workspace is not a git repository, skipping", "workspace", o.workspaceRoot)
+		return
+	}
+
+	paths := make([]string, len(o.writeLedger))
+	for i, entry := range o.writeLedger {
+		paths[i] = entry.Path
+	}
+
+	message := fmt.Sprintf("coop: %s\n\nTask %s", task.Description, task.ID)
+	if err := gitCommitChanges(o.workspaceRoot, paths, message); err != nil {
+		logging.Error("failed to commit workspace changes", err, "task_id", task.ID)
+	}
+}
+
+// cleanupWrites undoes every write recorded in the run's ledger: files that
+// didn't exist before the run are removed, files that did are restored to
+// their pre-run content. It's best-effort — a failed revert is logged and
+// the rest of the ledger is still processed.
+func (o *Orchestrator) cleanupWrites() []types.FileChange {
+	if len(o.writeLedger) == 0 {
+		return nil
+	}
+	reverted := make([]types.FileChange, 0, len(o.writeLedger))
+	for _, entry := range o.writeLedger {
+		fullPath := filepath.Join(o.workspaceRoot, entry.Path)
+		if entry.Existed {
+			if err := os.WriteFile(fullPath, []byte(entry.OldContent), 0644); err != nil {
+				logging.Error("failed to restore file on kill cleanup", err, "path", entry.Path)
+				continue
+			}
+		} else {
+			if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+				logging.Error("failed to remove file on kill cleanup", err, "path", entry.Path)
+				continue
+			}
+		}
+		reverted = append(reverted, types.FileChange{Path: entry.Path, Action: "reverted"})
+	}
+	return reverted
+}
@@ -0,0 +1,210 @@
+package orchestrator
+
+import (
+	"reflect"
+	"testing"
+
+	"cooperations/internal/tui/stream"
+	"cooperations/internal/types"
+)
+
+func TestExtractTargetPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		task string
+		want []string
+	}{
+		{
+			name: "single path",
+			task: "fix the bug in foo.go",
+			want: []string{"foo.go"},
+		},
+		{
+			name: "multiple paths",
+			task: "update foo.go and bar.go",
+			want: []string{"foo.go", "bar.go"},
+		},
+		{
+			name: "path in backticks",
+			task: "rename the function in `internal/util.go`",
+			want: []string{"internal/util.go"},
+		},
+		{
+			name: "version number is not a path",
+			task: "bump the API to v1.2 and update client.go accordingly",
+			want: []string{"client.go"},
+		},
+		{
+			name: "no paths",
+			task: "improve error messages across the CLI",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractTargetPaths(tt.task)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractTargetPaths(%q) = %v, want %v", tt.task, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    string
+	}{
+		{
+			name:    "extension wins over content",
+			path:    "main.go",
+			content: "def handler():\n    pass",
+			want:    "go",
+		},
+		{
+			name:    "unrecognized extension stays text",
+			path:    "notes.txt",
+			content: "package main\nfunc main() {}",
+			want:    "text",
+		},
+		{
+			name:    "python shebang",
+			path:    "",
+			content: "#!/usr/bin/env python\nprint('hi')",
+			want:    "python",
+		},
+		{
+			name:    "bash shebang",
+			path:    "",
+			content: "#!/bin/bash\necho hi",
+			want:    "bash",
+		},
+		{
+			name:    "go keywords",
+			path:    "",
+			content: "package main\n\nfunc main() {}\n",
+			want:    "go",
+		},
+		{
+			name:    "python keywords",
+			path:    "",
+			content: "def greet(name):\n    return name",
+			want:    "python",
+		},
+		{
+			name:    "rust keywords",
+			path:    "",
+			content: "fn main() -> () {}",
+			want:    "rust",
+		},
+		{
+			name:    "javascript keywords",
+			path:    "",
+			content: "function greet() { return 1 }",
+			want:    "javascript",
+		},
+		{
+			name:    "no signal falls back to text",
+			path:    "",
+			content: "just some prose",
+			want:    "text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectLanguage(tt.path, tt.content); got != tt.want {
+				t.Errorf("detectLanguage(%q, %q) = %q, want %q", tt.path, tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLooksLikeVersion(t *testing.T) {
+	tests := []struct {
+		candidate string
+		want      bool
+	}{
+		{"v1.2", true},
+		{"2.0", true},
+		{"foo.go", false},
+		{"README.md", false},
+		{"v1.2.3", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.candidate, func(t *testing.T) {
+			if got := looksLikeVersion(tt.candidate); got != tt.want {
+				t.Errorf("looksLikeVersion(%q) = %v, want %v", tt.candidate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepProgressPercentStaysWithinBounds(t *testing.T) {
+	tests := []struct {
+		role      types.Role
+		stepCount int
+		want      float64
+	}{
+		{types.RoleArchitect, 1, 30},
+		{types.RoleImplementer, 3, 65},
+		{types.RoleReviewer, 6, 90},
+		{types.RoleHuman, 10, 95}, // no configured weight, falls back to the capped step-count formula
+	}
+
+	for _, tt := range tests {
+		if got := stepProgressPercent(tt.role, tt.stepCount); got != tt.want {
+			t.Errorf("stepProgressPercent(%v, %d) = %v, want %v", tt.role, tt.stepCount, got, tt.want)
+		}
+	}
+}
+
+func TestEmitProgressClampsAndIsMonotonic(t *testing.T) {
+	o := &Orchestrator{stream: stream.NewWorkflowStream()}
+
+	drain := func() float64 {
+		select {
+		case u := <-o.stream.Progress:
+			return u.Percent
+		default:
+			t.Fatal("expected a progress update on the channel")
+			return -1
+		}
+	}
+
+	o.emitProgress("Architect", 150, "over 100 clamps to 100")
+	if got := drain(); got != 100 {
+		t.Errorf("percent = %v, want clamped to 100", got)
+	}
+
+	o.emitProgress("Implementer", -10, "below 0 clamps to 0")
+	// A non-reset stage can't move backward from the 100 the prior step set,
+	// so this stays at 100 rather than the clamped-but-lower 0.
+	if got := drain(); got != 100 {
+		t.Errorf("percent = %v, want held at prior high-water mark 100", got)
+	}
+
+	o.emitProgress("Starting", 0, "explicit reset stage may move backward")
+	if got := drain(); got != 0 {
+		t.Errorf("percent = %v, want 0 after reset stage", got)
+	}
+
+	o.emitProgress("Architect", 30, "step 1")
+	if got := drain(); got != 30 {
+		t.Errorf("percent = %v, want 30", got)
+	}
+
+	o.emitProgress("Reviewer", 15, "a re-fired earlier-phase stage must not move the bar backward")
+	if got := drain(); got != 30 {
+		t.Errorf("percent = %v, want held at high-water mark 30", got)
+	}
+
+	o.emitProgress("Complete", 100, "explicit completion")
+	if got := drain(); got != 100 {
+		t.Errorf("percent = %v, want 100", got)
+	}
+}
@@ -0,0 +1,171 @@
+// Package server exposes a running workflow's event stream over HTTP, for
+// lightweight remote monitoring without the TUI or GUI.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cooperations/internal/tui/stream"
+)
+
+// eventBuffer is the SSE subscriber's broker buffer. Dashboards are
+// best-effort observers, so a slow browser drops old events rather than
+// backpressuring the workflow.
+const eventBuffer = 64
+
+// Server streams one workflow run's events over Server-Sent Events at
+// /events and accepts human responses to DecisionRequests at /decision.
+// /ws offers the same event stream plus decision responses over a single
+// bidirectional WebSocket, for clients that want one connection instead of
+// two. All three require token: /decision and /ws can steer a live
+// workflow's approval gates, and /events streams the same code diffs, file
+// trees, and agent content the workflow is acting on.
+type Server struct {
+	broker *stream.Broker
+	ws     *stream.WorkflowStream
+	http   *http.Server
+	token  string
+}
+
+// New creates a Server that fans out broker's events and routes /decision
+// posts into ws.Response. addr is passed straight to http.Server (e.g.
+// "127.0.0.1:9091"). token, if non-empty, is required on /events,
+// /decision, and /ws (see authorized); an empty token disables that check,
+// which callers should only do for a deliberately trusted deployment.
+func New(broker *stream.Broker, ws *stream.WorkflowStream, addr, token string) *Server {
+	s := &Server{broker: broker, ws: ws, token: token}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/events", s.handleEvents)
+	mux.HandleFunc("/decision", s.handleDecision)
+	mux.HandleFunc("/ws", s.handleWS)
+	s.http = &http.Server{Addr: addr, Handler: mux}
+
+	return s
+}
+
+// authorized reports whether r carries s.token via the X-Coop-Token header
+// or a token query parameter (the latter so browser WebSocket clients,
+// which can't set custom headers on the handshake, can authenticate too).
+// The comparison is constant-time so a timing side channel can't be used to
+// guess the token byte by byte. An empty s.token disables the check.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	got := r.Header.Get("X-Coop-Token")
+	if got == "" {
+		got = r.URL.Query().Get("token")
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) == 1
+}
+
+// ListenAndServe runs the HTTP server until ctx is canceled, then shuts it
+// down gracefully. It returns nil on a clean shutdown, or the underlying
+// listen error otherwise.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if err := s.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.http.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("shutdown http server: %w", err)
+		}
+		<-errCh
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// handleEvents streams broker events to the client as Server-Sent Events,
+// one "event: <kind>\ndata: <json>\n\n" block per event, until the client
+// disconnects. It requires token like /decision and /ws do, since the
+// events it streams (code diffs, file trees, agent content) are the
+// workflow's actual source and output, not just control signals.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events, unsubscribe := s.broker.Subscribe(eventBuffer, stream.PolicyDropOldest)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(evt.Payload)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", evt.Kind, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// handleDecision accepts a JSON-encoded stream.HumanDecision and forwards
+// it to the attached WorkflowStream's Response channel, resolving whatever
+// DecisionRequest matching RequestID the workflow is currently waiting on.
+func (s *Server) handleDecision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if s.ws == nil {
+		http.Error(w, "no workflow stream attached", http.StatusServiceUnavailable)
+		return
+	}
+
+	var decision stream.HumanDecision
+	if err := json.NewDecoder(r.Body).Decode(&decision); err != nil {
+		http.Error(w, fmt.Sprintf("decode decision: %v", err), http.StatusBadRequest)
+		return
+	}
+	if decision.RequestID == "" {
+		http.Error(w, "request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	select {
+	case s.ws.Response <- decision:
+		w.WriteHeader(http.StatusAccepted)
+	case <-r.Context().Done():
+	}
+}
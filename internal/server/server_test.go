@@ -0,0 +1,189 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cooperations/internal/tui/stream"
+)
+
+func TestServerStreamsEvents(t *testing.T) {
+	broker := stream.NewBroker()
+	s := New(broker, stream.NewWorkflowStream(), ":0", "")
+
+	rec := httptest.NewServer(http.HandlerFunc(s.handleEvents))
+	defer rec.Close()
+
+	req, err := http.NewRequest(http.MethodGet, rec.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// Give the handler a moment to subscribe before publishing.
+	time.Sleep(50 * time.Millisecond)
+	broker.Publish(context.Background(), "progress", map[string]any{"stage": "Starting"})
+
+	reader := bufio.NewReader(resp.Body)
+	var eventLine, dataLine string
+	for i := 0; i < 10; i++ {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read SSE stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if strings.HasPrefix(line, "event: ") {
+			eventLine = line
+		}
+		if strings.HasPrefix(line, "data: ") {
+			dataLine = line
+			break
+		}
+	}
+
+	if eventLine != "event: progress" {
+		t.Errorf("event line = %q, want %q", eventLine, "event: progress")
+	}
+	if !strings.Contains(dataLine, "Starting") {
+		t.Errorf("data line = %q, want it to contain %q", dataLine, "Starting")
+	}
+}
+
+func TestServerHandleDecision(t *testing.T) {
+	ws := stream.NewWorkflowStream()
+	s := New(stream.NewBroker(), ws, ":0", "")
+
+	srv := httptest.NewServer(http.HandlerFunc(s.handleDecision))
+	defer srv.Close()
+
+	body := strings.NewReader(`{"request_id":"req-1","action":"approve"}`)
+	resp, err := http.Post(srv.URL, "application/json", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+
+	select {
+	case decision := <-ws.Response:
+		if decision.RequestID != "req-1" || decision.Action != stream.DecisionApprove {
+			t.Errorf("decision = %+v, want request_id=req-1 action=approve", decision)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for decision on ws.Response")
+	}
+}
+
+func TestServerHandleDecisionMissingRequestID(t *testing.T) {
+	ws := stream.NewWorkflowStream()
+	s := New(stream.NewBroker(), ws, ":0", "")
+
+	srv := httptest.NewServer(http.HandlerFunc(s.handleDecision))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"action":"approve"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestServerHandleEventsRequiresToken(t *testing.T) {
+	s := New(stream.NewBroker(), stream.NewWorkflowStream(), ":0", "secret")
+
+	rec := httptest.NewServer(http.HandlerFunc(s.handleEvents))
+	defer rec.Close()
+
+	resp, err := http.Get(rec.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("without token: status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rec.URL+"?token=secret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("with correct token: status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestServerHandleDecisionRequiresToken(t *testing.T) {
+	ws := stream.NewWorkflowStream()
+	s := New(stream.NewBroker(), ws, ":0", "secret")
+
+	srv := httptest.NewServer(http.HandlerFunc(s.handleDecision))
+	defer srv.Close()
+
+	body := `{"request_id":"req-1","action":"approve"}`
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("without token: status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Coop-Token", "wrong")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("with wrong token: status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	req, err = http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Coop-Token", "secret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("with correct token: status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	"cooperations/internal/tui/stream"
+)
+
+// wsUpgrader upgrades /ws connections. CheckOrigin allows any origin; the
+// token requirement enforced by handleWS (see Server.authorized) is what
+// actually guards this endpoint, since it's meant to be reachable from a
+// dashboard served from anywhere, not just same-origin pages.
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsMessage is the envelope sent to every /ws client: the same kind+payload
+// shape as an SSE event.
+type wsMessage struct {
+	Kind    string      `json:"kind"`
+	Payload interface{} `json:"payload"`
+}
+
+// handleWS upgrades the connection to a WebSocket and runs it
+// bidirectionally: broker events are pushed to the client as they arrive,
+// and any HumanDecision the client sends back is routed onto ws.Response.
+// Any number of observers may connect; a decision from whichever one
+// answers first is the one the workflow sees, the same way multiple
+// /decision posts would race on the same channel.
+func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	events, unsubscribe := s.broker.Subscribe(eventBuffer, stream.PolicyDropOldest)
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go s.wsReadDecisions(conn, done)
+
+	for {
+		select {
+		case <-done:
+			return
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(wsMessage{Kind: evt.Kind, Payload: evt.Payload}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// wsReadDecisions reads incoming JSON messages from conn, decodes each as a
+// stream.HumanDecision, and forwards well-formed ones onto s.ws.Response.
+// It returns (closing done) when the connection is closed or errors, which
+// tells handleWS's write loop to stop too.
+func (s *Server) wsReadDecisions(conn *websocket.Conn, done chan struct{}) {
+	defer close(done)
+	for {
+		var decision stream.HumanDecision
+		if err := conn.ReadJSON(&decision); err != nil {
+			return
+		}
+		if decision.RequestID == "" {
+			slog.Warn("ignoring decision with empty request_id from websocket client")
+			continue
+		}
+		if s.ws == nil {
+			continue
+		}
+		select {
+		case s.ws.Response <- decision:
+		default:
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"cooperations/internal/tui/stream"
+)
+
+func TestWSDecisionRoundTrip(t *testing.T) {
+	broker := stream.NewBroker()
+	ws := stream.NewWorkflowStream()
+	s := New(broker, ws, ":0", "")
+
+	srv := httptest.NewServer(s.http.Handler)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	// Give the handler a moment to subscribe before publishing an event.
+	time.Sleep(50 * time.Millisecond)
+	broker.Publish(context.Background(), "progress", map[string]any{"stage": "Starting"})
+
+	var msg wsMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("read event over websocket: %v", err)
+	}
+	if msg.Kind != "progress" {
+		t.Errorf("Kind = %q, want %q", msg.Kind, "progress")
+	}
+
+	decision := stream.HumanDecision{RequestID: "req-1", Action: stream.DecisionApprove}
+	if err := conn.WriteJSON(decision); err != nil {
+		t.Fatalf("write decision: %v", err)
+	}
+
+	select {
+	case got := <-ws.Response:
+		if got.RequestID != "req-1" || got.Action != stream.DecisionApprove {
+			t.Errorf("Response = %+v, want %+v", got, decision)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for decision on ws.Response")
+	}
+}
+
+func TestWSRequiresToken(t *testing.T) {
+	broker := stream.NewBroker()
+	ws := stream.NewWorkflowStream()
+	s := New(broker, ws, ":0", "secret")
+
+	srv := httptest.NewServer(s.http.Handler)
+	defer srv.Close()
+
+	base := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+
+	if _, resp, err := websocket.DefaultDialer.Dial(base, nil); err == nil {
+		t.Error("dial without token: expected an error, got none")
+	} else if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("dial without token: status = %v, want %d", resp, http.StatusUnauthorized)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(base+"?token=secret", nil)
+	if err != nil {
+		t.Fatalf("dial with token: %v", err)
+	}
+	conn.Close()
+}
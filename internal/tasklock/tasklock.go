@@ -0,0 +1,94 @@
+// Package tasklock provides advisory file locking, backed by the kernel's
+// flock, so at most one live process attaches read-write to a given task or
+// session id at a time.
+package tasklock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// ErrLocked is returned by Acquire when another live process already holds
+// the lock for id.
+var ErrLocked = errors.New("locked by another process")
+
+// Lock represents a held advisory lock. Release it when done with id. The
+// underlying file is kept open for the lifetime of the Lock, since the
+// kernel flock it holds is what actually enforces exclusivity - closing it
+// (including implicitly, if the process dies) releases the lock.
+type Lock struct {
+	path string
+	file *os.File
+}
+
+// info is the JSON payload written into a lock file, purely for a human (or
+// `coop status`) inspecting the locks directory to see who holds a lock and
+// since when; it plays no part in Acquire's own exclusivity check.
+type info struct {
+	PID      int       `json:"pid"`
+	Acquired time.Time `json:"acquired"`
+}
+
+// Acquire takes an advisory lock on id under dir/locks/<id>.lock. Exclusivity
+// is enforced by an OS-level, non-blocking flock on the file rather than by
+// reading and interpreting its contents, so two processes racing to attach
+// to the same id can't both observe it as free: at most one flock call
+// succeeds. A lock left behind by a process that crashed or was killed is
+// released automatically by the kernel when that process's file descriptor
+// closes, so no separate PID-liveness/staleness check is needed; a lock held
+// by a live process returns ErrLocked.
+func Acquire(dir, id string) (*Lock, error) {
+	locksDir := filepath.Join(dir, "locks")
+	if err := os.MkdirAll(locksDir, 0755); err != nil {
+		return nil, fmt.Errorf("create locks directory: %w", err)
+	}
+	path := filepath.Join(locksDir, id+".lock")
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if err == syscall.EWOULDBLOCK {
+			return nil, ErrLocked
+		}
+		return nil, fmt.Errorf("acquire file lock: %w", err)
+	}
+
+	data, err := json.Marshal(info{PID: os.Getpid(), Acquired: time.Now().UTC()})
+	if err != nil {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+		return nil, err
+	}
+	if err := f.Truncate(0); err != nil {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+		return nil, fmt.Errorf("truncate lock file: %w", err)
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+		return nil, fmt.Errorf("write lock file: %w", err)
+	}
+
+	return &Lock{path: path, file: f}, nil
+}
+
+// Release unlocks and removes the lock file, freeing id for other
+// processes. It is safe to call on a nil Lock.
+func (l *Lock) Release() error {
+	if l == nil {
+		return nil
+	}
+	syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	l.file.Close()
+	return os.Remove(l.path)
+}
@@ -0,0 +1,106 @@
+package tasklock
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestAcquireRelease(t *testing.T) {
+	dir := t.TempDir()
+
+	lock, err := Acquire(dir, "task-1")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if lock == nil {
+		t.Fatal("Acquire() returned nil lock with no error")
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+
+	// Released, so a second Acquire on the same id should succeed.
+	lock2, err := Acquire(dir, "task-1")
+	if err != nil {
+		t.Fatalf("Acquire() after release error = %v", err)
+	}
+	defer lock2.Release()
+}
+
+func TestAcquire_AlreadyLocked(t *testing.T) {
+	dir := t.TempDir()
+
+	lock, err := Acquire(dir, "task-1")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer lock.Release()
+
+	if _, err := Acquire(dir, "task-1"); !errors.Is(err, ErrLocked) {
+		t.Errorf("second Acquire() error = %v, want ErrLocked", err)
+	}
+}
+
+func TestAcquire_DifferentIDsDontConflict(t *testing.T) {
+	dir := t.TempDir()
+
+	lockA, err := Acquire(dir, "task-a")
+	if err != nil {
+		t.Fatalf("Acquire(task-a) error = %v", err)
+	}
+	defer lockA.Release()
+
+	lockB, err := Acquire(dir, "task-b")
+	if err != nil {
+		t.Fatalf("Acquire(task-b) error = %v", err)
+	}
+	defer lockB.Release()
+}
+
+func TestAcquire_NilLockRelease(t *testing.T) {
+	var lock *Lock
+	if err := lock.Release(); err != nil {
+		t.Errorf("Release() on nil lock error = %v, want nil", err)
+	}
+}
+
+// TestAcquire_Concurrent races many goroutines to acquire the same id at
+// once, the exact scenario the flock-based Acquire exists to make safe:
+// exactly one should win and get a usable lock, the rest should observe
+// ErrLocked, and none should silently get a duplicate live lock.
+func TestAcquire_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	const n = 50
+	var wg sync.WaitGroup
+	locks := make([]*Lock, n)
+	errs := make([]error, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			locks[i], errs[i] = Acquire(dir, "task-race")
+		}(i)
+	}
+	wg.Wait()
+
+	var won int
+	for i := 0; i < n; i++ {
+		if errs[i] == nil {
+			won++
+			continue
+		}
+		if !errors.Is(errs[i], ErrLocked) {
+			t.Errorf("goroutine %d: error = %v, want nil or ErrLocked", i, errs[i])
+		}
+	}
+	if won != 1 {
+		t.Errorf("winners = %d, want exactly 1", won)
+	}
+
+	for _, lock := range locks {
+		lock.Release()
+	}
+}
@@ -2,12 +2,17 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"cooperations/internal/tui/session"
 	"cooperations/internal/tui/stream"
+	"cooperations/internal/tui/streamlog"
+	"cooperations/internal/tui/styles"
+	"cooperations/internal/tui/views"
 	"cooperations/internal/tui/widgets"
+	"cooperations/internal/types"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -15,6 +20,14 @@ import (
 // tickMsg is sent periodically for animations.
 type tickMsg time.Time
 
+// bellCmd rings the terminal bell to draw attention to a pending decision,
+// for a director who's scrolled away or in zen mode and might otherwise
+// miss it. Respects --no-bell (see Model.BellEnabled).
+func bellCmd() tea.Msg {
+	fmt.Print("\a")
+	return nil
+}
+
 // streamMsg wraps stream events for the update loop.
 type streamMsg struct {
 	event interface{}
@@ -25,6 +38,13 @@ type sessionSavedMsg struct {
 	Err error
 }
 
+// autosavedMsg reports the result of a periodic background save, kept
+// separate from sessionSavedMsg so it can surface a subtler toast than an
+// explicit ctrl+s save does.
+type autosavedMsg struct {
+	Err error
+}
+
 type sessionLoadedMsg struct {
 	Session *session.Session
 	Err     error
@@ -64,6 +84,12 @@ func listenForStreams(s *stream.WorkflowStream) tea.Cmd {
 			}
 			return streamMsg{event: token}
 
+		case plan, ok := <-s.Plan:
+			if !ok {
+				return nil
+			}
+			return streamMsg{event: plan}
+
 		case progress, ok := <-s.Progress:
 			if !ok {
 				return nil
@@ -106,6 +132,12 @@ func listenForStreams(s *stream.WorkflowStream) tea.Cmd {
 			}
 			return streamMsg{event: metrics}
 
+		case summary, ok := <-s.Summary:
+			if !ok {
+				return nil
+			}
+			return streamMsg{event: summary}
+
 		case thinking, ok := <-s.Thinking:
 			if !ok {
 				return nil
@@ -181,6 +213,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tickMsg:
 		m.Tick()
 		cmds = append(cmds, tickCmd(m.TickInterval))
+		if m.dueForAutosave() {
+			m.LastAutosave = time.Now()
+			cmds = append(cmds, m.autosaveCmd())
+		}
 
 	case streamMsg:
 		cmd := m.handleStreamEvent(msg.event)
@@ -198,15 +234,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.AddLogEntry(widgets.LogInfo, "session", fmt.Sprintf("Saved %s", msg.ID))
 		}
 
+	case autosavedMsg:
+		if msg.Err != nil {
+			m.ShowToast(fmt.Sprintf("Autosave failed: %v", msg.Err), widgets.ToastLevelWarning)
+		} else {
+			m.ShowToast("autosaved", widgets.ToastLevelInfo)
+		}
+
 	case sessionLoadedMsg:
 		if msg.Err != nil {
 			m.ShowToast(fmt.Sprintf("Session load failed: %v", msg.Err), widgets.ToastLevelError)
 			break
 		}
 		if msg.Session != nil {
+			m.releaseTaskLock()
 			m.SessionID = msg.Session.ID
 			m.SessionName = msg.Session.Name
 			m.CurrentTask = msg.Session.Task
+			m.acquireTaskLock(msg.Session.ID)
 			m.ShowToast(fmt.Sprintf("Session loaded (%s)", msg.Session.ID), widgets.ToastLevelSuccess)
 			m.AddLogEntry(widgets.LogInfo, "session", fmt.Sprintf("Loaded %s", msg.Session.ID))
 		}
@@ -235,6 +280,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// quitCmd saves the active session, persists layout preferences, and
+// releases the task lock before returning the command that actually exits
+// the program. If a DecisionRequest is still pending, it's auto-rejected
+// first: otherwise the orchestrator goroutine would block forever on
+// stream.Response, leaving it running (and the stream unclosed) after the
+// TUI itself has exited. The session save happens synchronously, since the
+// program exits immediately after and there's no later Update cycle left
+// to handle a tea.Cmd's result.
+func (m *Model) quitCmd() tea.Cmd {
+	if m.PendingDecision != nil {
+		m.sendDecision(stream.DecisionReject, "quit during pending decision", "")
+	}
+	if m.SessionManager != nil && m.SessionManager.Current != nil && !m.ReplayActive {
+		_ = m.SessionManager.Save()
+	}
+	m.savePrefsOnQuit()
+	m.releaseTaskLock()
+	return tea.Quit
+}
+
 // handleKeyPress handles keyboard input.
 func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	// Handle dialog input first
@@ -247,7 +312,13 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return m.handleSearchInput(msg)
 	}
 
-	// Search navigation (works in dashboard and focus)
+	// Search navigation (works in dashboard and focus). NextResult/PrevResult
+	// both reuse "n"/"N" (also NextStep's and PrevResult's dashboard keys),
+	// which is safe only because this check runs before the dashboard switch
+	// below: with a query active, "n"/"N" always mean "next/prev match" and
+	// NextStep is unreachable until the query is cleared. See
+	// keybindings.go's keyContexts, which models this same precedence so the
+	// startup conflict check doesn't flag it.
 	if (m.ViewMode == ViewModeDashboard || m.ViewMode == ViewModeFocus) && m.SearchQuery != "" {
 		switch {
 		case key.Matches(msg, m.Keys.NextResult):
@@ -272,7 +343,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	// Global keys
 	switch {
 	case key.Matches(msg, m.Keys.ForceQuit):
-		return tea.Quit
+		return m.quitCmd()
 
 	case key.Matches(msg, m.Keys.Quit):
 		if m.WorkflowState == WorkflowRunning {
@@ -280,7 +351,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 			m.ShowConfirm("Quit", "Workflow is still running. Are you sure you want to quit?", true)
 			return nil
 		}
-		return tea.Quit
+		return m.quitCmd()
 
 	case key.Matches(msg, m.Keys.Help):
 		m.ToggleHelp()
@@ -291,6 +362,10 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	case key.Matches(msg, m.Keys.ZenMode):
 		m.ToggleZen()
 
+	case key.Matches(msg, m.Keys.ToggleTheme):
+		name := styles.Cycle()
+		m.ShowToast(fmt.Sprintf("Theme: %s", name), widgets.ToastLevelInfo)
+
 	case key.Matches(msg, m.Keys.Tab):
 		if m.Dashboard != nil {
 			m.Dashboard.ActivePanel = (m.Dashboard.ActivePanel + 1) % 3
@@ -372,6 +447,12 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	case key.Matches(msg, m.Keys.Bottom):
 		m.scrollToBottom()
 
+	case key.Matches(msg, m.Keys.HScrollLeft):
+		m.scrollLeft(4)
+
+	case key.Matches(msg, m.Keys.HScrollRight):
+		m.scrollRight(4)
+
 	case key.Matches(msg, m.Keys.Pause):
 		if m.WorkflowState == WorkflowRunning {
 			m.SetWorkflowState(WorkflowPaused)
@@ -451,6 +532,10 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 			m.ShowToast("Session manager unavailable", widgets.ToastLevelWarning)
 			return nil
 		}
+		if m.ReadOnly {
+			m.ShowToast("Session is read-only (busy in another process)", widgets.ToastLevelWarning)
+			return nil
+		}
 		if m.CurrentTask != "" {
 			m.ensureSession(m.CurrentTask)
 		}
@@ -461,13 +546,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 			m.ShowToast("Session manager unavailable", widgets.ToastLevelWarning)
 			return nil
 		}
-		dialog := widgets.NewInputDialog("Open session", "Enter session ID", m.Width/2)
-		dialog.Placeholder = "session_..."
-		m.InputDialog = &dialog
-		m.DecisionDialog = nil
-		m.ConfirmDialog = nil
-		m.InputMode = InputModeOpenSession
-		m.ShowDialog = true
+		m.reopenSessionPicker()
 
 	case key.Matches(msg, m.Keys.Replay):
 		if m.SessionManager == nil {
@@ -484,10 +563,28 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		m.ShowToast(fmt.Sprintf("Replaying %s", m.SessionManager.Current.ID), widgets.ToastLevelInfo)
 		return m.replaySessionCmd()
 
+	case key.Matches(msg, m.Keys.Checkpoint):
+		m.createCheckpoint()
+
 	case key.Matches(msg, m.Keys.Open):
 		if m.Dashboard != nil && m.Dashboard.ActivePanel == 2 && m.Dashboard.RightMode == 1 {
 			m.Dashboard.FileTree.Toggle()
 		}
+
+	case key.Matches(msg, m.Keys.Confirm):
+		if m.Dashboard != nil && m.Dashboard.ActivePanel == 2 && m.Dashboard.RightMode == 1 {
+			node := m.Dashboard.FileTree.GetSelectedNode()
+			if node == nil {
+				m.ShowToast("No file selected", widgets.ToastLevelWarning)
+				return nil
+			}
+			if node.IsDir {
+				m.Dashboard.FileTree.Toggle()
+				return nil
+			}
+			m.PreviewFile(node.Path)
+		}
+
 	case key.Matches(msg, m.Keys.CopyPath):
 		if m.Dashboard != nil && m.Dashboard.ActivePanel == 2 && m.Dashboard.RightMode == 1 {
 			path := m.Dashboard.FileTree.GetSelected()
@@ -518,30 +615,157 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 			}
 		}
 
+	case key.Matches(msg, m.Keys.CopyContent):
+		m.copyActivePanelContent()
+
 	case key.Matches(msg, m.Keys.Refresh):
 		m.RefreshFileTree()
+
+	case key.Matches(msg, m.Keys.FilterCycle):
+		if m.Dashboard != nil && m.Dashboard.ActivePanel == 2 && m.Dashboard.RightMode == 1 {
+			m.Dashboard.FileTree.CycleFilter()
+			m.ShowToast("File filter: "+m.Dashboard.FileTree.FilterModeLabel(), widgets.ToastLevelInfo)
+		}
+
+	case key.Matches(msg, m.Keys.CollapseAll):
+		if m.Dashboard != nil && m.Dashboard.ActivePanel == 2 && m.Dashboard.RightMode == 1 {
+			m.Dashboard.FileTree.CollapseAll()
+		}
+
+	case key.Matches(msg, m.Keys.ExpandAll):
+		if m.Dashboard != nil && m.Dashboard.ActivePanel == 2 && m.Dashboard.RightMode == 1 {
+			m.Dashboard.FileTree.ExpandAll()
+		}
+
+	case key.Matches(msg, m.Keys.LogLevelCycle):
+		if m.Dashboard != nil && m.Dashboard.ActivePanel == 2 && m.Dashboard.RightMode == 0 {
+			m.Dashboard.ActivityLog.CycleMinLevel()
+		}
+
+	case key.Matches(msg, m.Keys.LogAgentCycle):
+		if m.Dashboard != nil && m.Dashboard.ActivePanel == 2 && m.Dashboard.RightMode == 0 {
+			role := m.Dashboard.ActivityLog.CycleAgentFilter()
+			if role == "" {
+				m.ShowToast("Activity log: showing all agents", widgets.ToastLevelInfo)
+			} else {
+				m.ShowToast("Activity log: showing "+role, widgets.ToastLevelInfo)
+			}
+		}
 	}
 
 	return nil
 }
 
-// handleDialogInput handles input when a dialog is open.
+// handleDialogInput handles input when a dialog is open. ForceQuit is
+// checked before any dialog-specific handling: without it, quitting while
+// a DecisionRequest is pending would leave the orchestrator goroutine
+// blocked forever on stream.Response, since nothing else ever answers it.
 func (m *Model) handleDialogInput(msg tea.KeyMsg) tea.Cmd {
+	if key.Matches(msg, m.Keys.ForceQuit) {
+		return m.quitCmd()
+	}
+	if key.Matches(msg, m.Keys.Quit) && m.PendingDecision != nil {
+		m.ShowToast("A decision is pending - resolve it, or press Ctrl+C to force quit", widgets.ToastLevelWarning)
+		return nil
+	}
 	if m.InputDialog != nil {
 		return m.handleInputDialog(msg)
 	}
+	if m.SessionPicker != nil {
+		return m.handleSessionPicker(msg)
+	}
 	if m.DecisionDialog != nil {
 		return m.handleDecisionDialog(msg)
 	}
 	if m.ConfirmDialog != nil {
 		return m.handleConfirmDialog(msg)
 	}
+	if m.SummaryDialog != nil {
+		return m.handleSummaryDialog(msg)
+	}
 	if key.Matches(msg, m.Keys.Cancel) {
 		m.HideDialog()
 	}
 	return nil
 }
 
+// handleSummaryDialog handles input for the end-of-run completion overlay:
+// 'f' jumps to the dashboard's file tree panel, 'c' copies the summary
+// text, and cancel/confirm both dismiss it.
+func (m *Model) handleSummaryDialog(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.Keys.Cancel), key.Matches(msg, m.Keys.Confirm):
+		m.HideDialog()
+	case msg.String() == "f":
+		m.HideDialog()
+		m.ViewMode = ViewModeDashboard
+		if m.Dashboard != nil {
+			m.Dashboard.ActivePanel = 2 // right panel
+			m.Dashboard.RightMode = 1   // files
+		}
+	case msg.String() == "c":
+		if err := copyToClipboard(m.SummaryDialog.PlainText()); err != nil {
+			m.ShowToast("Copy failed: "+err.Error(), widgets.ToastLevelWarning)
+		} else {
+			m.ShowToast("Summary copied to clipboard", widgets.ToastLevelSuccess)
+		}
+	}
+	return nil
+}
+
+// handleSessionPicker handles input for the session picker dialog opened by
+// OpenSession: arrow keys move the selection, confirming loads the selected
+// session the same way the old free-text prompt did, 'd' asks to delete it,
+// and 'r' renames it in place.
+func (m *Model) handleSessionPicker(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.Keys.Cancel):
+		m.HideDialog()
+
+	case key.Matches(msg, m.Keys.Up):
+		m.SessionPicker.MoveUp()
+
+	case key.Matches(msg, m.Keys.Down):
+		m.SessionPicker.MoveDown()
+
+	case key.Matches(msg, m.Keys.Confirm):
+		item := m.SessionPicker.GetSelected()
+		m.HideDialog()
+		if item == nil {
+			m.ShowToast("No sessions to open", widgets.ToastLevelWarning)
+			return nil
+		}
+		return m.loadSessionCmd(item.ID)
+
+	case msg.String() == "d":
+		item := m.SessionPicker.GetSelected()
+		if item == nil {
+			return nil
+		}
+		m.SessionPickerTarget = item.ID
+		m.SessionPicker = nil
+		confirm := widgets.NewConfirmDialog("Delete session",
+			fmt.Sprintf("Delete session %q? It will be moved to trash.", item.Task), m.Width/2)
+		confirm.Danger = true
+		m.ConfirmDialog = &confirm
+		m.PendingAction = "delete-session"
+
+	case msg.String() == "r":
+		item := m.SessionPicker.GetSelected()
+		if item == nil {
+			return nil
+		}
+		m.SessionPickerTarget = item.ID
+		m.SessionPicker = nil
+		input := widgets.NewInputDialog("Rename session", "New name", m.Width/2)
+		input.Value = item.Task
+		input.CursorPos = len(input.Value)
+		m.InputDialog = &input
+		m.InputMode = InputModeRenameSession
+	}
+	return nil
+}
+
 func (m *Model) handleDecisionDialog(msg tea.KeyMsg) tea.Cmd {
 	switch {
 	case key.Matches(msg, m.Keys.Cancel):
@@ -582,29 +806,40 @@ func (m *Model) handleDecisionDialog(msg tea.KeyMsg) tea.Cmd {
 func (m *Model) handleConfirmDialog(msg tea.KeyMsg) tea.Cmd {
 	switch {
 	case key.Matches(msg, m.Keys.Cancel):
-		m.PendingAction = ""
-		m.HideDialog()
+		m.cancelConfirmDialog()
 	case msg.String() == "y":
 		m.ConfirmDialog.Selected = 1
 		return m.executeConfirmedAction()
 	case msg.String() == "n":
-		m.PendingAction = ""
-		m.HideDialog()
+		m.cancelConfirmDialog()
 	case key.Matches(msg, m.Keys.Left), key.Matches(msg, m.Keys.Right), key.Matches(msg, m.Keys.Tab):
 		m.ConfirmDialog.Toggle()
 	case key.Matches(msg, m.Keys.Confirm):
 		if m.ConfirmDialog.IsYes() {
 			return m.executeConfirmedAction()
 		}
-		m.PendingAction = ""
-		m.HideDialog()
+		m.cancelConfirmDialog()
 	}
 	return nil
 }
 
+// cancelConfirmDialog dismisses a pending confirm dialog. A delete-session
+// confirm, opened from the session picker, returns to the (unmodified)
+// picker instead of closing the whole overlay.
+func (m *Model) cancelConfirmDialog() {
+	action := m.PendingAction
+	m.PendingAction = ""
+	if action == "delete-session" {
+		m.reopenSessionPicker()
+		return
+	}
+	m.HideDialog()
+}
+
 // executeConfirmedAction handles the action after confirm dialog is accepted.
 func (m *Model) executeConfirmedAction() tea.Cmd {
 	action := m.PendingAction
+	target := m.SessionPickerTarget
 	m.PendingAction = ""
 	m.HideDialog()
 
@@ -624,9 +859,21 @@ func (m *Model) executeConfirmedAction() tea.Cmd {
 		m.ShowToast("Workflow killed", widgets.ToastLevelError)
 		return nil
 
+	case "delete-session":
+		if m.SessionManager == nil || target == "" {
+			return nil
+		}
+		if err := m.SessionManager.Delete(target); err != nil {
+			m.ShowToast("Delete failed: "+err.Error(), widgets.ToastLevelWarning)
+		} else {
+			m.ShowToast("Session deleted", widgets.ToastLevelSuccess)
+		}
+		m.reopenSessionPicker()
+		return nil
+
 	case "quit", "":
 		// Default behavior - quit
-		return tea.Quit
+		return m.quitCmd()
 	}
 
 	return nil
@@ -635,20 +882,26 @@ func (m *Model) executeConfirmedAction() tea.Cmd {
 func (m *Model) handleInputDialog(msg tea.KeyMsg) tea.Cmd {
 	switch msg.Type {
 	case tea.KeyEsc:
-		if m.InputMode == InputModeOpenSession {
-			m.HideDialog()
+		if m.InputMode == InputModeRenameSession {
+			m.reopenSessionPicker()
 			return nil
 		}
 		m.sendDecision(stream.DecisionReject, "edit cancelled", "")
 	case tea.KeyEnter:
-		if m.InputMode == InputModeOpenSession {
-			sessionID := strings.TrimSpace(m.InputDialog.Value)
-			if sessionID == "" {
-				m.ShowToast("Session ID required", widgets.ToastLevelWarning)
+		if m.InputMode == InputModeRenameSession {
+			name := strings.TrimSpace(m.InputDialog.Value)
+			target := m.SessionPickerTarget
+			if name == "" || m.SessionManager == nil || target == "" {
+				m.reopenSessionPicker()
 				return nil
 			}
-			m.HideDialog()
-			return m.loadSessionCmd(sessionID)
+			if err := m.SessionManager.Rename(target, name); err != nil {
+				m.ShowToast("Rename failed: "+err.Error(), widgets.ToastLevelWarning)
+			} else {
+				m.ShowToast("Session renamed", widgets.ToastLevelSuccess)
+			}
+			m.reopenSessionPicker()
+			return nil
 		}
 		action := m.PendingDecisionAction
 		if action == "" {
@@ -700,6 +953,7 @@ func (m *Model) sendDecision(action stream.DecisionAction, comment, edited strin
 	}
 	m.PendingDecision = nil
 	m.PendingDecisionAction = ""
+	m.PendingDecisionDefault = ""
 	m.HideDialog()
 }
 
@@ -729,6 +983,10 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) tea.Cmd {
 		} else if m.SearchQuery != "" {
 			m.ShowToast("No matches", widgets.ToastLevelWarning)
 		}
+	case tea.KeyCtrlR:
+		m.SearchRegex = !m.SearchRegex
+	case tea.KeyCtrlT:
+		m.SearchCaseSensitive = !m.SearchCaseSensitive
 	case tea.KeyBackspace:
 		if len(m.SearchQuery) > 0 {
 			m.SearchQuery = m.SearchQuery[:len(m.SearchQuery)-1]
@@ -741,10 +999,43 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
-// handleHelpKeys handles keys in help view.
+// handleHelpKeys handles keys in help view. Pressing "/" (mirroring the
+// dashboard's search binding) starts a live text filter over the displayed
+// bindings; while typing it, every other binding is suspended so letters
+// like "j"/"k" go into the filter instead of scrolling.
 func (m *Model) handleHelpKeys(msg tea.KeyMsg) tea.Cmd {
+	if m.Help != nil && m.Help.Filtering {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.Help.Filtering = false
+			m.Help.SetQuery("")
+		case tea.KeyEnter:
+			m.Help.Filtering = false
+		case tea.KeyBackspace:
+			q := m.Help.Query
+			if len(q) > 0 {
+				m.Help.SetQuery(q[:len(q)-1])
+			}
+		case tea.KeyRunes:
+			m.Help.SetQuery(m.Help.Query + string(msg.Runes))
+		}
+		return nil
+	}
+
 	switch {
-	case key.Matches(msg, m.Keys.Cancel), key.Matches(msg, m.Keys.Help):
+	case key.Matches(msg, m.Keys.Search):
+		if m.Help != nil {
+			m.Help.Filtering = true
+		}
+
+	case key.Matches(msg, m.Keys.Cancel):
+		if m.Help != nil && m.Help.Query != "" {
+			m.Help.SetQuery("")
+			return nil
+		}
+		m.ViewMode = m.PreviousMode
+
+	case key.Matches(msg, m.Keys.Help):
 		m.ViewMode = m.PreviousMode
 
 	case key.Matches(msg, m.Keys.Up):
@@ -758,7 +1049,7 @@ func (m *Model) handleHelpKeys(msg tea.KeyMsg) tea.Cmd {
 		}
 
 	case key.Matches(msg, m.Keys.ForceQuit):
-		return tea.Quit
+		return m.quitCmd()
 	}
 
 	return nil
@@ -768,30 +1059,65 @@ func (m *Model) handleHelpKeys(msg tea.KeyMsg) tea.Cmd {
 func (m *Model) handleFocusKeys(msg tea.KeyMsg) tea.Cmd {
 	switch {
 	case key.Matches(msg, m.Keys.Cancel):
+		if m.Focus != nil && m.Focus.Selecting {
+			m.Focus.ClearSelection()
+			m.Focus.CodeBlock.ClearHighlights()
+			break
+		}
 		m.ViewMode = ViewModeDashboard
 
 	case key.Matches(msg, m.Keys.ToggleCenter):
 		if m.Focus != nil {
 			m.Focus.Mode = (m.Focus.Mode + 1) % 4
+			if m.Focus.Mode != views.FocusModeCode {
+				m.Focus.ClearSelection()
+				m.Focus.CodeBlock.ClearHighlights()
+			}
 		}
 
 	case key.Matches(msg, m.Keys.DiffView):
 		if m.Focus != nil {
 			m.Focus.Mode = 2 // Diff mode
+			m.Focus.ClearSelection()
+			m.Focus.CodeBlock.ClearHighlights()
 		}
 
 	case key.Matches(msg, m.Keys.Search):
 		m.SearchMode = true
 		m.SearchQuery = ""
 
+	case key.Matches(msg, m.Keys.VisualSelect):
+		m.toggleFocusSelection()
+
+	case key.Matches(msg, m.Keys.CopyContent):
+		if m.Focus != nil && m.Focus.Selecting {
+			m.copyFocusSelection()
+		} else {
+			m.copyActivePanelContent()
+		}
+
 	case key.Matches(msg, m.Keys.Up):
-		m.scrollFocusUp(1)
+		if m.Focus != nil && m.Focus.Selecting {
+			m.extendFocusSelection(-1)
+		} else {
+			m.scrollFocusUp(1)
+		}
 
 	case key.Matches(msg, m.Keys.Down):
-		m.scrollFocusDown(1)
+		if m.Focus != nil && m.Focus.Selecting {
+			m.extendFocusSelection(1)
+		} else {
+			m.scrollFocusDown(1)
+		}
+
+	case key.Matches(msg, m.Keys.HScrollLeft):
+		m.scrollFocusLeft(4)
+
+	case key.Matches(msg, m.Keys.HScrollRight):
+		m.scrollFocusRight(4)
 
 	case key.Matches(msg, m.Keys.ForceQuit):
-		return tea.Quit
+		return m.quitCmd()
 
 	case key.Matches(msg, m.Keys.Quit):
 		m.ViewMode = ViewModeDashboard
@@ -807,7 +1133,7 @@ func (m *Model) handleZenKeys(msg tea.KeyMsg) tea.Cmd {
 		m.ViewMode = ViewModeDashboard
 
 	case key.Matches(msg, m.Keys.ForceQuit):
-		return tea.Quit
+		return m.quitCmd()
 	}
 
 	return nil
@@ -981,6 +1307,60 @@ func (m *Model) scrollFocusDown(lines int) {
 	}
 }
 
+// scrollLeft shifts the dashboard's center code/diff view left. Only the
+// code and diff center modes support horizontal scrolling; other modes and
+// panels ignore it.
+func (m *Model) scrollLeft(cols int) {
+	if m.Dashboard == nil || m.Dashboard.ActivePanel != 1 {
+		return
+	}
+	switch m.Dashboard.CenterMode {
+	case 1:
+		m.Dashboard.CodeBlock.ScrollLeft(cols)
+	case 2:
+		m.Dashboard.DiffBlock.ScrollLeft(cols)
+	}
+}
+
+// scrollRight shifts the dashboard's center code/diff view right.
+func (m *Model) scrollRight(cols int) {
+	if m.Dashboard == nil || m.Dashboard.ActivePanel != 1 {
+		return
+	}
+	switch m.Dashboard.CenterMode {
+	case 1:
+		m.Dashboard.CodeBlock.ScrollRight(cols)
+	case 2:
+		m.Dashboard.DiffBlock.ScrollRight(cols)
+	}
+}
+
+// scrollFocusLeft shifts the focus view's code/diff mode left.
+func (m *Model) scrollFocusLeft(cols int) {
+	if m.Focus == nil {
+		return
+	}
+	switch m.Focus.Mode {
+	case 1:
+		m.Focus.CodeBlock.ScrollLeft(cols)
+	case 2:
+		m.Focus.DiffBlock.ScrollLeft(cols)
+	}
+}
+
+// scrollFocusRight shifts the focus view's code/diff mode right.
+func (m *Model) scrollFocusRight(cols int) {
+	if m.Focus == nil {
+		return
+	}
+	switch m.Focus.Mode {
+	case 1:
+		m.Focus.CodeBlock.ScrollRight(cols)
+	case 2:
+		m.Focus.DiffBlock.ScrollRight(cols)
+	}
+}
+
 func (m *Model) stepKeyForName(name string) (key, label string, isRole bool) {
 	trimmed := strings.TrimSpace(name)
 	if trimmed == "" {
@@ -1032,6 +1412,35 @@ func (m *Model) ensureWorkflowStep(key, name, description, agent string) int {
 	return idx
 }
 
+// showPlannedSteps pre-populates the workflow steps panel with a run's
+// previewed role sequence as pending steps, ahead of the first agent
+// actually starting. Each planned step uses the same key stepKeyForName
+// would derive from that role's own progress events, so when the real run
+// reaches that role it updates this slot in place instead of adding a
+// duplicate.
+func (m *Model) showPlannedSteps(plan types.WorkflowPlan) {
+	if m.Dashboard == nil || m.Dashboard.WorkflowSteps == nil || len(plan.Steps) == 0 {
+		return
+	}
+
+	for _, step := range plan.Steps {
+		key, label, _ := m.stepKeyForName(string(step.Role))
+		if key == "" {
+			continue
+		}
+		if m.WorkflowStepIndex != nil {
+			if _, exists := m.WorkflowStepIndex[key]; exists {
+				continue
+			}
+		}
+		description := fmt.Sprintf("~%d tokens", step.EstimatedTokens)
+		if step.EstimatedMS > 0 {
+			description = fmt.Sprintf("%s, ~%s", description, time.Duration(step.EstimatedMS)*time.Millisecond)
+		}
+		m.ensureWorkflowStep(key, label, description, "")
+	}
+}
+
 func (m *Model) updateWorkflowFromProgress(update stream.ProgressUpdate) {
 	if m.Dashboard == nil || m.Dashboard.WorkflowSteps == nil {
 		return
@@ -1099,10 +1508,11 @@ func (m *Model) updateWorkflowFromHandoff(event stream.HandoffEvent) {
 			key = "role:" + strings.ToLower(event.To)
 			label = titleCase(event.To)
 		}
-		idx := m.ensureWorkflowStep(key, label, event.Reason, label)
+		reason := event.Reason.String()
+		idx := m.ensureWorkflowStep(key, label, reason, label)
 		if idx >= 0 {
-			if event.Reason != "" {
-				m.Dashboard.WorkflowSteps.Steps[idx].Description = event.Reason
+			if reason != "" {
+				m.Dashboard.WorkflowSteps.Steps[idx].Description = reason
 			}
 			m.Dashboard.WorkflowSteps.SetStatus(idx, widgets.StepRunning)
 		}
@@ -1125,6 +1535,9 @@ func extractTaskFromProgress(message string) string {
 
 // handleStreamEvent processes events from the workflow stream.
 func (m *Model) handleStreamEvent(event interface{}) tea.Cmd {
+	m.LastEventAt = time.Now()
+	m.Stalled = false
+
 	if progress, ok := event.(stream.ProgressUpdate); ok {
 		if task := extractTaskFromProgress(progress.Message); task != "" {
 			m.CurrentTask = task
@@ -1155,6 +1568,9 @@ func (m *Model) handleStreamEvent(event interface{}) tea.Cmd {
 			}
 		}
 
+	case stream.PlanUpdate:
+		m.showPlannedSteps(e.Plan)
+
 	case stream.ProgressUpdate:
 		m.UpdateProgress(e.Percent, e.Message)
 		m.SetWorkflowState(WorkflowRunning)
@@ -1168,11 +1584,11 @@ func (m *Model) handleStreamEvent(event interface{}) tea.Cmd {
 			}
 			// Set new agent as active
 			if e.To != "" {
-				m.SetCurrentAgent(e.To, e.Reason)
+				m.SetCurrentAgent(e.To, e.Reason.String())
 			}
 		}
 		m.updateWorkflowFromHandoff(e)
-		m.AddLogEntry(widgets.LogInfo, e.From, fmt.Sprintf("Handoff to %s: %s", e.To, e.Reason))
+		m.AddLogEntry(widgets.LogInfo, e.From, fmt.Sprintf("Handoff to %s: %s", e.To, e.Reason.String()))
 
 	case stream.CodeUpdate:
 		m.SetCodeContent(e.Content, e.Language, e.Path)
@@ -1204,6 +1620,13 @@ func (m *Model) handleStreamEvent(event interface{}) tea.Cmd {
 			if m.Dashboard != nil {
 				m.Dashboard.FileTree.RemoveFile(e.Path)
 			}
+		case "scope":
+			// A file named in scope before it's actually written (e.g. by
+			// the architect, ahead of the implementer running) - show it
+			// in the tree without marking it added or modified yet.
+			if m.Dashboard != nil {
+				m.Dashboard.FileTree.AddPath(e.Path, widgets.FileStatusNone, e.IsDir)
+			}
 		default:
 			status := widgets.FileStatusModified
 			if strings.ToLower(e.Action) == "add" {
@@ -1232,6 +1655,9 @@ func (m *Model) handleStreamEvent(event interface{}) tea.Cmd {
 	case stream.MetricsSnapshot:
 		m.UpdateMetricsSnapshot(e)
 
+	case stream.WorkflowSummary:
+		m.UpdateWorkflowSummary(e)
+
 	case stream.ThinkingUpdate:
 		if m.Dashboard != nil {
 			m.Dashboard.AgentPanel.SetStatus(e.AgentRole, widgets.AgentThinking, e.Stage)
@@ -1256,14 +1682,37 @@ func (m *Model) handleStreamEvent(event interface{}) tea.Cmd {
 		reqCopy := e
 		m.PendingDecision = &reqCopy
 		m.PendingDecisionAction = ""
+		m.PendingDecisionDefault = e.DefaultAction
+		if m.PendingDecisionDefault == "" {
+			m.PendingDecisionDefault = stream.DecisionApprove
+		}
 		var options []widgets.DecisionOption
 		for i, opt := range e.Options {
+			key := opt.Key
+			if key == "" {
+				key = fmt.Sprintf("%d", i+1)
+			}
 			options = append(options, widgets.DecisionOption{
-				Key:   fmt.Sprintf("%d", i+1),
-				Label: opt,
+				Key:         key,
+				Label:       opt.Label,
+				Description: opt.Description,
+				Danger:      opt.Danger,
 			})
 		}
 		m.ShowDecision(e.Title, e.Prompt, options)
+		if e.Timeout > 0 && m.DecisionDialog != nil {
+			m.DecisionDialog.Deadline = time.Now().Add(e.Timeout)
+			m.DecisionDialog.DefaultLabel = string(m.PendingDecisionDefault)
+		}
+		if m.ViewMode == ViewModeZen {
+			// Zen mode hides everything but the streaming text, so a
+			// waiting decision could sit unnoticed indefinitely - drop
+			// back to the dashboard where the dialog is actually visible.
+			m.ViewMode = ViewModeDashboard
+		}
+		if m.BellEnabled {
+			return bellCmd
+		}
 
 	case stream.SessionEvent:
 		m.AddLogEntry(widgets.LogInfo, "session", fmt.Sprintf("%s (%s)", e.Type, e.SessionID))
@@ -1348,16 +1797,39 @@ func (m Model) View() string {
 		switch {
 		case m.InputDialog != nil:
 			content += "\n" + m.InputDialog.View()
+		case m.SessionPicker != nil:
+			content += "\n" + m.SessionPicker.View()
 		case m.DecisionDialog != nil:
 			content += "\n" + m.DecisionDialog.View()
 		case m.ConfirmDialog != nil:
 			content += "\n" + m.ConfirmDialog.View()
+		case m.SummaryDialog != nil:
+			content += "\n" + m.SummaryDialog.View()
 		}
 	}
 
+	// Persistent reminder that a decision is waiting on the human, in case
+	// the dialog itself scrolled out of view or the view mode changed
+	// underneath it.
+	if m.PendingDecision != nil {
+		content += "\n" + styles.ToastWarning.Render(" ⚠ Waiting for your decision: "+m.PendingDecision.Title+" ")
+	}
+
+	// Heartbeat badge: no stream event for StallThreshold while running.
+	if m.Stalled {
+		content += "\n" + styles.ToastWarning.Render(fmt.Sprintf(" ⚠ Possibly stalled: no events for %s ", time.Since(m.LastEventAt).Round(time.Second)))
+	}
+
 	// Search bar if active
 	if m.SearchMode {
-		searchBar := fmt.Sprintf("/%s█", m.SearchQuery)
+		var modeFlags string
+		if m.SearchRegex {
+			modeFlags += "[.*]"
+		}
+		if m.SearchCaseSensitive {
+			modeFlags += "[Aa]"
+		}
+		searchBar := fmt.Sprintf("/%s█ %s (ctrl+r regex, ctrl+t case-sensitive)", m.SearchQuery, modeFlags)
 		content += "\n" + searchBar
 	}
 
@@ -1378,6 +1850,64 @@ func (m *Model) saveSessionCmd() tea.Cmd {
 	}
 }
 
+// sessionPickerItems converts saved sessions into picker rows, sorted by
+// most recently updated first so the sessions a user is most likely to want
+// are already at the top.
+func sessionPickerItems(sessions []session.Session) []widgets.SessionPickerItem {
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+
+	items := make([]widgets.SessionPickerItem, len(sessions))
+	for i, s := range sessions {
+		items[i] = widgets.SessionPickerItem{
+			ID:          s.ID,
+			Task:        s.Task,
+			Status:      s.Status,
+			UpdatedAt:   s.UpdatedAt,
+			TotalTokens: s.Metrics.TotalTokens,
+		}
+	}
+	return items
+}
+
+// reopenSessionPicker rebuilds and shows the session picker from the
+// current on-disk session list, used to refresh it after a delete or
+// rename so the picker never shows stale rows.
+func (m *Model) reopenSessionPicker() {
+	m.SessionPickerTarget = ""
+	m.InputDialog = nil
+	m.ConfirmDialog = nil
+	m.PendingAction = ""
+	m.InputMode = InputModeNone
+
+	if m.SessionManager == nil {
+		m.HideDialog()
+		return
+	}
+	sessions, err := m.SessionManager.List()
+	if err != nil {
+		m.ShowToast("Failed to list sessions: "+err.Error(), widgets.ToastLevelWarning)
+		m.HideDialog()
+		return
+	}
+	picker := widgets.NewSessionPickerDialog("Open session", sessionPickerItems(sessions), m.Width/2)
+	m.SessionPicker = &picker
+	m.ShowDialog = true
+}
+
+// autosaveCmd is the periodic counterpart to saveSessionCmd: same
+// underlying Manager.Save call, but reported back as autosavedMsg so it can
+// surface a subtler toast than an explicit ctrl+s save.
+func (m *Model) autosaveCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.SessionManager == nil || m.SessionManager.Current == nil {
+			return autosavedMsg{Err: fmt.Errorf("no active session")}
+		}
+		return autosavedMsg{Err: m.SessionManager.Save()}
+	}
+}
+
 func (m *Model) loadSessionCmd(sessionID string) tea.Cmd {
 	return func() tea.Msg {
 		if m.SessionManager == nil {
@@ -1410,7 +1940,56 @@ func Run(workflowStream *stream.WorkflowStream) error {
 
 // RunWithTask starts the TUI application with an initial task label.
 func RunWithTask(workflowStream *stream.WorkflowStream, task string) error {
+	return RunWithTaskAndStreamLog(workflowStream, task, "")
+}
+
+// RunWithTaskAndStreamLog starts the TUI application with an initial task
+// label, additionally recording every stream event as NDJSON at
+// streamLogPath if it is non-empty.
+func RunWithTaskAndStreamLog(workflowStream *stream.WorkflowStream, task, streamLogPath string) error {
+	return RunWithOptions(workflowStream, task, streamLogPath, true)
+}
+
+// RunWithOptions starts the TUI application with full control over the
+// stream log path and whether dashboard layout preferences are persisted on
+// quit (see --no-save-prefs).
+func RunWithOptions(workflowStream *stream.WorkflowStream, task, streamLogPath string, savePrefs bool) error {
+	return RunWithThemeOptions(workflowStream, task, streamLogPath, savePrefs, "")
+}
+
+// RunWithThemeOptions starts the TUI application with full control over the
+// stream log path, preference persistence, and an optional theme override
+// (see --theme) that takes priority over the theme saved in preferences.
+func RunWithThemeOptions(workflowStream *stream.WorkflowStream, task, streamLogPath string, savePrefs bool, theme string) error {
+	return RunWithBellOption(workflowStream, task, streamLogPath, savePrefs, theme, true)
+}
+
+// RunWithBellOption is RunWithThemeOptions plus control over whether a
+// pending decision rings the terminal bell (see --no-bell).
+func RunWithBellOption(workflowStream *stream.WorkflowStream, task, streamLogPath string, savePrefs bool, theme string, bellEnabled bool) error {
+	return RunWithStallTimeout(workflowStream, task, streamLogPath, savePrefs, theme, bellEnabled, DefaultStallThreshold)
+}
+
+// RunWithStallTimeout is RunWithBellOption plus control over how long the
+// workflow can run with no stream event before the dashboard flags it as
+// possibly stalled (see --stall-timeout). A non-positive stallThreshold
+// disables the check.
+func RunWithStallTimeout(workflowStream *stream.WorkflowStream, task, streamLogPath string, savePrefs bool, theme string, bellEnabled bool, stallThreshold time.Duration) error {
 	model := NewModelWithTask(workflowStream, task)
+	model.SavePrefs = savePrefs
+	model.ThemeOverride = theme
+	model.BellEnabled = bellEnabled
+	model.StallThreshold = stallThreshold
+
+	if streamLogPath != "" {
+		logger, err := streamlog.New(streamLogPath)
+		if err != nil {
+			return fmt.Errorf("start stream log: %w", err)
+		}
+		defer logger.Close()
+		model.StreamLog = logger
+	}
+
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	_, err := p.Run()
 	return err
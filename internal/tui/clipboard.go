@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// copyToClipboard copies text to the system clipboard by shelling out to a
+// platform clipboard utility (there's no clipboard library dependency in
+// this repo).
+func copyToClipboard(text string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("pbcopy")
+	case "windows":
+		cmd = exec.Command("clip")
+	default:
+		switch {
+		case commandExists("wl-copy"):
+			cmd = exec.Command("wl-copy")
+		case commandExists("xclip"):
+			cmd = exec.Command("xclip", "-selection", "clipboard")
+		case commandExists("xsel"):
+			cmd = exec.Command("xsel", "--clipboard", "--input")
+		default:
+			return errors.New("no clipboard utility found (install xclip, xsel, or wl-clipboard)")
+		}
+	}
+	cmd.Stdin = bytes.NewBufferString(text)
+	return cmd.Run()
+}
+
+// commandExists reports whether name is available on PATH.
+func commandExists(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// openInEditor opens path in the user's $EDITOR (or $VISUAL), falling back
+// to vi if neither is set.
+func openInEditor(path string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	cmd := exec.Command(editor, path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"cooperations/internal/tui/stream"
+	"cooperations/internal/types"
 )
 
 // Run runs a simulated workflow for demo purposes.
@@ -21,7 +22,7 @@ func Run(s *stream.WorkflowStream) {
 	s.SendHandoff(stream.HandoffEvent{
 		From:   "",
 		To:     "architect",
-		Reason: "Analyzing task requirements",
+		Reason: types.HandoffReason{Detail: "Analyzing task requirements"},
 	})
 	time.Sleep(300 * time.Millisecond)
 
@@ -107,7 +108,7 @@ func Run(s *stream.WorkflowStream) {
 	s.SendHandoff(stream.HandoffEvent{
 		From:   "architect",
 		To:     "implementer",
-		Reason: "Design approved, starting implementation",
+		Reason: types.HandoffReason{Detail: "Design approved, starting implementation"},
 	})
 	time.Sleep(300 * time.Millisecond)
 
@@ -220,7 +221,7 @@ func main() {
 	s.SendHandoff(stream.HandoffEvent{
 		From:   "implementer",
 		To:     "reviewer",
-		Reason: "Implementation complete, starting review",
+		Reason: types.HandoffReason{Detail: "Implementation complete, starting review"},
 	})
 	time.Sleep(300 * time.Millisecond)
 
@@ -341,7 +342,7 @@ func RunFast(s *stream.WorkflowStream) {
 	for i, agent := range agents {
 		s.SendHandoff(stream.HandoffEvent{
 			To:     agent,
-			Reason: "Processing",
+			Reason: types.HandoffReason{Detail: "Processing"},
 		})
 		time.Sleep(100 * time.Millisecond)
 
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"cooperations/internal/tui/widgets"
+)
+
+// gitFileStatuses runs `git status --porcelain` in root and returns each
+// changed path's status, relative to root with forward slashes, so
+// RefreshFileTree can show real working-tree changes for files the
+// workflow itself never touched. It returns nil when root isn't inside a
+// git working tree (or git isn't on PATH), so callers can fall back to
+// whatever statuses they already have.
+func gitFileStatuses(root string) map[string]widgets.FileStatus {
+	out, err := exec.Command("git", "-C", root, "status", "--porcelain", "--untracked-files=all").Output()
+	if err != nil {
+		return nil
+	}
+
+	statuses := make(map[string]widgets.FileStatus)
+	for _, line := range strings.Split(string(out), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		code := line[:2]
+		path := line[3:]
+		if idx := strings.Index(path, " -> "); idx >= 0 {
+			// Rename: "old -> new"; track the new path.
+			path = path[idx+len(" -> "):]
+		}
+		path = filepath.ToSlash(strings.Trim(path, "\""))
+
+		var status widgets.FileStatus
+		switch {
+		case code == "??":
+			status = widgets.FileStatusAdded
+		case strings.Contains(code, "R"):
+			status = widgets.FileStatusRenamed
+		case strings.Contains(code, "D"):
+			status = widgets.FileStatusDeleted
+		case strings.Contains(code, "A"):
+			status = widgets.FileStatusAdded
+		case strings.Contains(code, "M"):
+			status = widgets.FileStatusModified
+		default:
+			continue
+		}
+		statuses[path] = status
+	}
+	return statuses
+}
@@ -0,0 +1,218 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keybindingsFileName is the file user key overrides are read from under a
+// Model's PrefsDir, alongside tui_prefs.json. JSON rather than YAML, to
+// match the rest of the TUI's persisted state and avoid a new dependency.
+const keybindingsFileName = "keybindings.json"
+
+// KeyOverrides maps a KeyMap field name (e.g. "Up", "SaveSession") to the
+// list of key strings it should bind instead of the default.
+type KeyOverrides map[string][]string
+
+// keybindingsPath returns the path user key overrides are stored at under dir.
+func keybindingsPath(dir string) string {
+	return filepath.Join(dir, keybindingsFileName)
+}
+
+// LoadKeyOverrides reads user key overrides from dir, returning an empty
+// (non-nil) map if the file is missing, unreadable, or corrupt -- a broken
+// override file should never prevent the TUI from starting.
+func LoadKeyOverrides(dir string) KeyOverrides {
+	data, err := os.ReadFile(keybindingsPath(dir))
+	if err != nil {
+		return KeyOverrides{}
+	}
+	var overrides KeyOverrides
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return KeyOverrides{}
+	}
+	return overrides
+}
+
+var keyBindingType = reflect.TypeOf(key.Binding{})
+
+// ApplyKeyOverrides returns a copy of km with each named field's keys
+// replaced by its override, preserving the field's original help
+// description. Fields that don't exist on KeyMap are ignored here; use
+// ValidateKeyOverrides to surface those instead of silently dropping them.
+func ApplyKeyOverrides(km KeyMap, overrides KeyOverrides) KeyMap {
+	v := reflect.ValueOf(&km).Elem()
+	for name, keys := range overrides {
+		if len(keys) == 0 {
+			continue
+		}
+		field := v.FieldByName(name)
+		if !field.IsValid() || field.Type() != keyBindingType {
+			continue
+		}
+		existing := field.Interface().(key.Binding)
+		field.Set(reflect.ValueOf(key.NewBinding(
+			key.WithKeys(keys...),
+			key.WithHelp(strings.Join(keys, "/"), existing.Help().Desc),
+		)))
+	}
+	return km
+}
+
+// ValidateKeyOverrides reports override field names that don't match a
+// KeyMap field, so a typo in keybindings.json is surfaced instead of
+// silently ignored.
+func ValidateKeyOverrides(overrides KeyOverrides) []string {
+	var unknown []string
+	v := reflect.ValueOf(KeyMap{})
+	for name := range overrides {
+		if !v.FieldByName(name).IsValid() {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// ConflictingBindings finds key strings bound to more than one KeyMap field,
+// mapping each such key to the field names sharing it. This is a global
+// check across the whole KeyMap -- some overlaps are legitimate because the
+// fields are only ever active in mutually exclusive contexts (e.g. Pause
+// and Resume both use Space), so callers should treat the result as
+// something to review rather than a hard error.
+func ConflictingBindings(km KeyMap) map[string][]string {
+	holders := make(map[string][]string)
+	v := reflect.ValueOf(km)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Type() != keyBindingType {
+			continue
+		}
+		b := field.Interface().(key.Binding)
+		for _, k := range b.Keys() {
+			holders[k] = append(holders[k], t.Field(i).Name)
+		}
+	}
+
+	conflicts := make(map[string][]string)
+	for k, fields := range holders {
+		if len(fields) > 1 {
+			sort.Strings(fields)
+			conflicts[k] = fields
+		}
+	}
+	return conflicts
+}
+
+// KeyContext names a set of KeyMap fields reachable from the same code path
+// in handleKeyPress -- dialog input, then search-text input, then
+// search-result navigation, then the active ViewMode's own handler, then
+// the global dashboard switch. Two bindings that share a key but live in
+// different contexts can never fire ambiguously, since only one context is
+// ever active for a given keypress.
+type KeyContext struct {
+	Name   string
+	Fields []string
+}
+
+// keyContexts mirrors the dispatch groups in app.go's handleKeyPress and its
+// per-mode handlers (handleFocusKeys, handleZenKeys, handleDialogInput).
+var keyContexts = []KeyContext{
+	{
+		Name: "Dashboard",
+		Fields: []string{
+			"ForceQuit", "Quit", "Help", "FocusMode", "ZenMode", "ToggleTheme",
+			"Tab", "ShiftTab", "Panel1", "Panel2", "Panel3", "Left", "Right",
+			"ToggleCenter", "ToggleRight", "MetricsView", "DiffView",
+			"Up", "Down", "HalfUp", "HalfDown", "PageUp", "PageDown", "Top", "Bottom",
+			"HScrollLeft", "HScrollRight",
+			"Pause", "Resume", "NextStep", "Skip", "Kill",
+			"Search", "ClearSearch",
+			"Open", "Edit", "CopyPath", "CopyContent", "Refresh", "FilterCycle",
+			"CollapseAll", "ExpandAll", "VisualSelect",
+			"LogLevelCycle", "LogAgentCycle",
+			"SaveSession", "OpenSession", "Replay", "Checkpoint",
+		},
+	},
+	{
+		// Intercepted ahead of the Dashboard context whenever a search query is
+		// active (see the SearchQuery != "" check near the top of
+		// handleKeyPress), so NextResult/PrevResult never compete with
+		// Dashboard's NextStep/Kill for the same keypress.
+		Name:   "Search results active",
+		Fields: []string{"NextResult", "PrevResult"},
+	},
+	{
+		Name: "Focus view",
+		Fields: []string{
+			"Cancel", "ToggleCenter", "DiffView", "Search", "VisualSelect",
+			"CopyContent", "Up", "Down", "HScrollLeft", "HScrollRight",
+			"ForceQuit", "Quit",
+		},
+	},
+	{
+		Name:   "Zen view",
+		Fields: []string{"Cancel", "Quit", "ForceQuit"},
+	},
+	{
+		Name:   "Dialog",
+		Fields: []string{"ForceQuit", "Quit", "Cancel", "Confirm"},
+	},
+}
+
+// legitimateContextConflicts lists field-name pairs allowed to share a key
+// within the same context because they're mutually exclusive by runtime
+// state rather than by input context: Pause and Resume are two labels for
+// the same Space keypress, whose effect flips based on WorkflowState, so
+// they can never both apply at once.
+var legitimateContextConflicts = map[string]bool{
+	"Pause|Resume": true,
+}
+
+// ContextualConflicts is like ConflictingBindings but restricted to fields
+// reachable from the same input context (see keyContexts), so it doesn't
+// flag pairs such as NextStep/NextResult that are already unambiguous
+// because only one of their contexts is ever active for a given keypress.
+// Known-legitimate same-context conflicts (see legitimateContextConflicts)
+// are excluded from the result.
+func ContextualConflicts(km KeyMap) map[string]map[string][]string {
+	v := reflect.ValueOf(km)
+	result := make(map[string]map[string][]string)
+
+	for _, ctx := range keyContexts {
+		holders := make(map[string][]string)
+		for _, name := range ctx.Fields {
+			field := v.FieldByName(name)
+			if !field.IsValid() || field.Type() != keyBindingType {
+				continue
+			}
+			b := field.Interface().(key.Binding)
+			for _, k := range b.Keys() {
+				holders[k] = append(holders[k], name)
+			}
+		}
+
+		conflicts := make(map[string][]string)
+		for k, fields := range holders {
+			if len(fields) < 2 {
+				continue
+			}
+			sort.Strings(fields)
+			if len(fields) == 2 && legitimateContextConflicts[strings.Join(fields, "|")] {
+				continue
+			}
+			conflicts[k] = fields
+		}
+		if len(conflicts) > 0 {
+			result[ctx.Name] = conflicts
+		}
+	}
+	return result
+}
@@ -1,27 +1,32 @@
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"cooperations/internal/tui/views"
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap defines all keyboard shortcuts for the TUI.
 type KeyMap struct {
 	// Navigation
-	Left      key.Binding
-	Right     key.Binding
-	Up        key.Binding
-	Down      key.Binding
-	Tab       key.Binding
-	ShiftTab  key.Binding
-	Panel1    key.Binding
-	Panel2    key.Binding
-	Panel3    key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Tab      key.Binding
+	ShiftTab key.Binding
+	Panel1   key.Binding
+	Panel2   key.Binding
+	Panel3   key.Binding
 
 	// Scrolling
-	PageUp    key.Binding
-	PageDown  key.Binding
-	HalfUp    key.Binding
-	HalfDown  key.Binding
-	Top       key.Binding
-	Bottom    key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	HalfUp       key.Binding
+	HalfDown     key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	HScrollLeft  key.Binding
+	HScrollRight key.Binding
 
 	// View modes
 	ToggleCenter key.Binding
@@ -30,32 +35,43 @@ type KeyMap struct {
 	MetricsView  key.Binding
 	DiffView     key.Binding
 	ZenMode      key.Binding
+	ToggleTheme  key.Binding
 
 	// Workflow control
-	Pause     key.Binding
-	Resume    key.Binding
-	NextStep  key.Binding
-	Skip      key.Binding
-	Kill      key.Binding
-	Confirm   key.Binding
-	Cancel    key.Binding
+	Pause    key.Binding
+	Resume   key.Binding
+	NextStep key.Binding
+	Skip     key.Binding
+	Kill     key.Binding
+	Confirm  key.Binding
+	Cancel   key.Binding
 
 	// File operations
-	Open      key.Binding
-	Edit      key.Binding
-	CopyPath  key.Binding
-	Refresh   key.Binding
+	Open         key.Binding
+	Edit         key.Binding
+	CopyPath     key.Binding
+	CopyContent  key.Binding
+	Refresh      key.Binding
+	FilterCycle  key.Binding
+	CollapseAll  key.Binding
+	ExpandAll    key.Binding
+	VisualSelect key.Binding
+
+	// Activity log
+	LogLevelCycle key.Binding
+	LogAgentCycle key.Binding
 
 	// Search
-	Search       key.Binding
-	NextResult   key.Binding
-	PrevResult   key.Binding
-	ClearSearch  key.Binding
+	Search      key.Binding
+	NextResult  key.Binding
+	PrevResult  key.Binding
+	ClearSearch key.Binding
 
 	// Session
-	SaveSession  key.Binding
-	OpenSession  key.Binding
-	Replay       key.Binding
+	SaveSession key.Binding
+	OpenSession key.Binding
+	Replay      key.Binding
+	Checkpoint  key.Binding
 
 	// General
 	Help      key.Binding
@@ -129,6 +145,17 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("G"),
 			key.WithHelp("G", "go to bottom"),
 		),
+		// "h"/"l" and "L" are already spoken for by panel navigation and
+		// LogLevelCycle, so wide-line scrolling gets its own keys instead of
+		// the shift-H/L pair a plain vim binding would suggest.
+		HScrollLeft: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "scroll code/diff left"),
+		),
+		HScrollRight: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "scroll code/diff right"),
+		),
 
 		// View modes
 		ToggleCenter: key.NewBinding(
@@ -155,6 +182,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("z"),
 			key.WithHelp("z", "zen mode"),
 		),
+		ToggleTheme: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "cycle theme"),
+		),
 
 		// Workflow control
 		Pause: key.NewBinding(
@@ -199,10 +230,40 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("y"),
 			key.WithHelp("y", "copy path"),
 		),
+		CopyContent: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy active panel content"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("R"),
 			key.WithHelp("R", "refresh"),
 		),
+		FilterCycle: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "cycle file filter"),
+		),
+		CollapseAll: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "collapse all"),
+		),
+		ExpandAll: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "expand all"),
+		),
+		VisualSelect: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "visual-line select (focus code view)"),
+		),
+
+		// Activity log
+		LogLevelCycle: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "cycle min log level"),
+		),
+		LogAgentCycle: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "isolate agent in log"),
+		),
 
 		// Search
 		Search: key.NewBinding(
@@ -235,6 +296,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl+r"),
 			key.WithHelp("Ctrl+r", "replay"),
 		),
+		Checkpoint: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("Ctrl+p", "checkpoint"),
+		),
 
 		// General
 		Help: key.NewBinding(
@@ -265,22 +330,61 @@ func (k KeyMap) ShortHelp() []key.Binding {
 
 // FullHelp returns all bindings grouped by category.
 func (k KeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{
-		// Navigation
-		{k.Left, k.Right, k.Up, k.Down, k.Tab, k.Panel1, k.Panel2, k.Panel3},
-		// Scrolling
-		{k.PageUp, k.PageDown, k.HalfUp, k.HalfDown, k.Top, k.Bottom},
-		// Views
-		{k.ToggleCenter, k.ToggleRight, k.FocusMode, k.MetricsView, k.DiffView, k.ZenMode},
-		// Workflow
-		{k.Pause, k.NextStep, k.Skip, k.Kill, k.Confirm, k.Cancel},
-		// Files
-		{k.Open, k.Edit, k.CopyPath, k.Refresh},
-		// Search
-		{k.Search, k.NextResult, k.PrevResult},
-		// Session
-		{k.SaveSession, k.OpenSession, k.Replay},
-		// General
-		{k.Help, k.Quit, k.ForceQuit},
+	groups := make([][]key.Binding, len(k.CategorizedHelp()))
+	for i, cat := range k.CategorizedHelp() {
+		groups[i] = cat.Bindings
+	}
+	return groups
+}
+
+// HelpCategory names a group of bindings for display, e.g. by the
+// auto-generated help view.
+type HelpCategory struct {
+	Name     string
+	Bindings []key.Binding
+}
+
+// CategorizedHelp returns every binding in KeyMap grouped by category. This
+// is the single source of truth for both FullHelp and the help view, so the
+// two can't drift the way a hand-maintained help screen would.
+func (k KeyMap) CategorizedHelp() []HelpCategory {
+	return []HelpCategory{
+		{"Navigation", []key.Binding{k.Left, k.Right, k.Up, k.Down, k.Tab, k.Panel1, k.Panel2, k.Panel3}},
+		{"Scrolling", []key.Binding{k.PageUp, k.PageDown, k.HalfUp, k.HalfDown, k.Top, k.Bottom, k.HScrollLeft, k.HScrollRight}},
+		{"Views", []key.Binding{k.ToggleCenter, k.ToggleRight, k.FocusMode, k.MetricsView, k.DiffView, k.ZenMode, k.ToggleTheme}},
+		{"Workflow", []key.Binding{k.Pause, k.NextStep, k.Skip, k.Kill, k.Confirm, k.Cancel}},
+		{"Files", []key.Binding{k.Open, k.Edit, k.CopyPath, k.CopyContent, k.Refresh, k.FilterCycle, k.CollapseAll, k.ExpandAll, k.VisualSelect}},
+		{"Activity log", []key.Binding{k.LogLevelCycle, k.LogAgentCycle}},
+		{"Search", []key.Binding{k.Search, k.NextResult, k.PrevResult}},
+		{"Session", []key.Binding{k.SaveSession, k.OpenSession, k.Replay, k.Checkpoint}},
+		{"General", []key.Binding{k.Help, k.Quit, k.ForceQuit}},
+	}
+}
+
+// focusHelpCategories returns the bindings actually active in focus view,
+// for the help screen's focus-context display. Kept separate from
+// CategorizedHelp since most dashboard-only bindings (panel navigation,
+// file tree, sessions) don't apply there.
+func focusHelpCategories(k KeyMap) []HelpCategory {
+	return []HelpCategory{
+		{"Focus view", []key.Binding{k.Cancel, k.ToggleCenter, k.DiffView, k.Search, k.VisualSelect, k.CopyContent, k.Up, k.Down, k.HScrollLeft, k.HScrollRight}},
+		{"General", []key.Binding{k.Help, k.Quit, k.ForceQuit}},
+	}
+}
+
+// helpBindings flattens a []HelpCategory into the plain KeyBinding list the
+// (decoupled) help view renders, using each binding's own Help() text
+// rather than a separately maintained description.
+func helpBindings(categories []HelpCategory) []views.KeyBinding {
+	var out []views.KeyBinding
+	for _, cat := range categories {
+		for _, b := range cat.Bindings {
+			h := b.Help()
+			if h.Key == "" && h.Desc == "" {
+				continue
+			}
+			out = append(out, views.KeyBinding{Key: h.Key, Description: h.Desc, Category: cat.Name})
+		}
 	}
+	return out
 }
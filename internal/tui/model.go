@@ -1,15 +1,20 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"cooperations/internal/tasklock"
 	"cooperations/internal/tui/session"
 	"cooperations/internal/tui/stream"
+	"cooperations/internal/tui/streamlog"
 	"cooperations/internal/tui/styles"
 	"cooperations/internal/tui/views"
 	"cooperations/internal/tui/widgets"
@@ -36,13 +41,17 @@ const (
 	WorkflowError
 )
 
+// DefaultStallThreshold is how long WorkflowRunning can go without a stream
+// event before the dashboard flags it as possibly stalled (see --stall-timeout).
+const DefaultStallThreshold = 20 * time.Second
+
 // InputMode indicates what the input dialog is used for.
 type InputMode int
 
 const (
 	InputModeNone InputMode = iota
 	InputModeDecisionEdit
-	InputModeOpenSession
+	InputModeRenameSession
 )
 
 // SearchTarget represents which view is being searched.
@@ -55,6 +64,27 @@ const (
 	SearchTargetDiff
 )
 
+// SearchHit is a single search match, tagged with the buffer it was found
+// in so "search all" results can jump between center modes.
+type SearchHit struct {
+	Target SearchTarget
+	Line   int
+}
+
+// String returns the short, human-readable name shown in match toasts.
+func (t SearchTarget) String() string {
+	switch t {
+	case SearchTargetStreaming:
+		return "streaming"
+	case SearchTargetCode:
+		return "code"
+	case SearchTargetDiff:
+		return "diff"
+	default:
+		return "none"
+	}
+}
+
 // Model is the main TUI application state.
 type Model struct {
 	// Dimensions
@@ -82,29 +112,46 @@ type Model struct {
 	CompletedSteps    int
 	WorkflowStepIndex map[string]int
 
+	// Heartbeat: LastEventAt is reset on every stream event; if
+	// WorkflowRunning and nothing has arrived for StallThreshold, Stalled
+	// flips true and the dashboard shows a "possibly stalled" badge until
+	// the next event (or a state change away from running) clears it.
+	// StallThreshold <= 0 disables the check.
+	LastEventAt    time.Time
+	StallThreshold time.Duration
+	Stalled        bool
+
 	// Stream for receiving updates
 	Stream *stream.WorkflowStream
 
 	// Dialogs
-	DecisionDialog        *widgets.DecisionDialog
-	ConfirmDialog         *widgets.ConfirmDialog
-	InputDialog           *widgets.InputDialog
-	PendingDecision       *stream.DecisionRequest
-	PendingDecisionAction stream.DecisionAction
-	InputMode             InputMode
-	PendingAction         string // "skip", "kill", "quit" for confirm dialogs
+	DecisionDialog         *widgets.DecisionDialog
+	ConfirmDialog          *widgets.ConfirmDialog
+	InputDialog            *widgets.InputDialog
+	SummaryDialog          *widgets.SummaryDialog
+	SessionPicker          *widgets.SessionPickerDialog
+	SessionPickerTarget    string // session ID targeted by a delete/rename action from the picker
+	PendingDecision        *stream.DecisionRequest
+	PendingDecisionAction  stream.DecisionAction
+	PendingDecisionDefault stream.DecisionAction
+	InputMode              InputMode
+	PendingAction          string // "skip", "kill", "quit" for confirm dialogs
 
 	// Workflow control state
 	StepMode bool // Auto-pause after each agent
 	CanSkip  bool // Whether skip is available at current phase
 
 	// Input state
-	Keys          KeyMap
-	SearchMode    bool
-	SearchQuery   string
-	SearchResults []int
-	SearchIndex   int
-	SearchTarget  SearchTarget
+	Keys KeyMap
+	// KeyOverrideErrors names any keybindings.json fields that didn't match
+	// a KeyMap field, surfaced as a startup toast once the dashboard exists.
+	KeyOverrideErrors   []string
+	SearchMode          bool
+	SearchQuery         string
+	SearchResults       []SearchHit
+	SearchIndex         int
+	SearchRegex         bool // Treat SearchQuery as a regular expression
+	SearchCaseSensitive bool // Match case exactly instead of folding case
 
 	// Timing
 	StartTime    time.Time
@@ -121,6 +168,31 @@ type Model struct {
 	ReplayActive   bool
 	ReplaySpeed    float64
 
+	// AutosaveInterval is how often the current session is saved
+	// automatically, independent of the user pressing ctrl+s. LastAutosave
+	// tracks when that last happened, so ticks between saves are cheap
+	// no-ops instead of hitting disk every tick.
+	AutosaveInterval time.Duration
+	LastAutosave     time.Time
+
+	// TaskLock is the advisory lock held on the current session's ID, so a
+	// concurrent `coop run` or second TUI attaching to the same session
+	// doesn't interleave writes with this one. ReadOnly is set instead of
+	// TaskLock when another live process already holds it.
+	TaskLock *tasklock.Lock
+	ReadOnly bool
+
+	// StreamLog, when set, receives every stream event as an NDJSON record
+	// for offline timeline reconstruction (see --stream-log).
+	StreamLog *streamlog.Writer
+
+	// Preferences
+	PrefsDir      string      // Directory tui_prefs.json is read from/written to
+	SavePrefs     bool        // Persist layout preferences on quit (see --no-save-prefs)
+	Prefs         Preferences // Loaded at startup, applied once views are initialized
+	ThemeOverride string      // Theme forced via --theme; takes priority over Prefs.Theme when set
+	BellEnabled   bool        // Ring the terminal bell on a pending decision; disabled by --no-bell
+
 	// Errors
 	LastError error
 }
@@ -132,26 +204,39 @@ func NewModel(workflowStream *stream.WorkflowStream) Model {
 
 // NewModelWithTask creates a new TUI model with an initial task label.
 func NewModelWithTask(workflowStream *stream.WorkflowStream, task string) Model {
-	sessionDir := os.Getenv("COOPERATIONS_DIR")
-	if sessionDir == "" {
-		sessionDir = ".cooperations"
+	baseDir := os.Getenv("COOPERATIONS_DIR")
+	if baseDir == "" {
+		baseDir = ".cooperations"
 	}
-	sessionDir = filepath.Join(sessionDir, "tui_sessions")
+	sessionDir := filepath.Join(baseDir, "tui_sessions")
 
 	repoRoot, _ := os.Getwd()
 	manager, err := session.NewManager(sessionDir)
 
+	keys := DefaultKeyMap()
+	overrides := LoadKeyOverrides(baseDir)
+	keyWarnings := ValidateKeyOverrides(overrides)
+	keys = ApplyKeyOverrides(keys, overrides)
+
 	model := Model{
 		Stream:            workflowStream,
-		Keys:              DefaultKeyMap(),
+		Keys:              keys,
+		KeyOverrideErrors: keyWarnings,
 		TickInterval:      100 * time.Millisecond,
 		StartTime:         time.Now(),
+		LastEventAt:       time.Now(),
+		StallThreshold:    DefaultStallThreshold,
 		SessionDir:        sessionDir,
 		RepoRoot:          repoRoot,
 		SessionManager:    manager,
 		SessionInitErr:    err,
 		ReplaySpeed:       1.0,
+		AutosaveInterval:  30 * time.Second,
 		WorkflowStepIndex: map[string]int{},
+		PrefsDir:          baseDir,
+		SavePrefs:         true,
+		Prefs:             loadPreferences(baseDir),
+		BellEnabled:       true,
 	}
 
 	if task != "" && manager != nil {
@@ -159,6 +244,7 @@ func NewModelWithTask(workflowStream *stream.WorkflowStream, task string) Model
 		model.SessionID = current.ID
 		model.SessionName = current.Name
 		model.CurrentTask = task
+		model.acquireTaskLock(current.ID)
 	}
 
 	return model
@@ -174,14 +260,53 @@ func (m *Model) Initialize(width, height int) {
 	m.Dashboard = views.NewDashboardView(width, height)
 	m.Focus = views.NewFocusView(width, height)
 	m.Help = views.NewHelpView(width, height)
+	m.Help.Bindings = helpBindings(m.Keys.CategorizedHelp())
 	m.Zen = views.NewZenView(width, height)
 
 	// Set initial view mode
 	m.ViewMode = ViewModeDashboard
+	m.applyPreferences()
 
 	if m.SessionInitErr != nil {
 		m.ShowToast(fmt.Sprintf("Session init failed: %v", m.SessionInitErr), widgets.ToastLevelWarning)
 	}
+	if len(m.KeyOverrideErrors) > 0 {
+		m.ShowToast(fmt.Sprintf("Unknown keybindings.json fields: %s", strings.Join(m.KeyOverrideErrors, ", ")), widgets.ToastLevelWarning)
+	}
+	if msg := describeContextualConflicts(m.Keys); msg != "" {
+		m.ShowToast(msg, widgets.ToastLevelWarning)
+	}
+}
+
+// describeContextualConflicts summarizes ContextualConflicts as a single
+// toast-sized string, or "" if there are none. Split out of Initialize so a
+// keybindings.json override that introduces a real ambiguity is caught at
+// startup rather than discovered by a confused keypress later.
+func describeContextualConflicts(km KeyMap) string {
+	conflicts := ContextualConflicts(km)
+	if len(conflicts) == 0 {
+		return ""
+	}
+
+	ctxNames := make([]string, 0, len(conflicts))
+	for name := range conflicts {
+		ctxNames = append(ctxNames, name)
+	}
+	sort.Strings(ctxNames)
+
+	var parts []string
+	for _, ctxName := range ctxNames {
+		byKey := conflicts[ctxName]
+		keys := make([]string, 0, len(byKey))
+		for k := range byKey {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s %q: %s", ctxName, k, strings.Join(byKey[k], "/")))
+		}
+	}
+	return "Key binding conflicts - " + strings.Join(parts, "; ")
 }
 
 // Resize updates all views with new dimensions.
@@ -209,13 +334,23 @@ func (m *Model) SetViewMode(mode ViewMode) {
 	m.ViewMode = mode
 }
 
-// ToggleHelp toggles the help overlay.
+// ToggleHelp toggles the help overlay, populating it with the bindings
+// relevant to whichever view it was opened from.
 func (m *Model) ToggleHelp() {
 	if m.ViewMode == ViewModeHelp {
 		m.ViewMode = m.PreviousMode
-	} else {
-		m.SetViewMode(ViewModeHelp)
+		return
 	}
+
+	if m.Help != nil {
+		if m.ViewMode == ViewModeFocus {
+			m.Help.Bindings = helpBindings(focusHelpCategories(m.Keys))
+		} else {
+			m.Help.Bindings = helpBindings(m.Keys.CategorizedHelp())
+		}
+		m.Help.SetQuery("")
+	}
+	m.SetViewMode(ViewModeHelp)
 }
 
 // ToggleFocus toggles focus mode.
@@ -236,9 +371,14 @@ func (m *Model) ToggleZen() {
 	}
 }
 
-// SetWorkflowState updates the workflow state.
+// SetWorkflowState updates the workflow state. Leaving WorkflowRunning
+// clears any stall badge, since the heartbeat check only applies while
+// running.
 func (m *Model) SetWorkflowState(state WorkflowState) {
 	m.WorkflowState = state
+	if state != WorkflowRunning {
+		m.Stalled = false
+	}
 }
 
 // SetCurrentAgent updates the active agent.
@@ -288,7 +428,7 @@ func (m *Model) AppendStreamingContent(content string) {
 		m.Focus.StreamingText.Append(content)
 	}
 	if m.Zen != nil {
-		m.Zen.Content += content
+		m.Zen.Append(content)
 		m.Zen.ShowCursor = true
 	}
 }
@@ -347,6 +487,8 @@ func (m *Model) RefreshFileTree() {
 		return
 	}
 
+	gitStatuses := gitFileStatuses(root)
+
 	entries := m.Dashboard.FileTree.Snapshot()
 	if len(entries) > 0 {
 		m.Dashboard.FileTree.Clear()
@@ -358,6 +500,11 @@ func (m *Model) RefreshFileTree() {
 				continue
 			}
 			status := entry.Status
+			if status == widgets.FileStatusNone {
+				if gs, ok := gitStatuses[entry.Path]; ok {
+					status = gs
+				}
+			}
 			m.Dashboard.FileTree.AddPath(entry.Path, status, info.IsDir())
 		}
 		m.ShowToast("File tree refreshed", widgets.ToastLevelInfo)
@@ -367,10 +514,10 @@ func (m *Model) RefreshFileTree() {
 	// If no existing entries, build from disk with a reasonable cap.
 	const maxFiles = 2000
 	skips := map[string]struct{}{
-		".git":           {},
-		".cooperations":  {},
-		".claude":        {},
-		"node_modules":   {},
+		".git":          {},
+		".cooperations": {},
+		".claude":       {},
+		"node_modules":  {},
 	}
 
 	scanned := 0
@@ -400,7 +547,11 @@ func (m *Model) RefreshFileTree() {
 		if d.IsDir() {
 			return nil
 		}
-		m.Dashboard.FileTree.AddPath(rel, widgets.FileStatusNone, false)
+		status := widgets.FileStatusNone
+		if gs, ok := gitStatuses[rel]; ok {
+			status = gs
+		}
+		m.Dashboard.FileTree.AddPath(rel, status, false)
 		scanned++
 		if scanned >= maxFiles {
 			return fs.SkipAll
@@ -415,6 +566,116 @@ func (m *Model) RefreshFileTree() {
 	}
 }
 
+// copyActivePanelContent copies whichever center buffer (streaming
+// response, code, or diff) is currently on screen to the clipboard,
+// honoring Focus mode when active.
+func (m *Model) copyActivePanelContent() {
+	var content, label string
+
+	if m.ViewMode == ViewModeFocus && m.Focus != nil {
+		switch m.Focus.Mode {
+		case views.FocusModeStreaming:
+			content, label = m.Focus.StreamingText.Content, "response"
+		case views.FocusModeCode:
+			content, label = m.Focus.CodeBlock.Content, "code"
+		case views.FocusModeDiff:
+			content, label = m.Focus.DiffBlock.Content, "diff"
+		default:
+			m.ShowToast("Nothing to copy in this focus mode", widgets.ToastLevelWarning)
+			return
+		}
+	} else if m.Dashboard != nil {
+		switch m.Dashboard.CenterMode {
+		case 0:
+			content, label = m.Dashboard.StreamingText.Content, "response"
+		case 1:
+			content, label = m.Dashboard.CodeBlock.Content, "code"
+		case 2:
+			content, label = m.Dashboard.DiffBlock.Content, "diff"
+		}
+	}
+
+	if content == "" {
+		m.ShowToast("Nothing to copy", widgets.ToastLevelWarning)
+		return
+	}
+	if err := copyToClipboard(content); err != nil {
+		m.ShowToast("Copy failed: "+err.Error(), widgets.ToastLevelWarning)
+		return
+	}
+	m.ShowToast(fmt.Sprintf("Copied %s (%d bytes)", label, len(content)), widgets.ToastLevelSuccess)
+}
+
+// toggleFocusSelection starts or ends visual-line selection in the focus
+// view's code mode. Selection only applies there since that's the only
+// focus mode with addressable, copyable lines.
+func (m *Model) toggleFocusSelection() {
+	if m.Focus == nil || m.Focus.Mode != views.FocusModeCode {
+		m.ShowToast("Visual select only works in code view", widgets.ToastLevelWarning)
+		return
+	}
+	if m.Focus.Selecting {
+		m.Focus.ClearSelection()
+		m.Focus.CodeBlock.ClearHighlights()
+		return
+	}
+	anchor := m.Focus.CodeBlock.StartLine + m.Focus.CodeBlock.ScrollPos
+	m.Focus.StartSelection(anchor)
+	m.Focus.CodeBlock.ClearHighlights()
+	m.Focus.CodeBlock.AddHighlight(anchor)
+}
+
+// extendFocusSelection moves the selection cursor by delta lines, scrolls
+// the code block to keep it in view, and re-highlights the selected range.
+func (m *Model) extendFocusSelection(delta int) {
+	if m.Focus == nil || !m.Focus.Selecting {
+		return
+	}
+	lines := strings.Split(m.Focus.CodeBlock.Content, "\n")
+	maxLine := m.Focus.CodeBlock.StartLine + len(lines) - 1
+	m.Focus.ExtendSelection(delta, maxLine)
+	if delta < 0 {
+		m.Focus.CodeBlock.ScrollUp(1)
+	} else {
+		m.Focus.CodeBlock.ScrollDown(1)
+	}
+
+	lo, hi := m.Focus.SelectedRange()
+	m.Focus.CodeBlock.ClearHighlights()
+	for line := lo; line <= hi; line++ {
+		m.Focus.CodeBlock.AddHighlight(line)
+	}
+}
+
+// copyFocusSelection copies the currently selected line range from the
+// focus code view to the clipboard.
+func (m *Model) copyFocusSelection() {
+	if m.Focus == nil || !m.Focus.Selecting {
+		return
+	}
+	lines := strings.Split(m.Focus.CodeBlock.Content, "\n")
+	lo, hi := m.Focus.SelectedRange()
+	startIdx := lo - m.Focus.CodeBlock.StartLine
+	endIdx := hi - m.Focus.CodeBlock.StartLine
+	if startIdx < 0 {
+		startIdx = 0
+	}
+	if endIdx >= len(lines) {
+		endIdx = len(lines) - 1
+	}
+	if startIdx > endIdx {
+		m.ShowToast("Nothing selected", widgets.ToastLevelWarning)
+		return
+	}
+
+	selected := strings.Join(lines[startIdx:endIdx+1], "\n")
+	if err := copyToClipboard(selected); err != nil {
+		m.ShowToast("Copy failed: "+err.Error(), widgets.ToastLevelWarning)
+		return
+	}
+	m.ShowToast(fmt.Sprintf("Copied lines %d-%d (%d bytes)", lo, hi, len(selected)), widgets.ToastLevelSuccess)
+}
+
 // ResolvePath returns an absolute path for a workspace-relative path.
 func (m *Model) ResolvePath(path string) string {
 	if filepath.IsAbs(path) {
@@ -432,6 +693,61 @@ func (m *Model) ResolvePath(path string) string {
 	return filepath.Join(root, filepath.FromSlash(path))
 }
 
+// maxPreviewLines caps how much of a file PreviewFile loads, so opening a
+// large generated file doesn't stall the UI.
+const maxPreviewLines = 5000
+
+// PreviewFile loads path (resolved via ResolvePath) into the code block,
+// detecting its language from the extension and truncating long files.
+func (m *Model) PreviewFile(path string) {
+	data, err := os.ReadFile(m.ResolvePath(path))
+	if err != nil {
+		m.ShowToast("Preview failed: "+err.Error(), widgets.ToastLevelWarning)
+		return
+	}
+
+	lines := strings.Split(string(data), "\n")
+	truncated := len(lines) > maxPreviewLines
+	if truncated {
+		lines = lines[:maxPreviewLines]
+	}
+	content := strings.Join(lines, "\n")
+	if truncated {
+		content += fmt.Sprintf("\n... truncated (showing first %d lines) ...", maxPreviewLines)
+	}
+
+	m.SetCodeContent(content, detectLanguage(path), path)
+	if m.Dashboard != nil {
+		m.Dashboard.CenterMode = 1
+	}
+	m.ShowToast("Previewing: "+path, widgets.ToastLevelInfo)
+}
+
+// detectLanguage maps a file extension to the language name SetCodeContent
+// expects for syntax highlighting.
+func detectLanguage(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".go":
+		return "go"
+	case ".js", ".mjs", ".cjs":
+		return "javascript"
+	case ".ts", ".tsx":
+		return "typescript"
+	case ".py":
+		return "python"
+	case ".md":
+		return "markdown"
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	default:
+		return "text"
+	}
+}
+
 // UpdateProgress updates the progress bar.
 func (m *Model) UpdateProgress(percent float64, label string) {
 	if m.Dashboard != nil {
@@ -486,6 +802,90 @@ func (m *Model) UpdateMetricsSnapshot(snapshot stream.MetricsSnapshot) {
 	}
 }
 
+// UpdateWorkflowSummary replaces the metrics panel with the final aggregate
+// totals once a workflow finishes, since the last MetricsSnapshot only
+// reflects the last step's running totals.
+func (m *Model) UpdateWorkflowSummary(summary stream.WorkflowSummary) {
+	if m.Dashboard != nil {
+		m.Dashboard.CostTracker.SetSnapshot(summary.PromptTokens, summary.CompletionTokens, summary.EstimatedCostUSD)
+		m.Dashboard.Metrics.Clear()
+		m.Dashboard.Metrics.AddMetric(widgets.NewMetricCard("Tokens", fmt.Sprintf("%d", summary.TotalTokens), ""))
+		m.Dashboard.Metrics.AddMetric(widgets.NewMetricCard("Prompt", fmt.Sprintf("%d", summary.PromptTokens), ""))
+		m.Dashboard.Metrics.AddMetric(widgets.NewMetricCard("Completion", fmt.Sprintf("%d", summary.CompletionTokens), ""))
+		cost := widgets.NewMetricCard("Cost", fmt.Sprintf("$%.4f", summary.EstimatedCostUSD), "")
+		cost.Color = styles.Current.Warning
+		m.Dashboard.Metrics.AddMetric(cost)
+		if summary.ElapsedTime > 0 {
+			duration := widgets.NewMetricCard("Elapsed", summary.ElapsedTime.Round(time.Second).String(), "")
+			duration.Color = styles.Current.Info
+			m.Dashboard.Metrics.AddMetric(duration)
+		}
+		if summary.ReviewCycles > 0 {
+			m.Dashboard.Metrics.AddMetric(widgets.NewMetricCard("Review Cycles", fmt.Sprintf("%d", summary.ReviewCycles), ""))
+		}
+		if summary.FilesChanged > 0 {
+			m.Dashboard.Metrics.AddMetric(widgets.NewMetricCard("Files Changed", fmt.Sprintf("%d", summary.FilesChanged), ""))
+		}
+	}
+	if m.Focus != nil && summary.ElapsedTime > 0 {
+		m.Focus.Duration = summary.ElapsedTime.Round(time.Second).String()
+	}
+	if m.Focus != nil && summary.TotalTokens > 0 {
+		m.Focus.TokenCount = summary.TotalTokens
+	}
+
+	status := "Workflow complete"
+	if !summary.Success {
+		status = "Workflow aborted"
+	}
+	m.AddLogEntry(widgets.LogInfo, "workflow", fmt.Sprintf("%s: %d tokens, $%.4f, %s elapsed",
+		status, summary.TotalTokens, summary.EstimatedCostUSD, summary.ElapsedTime.Round(time.Second)))
+	for role, stats := range summary.RoleBreakdown {
+		m.AddLogEntry(widgets.LogInfo, role, fmt.Sprintf("%d call(s), %d tokens, %dms",
+			stats.Calls, stats.Tokens, stats.DurationMS))
+		if m.Dashboard != nil {
+			if card := m.Dashboard.AgentPanel.GetAgent(role); card != nil {
+				duration := time.Duration(stats.DurationMS) * time.Millisecond
+				card.SetMetrics(stats.Tokens, duration.Round(time.Millisecond).String())
+			}
+		}
+	}
+
+	m.ShowWorkflowSummaryDialog(summary)
+}
+
+// ShowWorkflowSummaryDialog opens the end-of-run completion overlay,
+// summarizing what UpdateWorkflowSummary just recorded plus the changed
+// files known to the file tree, so the run ends with an actionable recap
+// instead of a silent return to the dashboard.
+func (m *Model) ShowWorkflowSummaryDialog(summary stream.WorkflowSummary) {
+	stats := []string{
+		fmt.Sprintf("Tokens:   %d (%d prompt, %d completion)", summary.TotalTokens, summary.PromptTokens, summary.CompletionTokens),
+		fmt.Sprintf("Cost:     $%.4f", summary.EstimatedCostUSD),
+		fmt.Sprintf("Elapsed:  %s", summary.ElapsedTime.Round(time.Second)),
+	}
+	if summary.ReviewCycles > 0 {
+		stats = append(stats, fmt.Sprintf("Reviews:  %d cycle(s)", summary.ReviewCycles))
+	}
+	stats = append(stats, fmt.Sprintf("Files:    %d changed", summary.FilesChanged))
+
+	var files []string
+	if m.Dashboard != nil && m.Dashboard.FileTree != nil {
+		for _, entry := range m.Dashboard.FileTree.Snapshot() {
+			if !entry.IsDir && entry.Status != widgets.FileStatusNone {
+				files = append(files, entry.Path)
+			}
+		}
+	}
+
+	dialog := widgets.NewSummaryDialog(summary.Success, stats, files, m.Width/2)
+	m.SummaryDialog = &dialog
+	m.DecisionDialog = nil
+	m.ConfirmDialog = nil
+	m.InputDialog = nil
+	m.ShowDialog = true
+}
+
 // ShowToast displays a toast notification.
 func (m *Model) ShowToast(message string, level widgets.ToastLevel) {
 	if m.Dashboard != nil {
@@ -529,6 +929,9 @@ func (m *Model) HideDialog() {
 	m.DecisionDialog = nil
 	m.ConfirmDialog = nil
 	m.InputDialog = nil
+	m.SummaryDialog = nil
+	m.SessionPicker = nil
+	m.SessionPickerTarget = ""
 	m.InputMode = InputModeNone
 	m.ShowDialog = false
 }
@@ -547,6 +950,51 @@ func (m *Model) Tick() {
 	if m.Zen != nil {
 		m.Zen.ToggleCursor()
 	}
+
+	m.checkDecisionTimeout()
+	m.checkStall()
+}
+
+// checkStall flags the workflow as possibly stalled if it's been running
+// with no stream event for StallThreshold. It only fires once per stall
+// (guarded by !m.Stalled) so it doesn't re-toast every tick; any event
+// arriving, or the workflow leaving WorkflowRunning, clears the flag.
+func (m *Model) checkStall() {
+	if m.WorkflowState != WorkflowRunning || m.StallThreshold <= 0 || m.Stalled {
+		return
+	}
+	if time.Since(m.LastEventAt) < m.StallThreshold {
+		return
+	}
+	m.Stalled = true
+	m.ShowToast(fmt.Sprintf("No events for %s - workflow may be stalled", m.StallThreshold.Round(time.Second)), widgets.ToastLevelWarning)
+}
+
+// dueForAutosave reports whether enough time has passed since the last
+// autosave (or session start, if none has happened yet) to save again.
+// Saving is skipped entirely during replay, since replay drives an
+// already-persisted session rather than producing new events to lose.
+func (m *Model) dueForAutosave() bool {
+	if m.SessionManager == nil || m.SessionManager.Current == nil || m.ReplayActive {
+		return false
+	}
+	if m.AutosaveInterval <= 0 {
+		return false
+	}
+	return time.Since(m.LastAutosave) >= m.AutosaveInterval
+}
+
+// checkDecisionTimeout auto-selects the pending decision's default action
+// once its dialog's countdown reaches zero, so unattended runs don't stall
+// the workflow waiting on human input forever.
+func (m *Model) checkDecisionTimeout() {
+	if m.PendingDecision == nil || m.DecisionDialog == nil || m.DecisionDialog.Deadline.IsZero() {
+		return
+	}
+	if time.Now().Before(m.DecisionDialog.Deadline) {
+		return
+	}
+	m.sendDecision(m.PendingDecisionDefault, "timed out, auto-selected default", "")
 }
 
 // Elapsed returns the elapsed time since start.
@@ -581,40 +1029,108 @@ func (m *Model) ensureSession(task string) {
 	current := m.SessionManager.NewSession(task)
 	m.SessionID = current.ID
 	m.SessionName = current.Name
+	m.acquireTaskLock(current.ID)
+}
+
+// acquireTaskLock takes an advisory lock on sessionID so a second process
+// (a `coop run` resuming the same task, or another TUI instance) attaching
+// to it doesn't interleave writes with this one. If the lock is already
+// held by another live process, the model falls back to read-only instead
+// of failing outright.
+func (m *Model) acquireTaskLock(sessionID string) {
+	if m.PrefsDir == "" || sessionID == "" {
+		return
+	}
+	lock, err := tasklock.Acquire(m.PrefsDir, sessionID)
+	if err != nil {
+		if errors.Is(err, tasklock.ErrLocked) {
+			m.ReadOnly = true
+			m.ShowToast("Session busy in another process - attached read-only", widgets.ToastLevelWarning)
+			return
+		}
+		// Locking is best-effort; a failure to even write the lock file
+		// shouldn't block using the TUI.
+		return
+	}
+	m.TaskLock = lock
+	m.ReadOnly = false
+}
+
+// releaseTaskLock releases the current session's lock, if held.
+func (m *Model) releaseTaskLock() {
+	if m.TaskLock == nil {
+		return
+	}
+	_ = m.TaskLock.Release()
+	m.TaskLock = nil
+}
+
+// createCheckpoint records a checkpoint in the current session and, if a
+// prior checkpoint exists, logs the files touched, tokens spent, and
+// handoffs that happened since it via Manager.BetweenCheckpoints.
+func (m *Model) createCheckpoint() {
+	if m.ReadOnly {
+		m.ShowToast("Session is read-only (busy in another process)", widgets.ToastLevelWarning)
+		return
+	}
+	if m.SessionManager == nil || m.SessionManager.Current == nil {
+		m.ShowToast("No active session to checkpoint", widgets.ToastLevelWarning)
+		return
+	}
+
+	checkpoints := m.SessionManager.Current.Checkpoints
+	name := fmt.Sprintf("checkpoint-%d", len(checkpoints)+1)
+	cp := m.SessionManager.CreateCheckpoint(name, "")
+
+	if len(checkpoints) > 0 {
+		prev := checkpoints[len(checkpoints)-1]
+		if diff, err := m.SessionManager.BetweenCheckpoints(prev, *cp); err == nil {
+			m.AddLogEntry(widgets.LogInfo, "checkpoint", fmt.Sprintf(
+				"%s: %d file(s), %d token(s), %d handoff(s) since %s",
+				cp.Name, len(diff.FilesChanged), diff.TokensSpent, diff.Handoffs, prev.Name,
+			))
+		}
+	}
+
+	m.ShowToast(fmt.Sprintf("Checkpoint %s created", cp.Name), widgets.ToastLevelSuccess)
 }
 
 func (m *Model) recordStreamEvent(event interface{}) {
+	if m.StreamLog != nil && !m.ReplayActive {
+		m.StreamLog.Log(event)
+	}
+
 	if m.SessionManager == nil || m.ReplayActive {
 		return
 	}
 	m.SessionManager.RecordStreamEvent(event)
 }
 
-func (m *Model) searchTargetForView() SearchTarget {
+// setCenterMode switches whichever view is currently visible (Focus or
+// Dashboard) to show target, so jumping to a search hit in another buffer
+// brings it on screen.
+func (m *Model) setCenterMode(target SearchTarget) {
 	if m.ViewMode == ViewModeFocus && m.Focus != nil {
-		switch m.Focus.Mode {
-		case 0:
-			return SearchTargetStreaming
-		case 1:
-			return SearchTargetCode
-		case 2:
-			return SearchTargetDiff
-		default:
-			return SearchTargetNone
+		switch target {
+		case SearchTargetStreaming:
+			m.Focus.SetMode(views.FocusModeStreaming)
+		case SearchTargetCode:
+			m.Focus.SetMode(views.FocusModeCode)
+		case SearchTargetDiff:
+			m.Focus.SetMode(views.FocusModeDiff)
 		}
+		return
 	}
 	if m.Dashboard == nil {
-		return SearchTargetNone
-	}
-	switch m.Dashboard.CenterMode {
-	case 0:
-		return SearchTargetStreaming
-	case 1:
-		return SearchTargetCode
-	case 2:
-		return SearchTargetDiff
-	default:
-		return SearchTargetNone
+		return
+	}
+	switch target {
+	case SearchTargetStreaming:
+		m.Dashboard.CenterMode = 0
+	case SearchTargetCode:
+		m.Dashboard.CenterMode = 1
+	case SearchTargetDiff:
+		m.Dashboard.CenterMode = 2
 	}
 }
 
@@ -702,6 +1218,41 @@ func (m *Model) scrollToSearchResult(target SearchTarget, line int) {
 	}
 }
 
+// searchMatcher returns a function that reports whether a line matches
+// query, honoring SearchRegex and SearchCaseSensitive. It returns an error
+// if query is an invalid regular expression.
+func (m *Model) searchMatcher(query string) (func(line string) bool, error) {
+	if m.SearchRegex {
+		pattern := query
+		if !m.SearchCaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+
+	needle := query
+	if !m.SearchCaseSensitive {
+		needle = strings.ToLower(needle)
+	}
+	return func(line string) bool {
+		if !m.SearchCaseSensitive {
+			line = strings.ToLower(line)
+		}
+		return strings.Contains(line, needle)
+	}, nil
+}
+
+// searchableTargets lists the buffers "search all" scans, in the order
+// results are numbered.
+var searchableTargets = []SearchTarget{SearchTargetStreaming, SearchTargetCode, SearchTargetDiff}
+
+// runSearch scans the streaming, code, and diff buffers for query and jumps
+// to the first hit, switching center mode if the hit isn't in the buffer
+// currently on screen.
 func (m *Model) runSearch(query string) bool {
 	query = strings.TrimSpace(query)
 	if query == "" {
@@ -709,46 +1260,51 @@ func (m *Model) runSearch(query string) bool {
 		m.SearchQuery = ""
 		m.SearchResults = nil
 		m.SearchIndex = 0
-		m.SearchTarget = SearchTargetNone
 		return false
 	}
-	target := m.searchTargetForView()
-	if target == SearchTargetNone {
+	if m.Dashboard == nil {
+		return false
+	}
+
+	matches, err := m.searchMatcher(query)
+	if err != nil {
+		m.ShowToast(fmt.Sprintf("Invalid search regex: %v", err), widgets.ToastLevelWarning)
 		return false
 	}
-	content := m.searchContent(target)
-	lines := strings.Split(content, "\n")
-	results := make([]int, 0)
-	needle := strings.ToLower(query)
-	for i, line := range lines {
-		if strings.Contains(strings.ToLower(line), needle) {
-			results = append(results, i)
+
+	var results []SearchHit
+	for _, target := range searchableTargets {
+		lines := strings.Split(m.searchContent(target), "\n")
+		var hits []int
+		for i, line := range lines {
+			if matches(line) {
+				hits = append(hits, i)
+				results = append(results, SearchHit{Target: target, Line: i})
+			}
 		}
+		m.applySearchHighlights(target, hits)
 	}
+
 	m.SearchQuery = query
 	m.SearchResults = results
 	m.SearchIndex = 0
-	m.SearchTarget = target
-	m.applySearchHighlights(target, results)
 	if len(results) > 0 {
-		m.scrollToSearchResult(target, results[0])
+		m.jumpToHit(results[0])
 	}
 	return len(results) > 0
 }
 
+// jumpToHit switches to hit's buffer, if needed, and scrolls it into view.
+func (m *Model) jumpToHit(hit SearchHit) {
+	m.setCenterMode(hit.Target)
+	m.scrollToSearchResult(hit.Target, hit.Line)
+}
+
 func (m *Model) jumpSearch(delta int) {
 	if m.SearchQuery == "" {
 		m.ShowToast("No active search", widgets.ToastLevelInfo)
 		return
 	}
-	currentTarget := m.searchTargetForView()
-	if currentTarget == SearchTargetNone {
-		m.ShowToast("Search not available in this view", widgets.ToastLevelWarning)
-		return
-	}
-	if m.SearchTarget != currentTarget {
-		m.runSearch(m.SearchQuery)
-	}
 	if len(m.SearchResults) == 0 {
 		m.ShowToast("No matches", widgets.ToastLevelWarning)
 		return
@@ -757,9 +1313,9 @@ func (m *Model) jumpSearch(delta int) {
 	if m.SearchIndex < 0 {
 		m.SearchIndex = len(m.SearchResults) - 1
 	}
-	line := m.SearchResults[m.SearchIndex]
-	m.scrollToSearchResult(m.SearchTarget, line)
-	m.ShowToast(fmt.Sprintf("Match %d/%d", m.SearchIndex+1, len(m.SearchResults)), widgets.ToastLevelInfo)
+	hit := m.SearchResults[m.SearchIndex]
+	m.jumpToHit(hit)
+	m.ShowToast(fmt.Sprintf("Match %d/%d (%s)", m.SearchIndex+1, len(m.SearchResults), hit.Target), widgets.ToastLevelInfo)
 }
 
 func (m *Model) resetForReplay() {
@@ -772,7 +1328,6 @@ func (m *Model) resetForReplay() {
 	m.SearchQuery = ""
 	m.SearchResults = nil
 	m.SearchIndex = 0
-	m.SearchTarget = SearchTargetNone
 	m.SearchMode = false
 
 	if m.Dashboard != nil {
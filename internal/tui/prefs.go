@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"cooperations/internal/tui/styles"
+)
+
+// prefsFileName is the file preferences are persisted to under a Model's
+// PrefsDir.
+const prefsFileName = "tui_prefs.json"
+
+// Preferences captures dashboard layout choices that should survive across
+// TUI launches.
+type Preferences struct {
+	CenterMode  int     `json:"center_mode"`
+	RightMode   int     `json:"right_mode"`
+	ActivePanel int     `json:"active_panel"`
+	ReplaySpeed float64 `json:"replay_speed"`
+	FocusActive bool    `json:"focus_active"`
+	ZenActive   bool    `json:"zen_active"`
+	Theme       string  `json:"theme"`
+}
+
+// defaultPreferences returns the preferences a fresh install starts with.
+// The initial theme is guessed from the terminal's COLORFGBG env var rather
+// than hardcoded, so a light-background terminal doesn't default to Neon.
+func defaultPreferences() Preferences {
+	return Preferences{ReplaySpeed: 1.0, Theme: styles.DetectThemeName()}
+}
+
+// sanitized clamps any out-of-range values a corrupt or hand-edited
+// preferences file might contain, falling back to the matching default.
+func (p Preferences) sanitized() Preferences {
+	if p.CenterMode < 0 || p.CenterMode > 2 {
+		p.CenterMode = 0
+	}
+	if p.RightMode < 0 || p.RightMode > 2 {
+		p.RightMode = 0
+	}
+	if p.ActivePanel < 0 || p.ActivePanel > 2 {
+		p.ActivePanel = 0
+	}
+	if p.ReplaySpeed <= 0 {
+		p.ReplaySpeed = 1.0
+	}
+	if _, ok := styles.ByName(p.Theme); !ok {
+		p.Theme = styles.Registry[0].Name
+	}
+	return p
+}
+
+// prefsPath returns the path preferences are stored at under dir.
+func prefsPath(dir string) string {
+	return filepath.Join(dir, prefsFileName)
+}
+
+// loadPreferences reads preferences from dir, falling back to defaults if
+// the file is missing, unreadable, or corrupt.
+func loadPreferences(dir string) Preferences {
+	data, err := os.ReadFile(prefsPath(dir))
+	if err != nil {
+		return defaultPreferences()
+	}
+	var prefs Preferences
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return defaultPreferences()
+	}
+	return prefs.sanitized()
+}
+
+// savePreferences writes preferences to dir, creating it if necessary.
+func savePreferences(dir string, prefs Preferences) error {
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(prefsPath(dir), data, 0644)
+}
+
+// currentPreferences captures the model's live layout state as a
+// Preferences value ready to persist.
+func (m *Model) currentPreferences() Preferences {
+	prefs := Preferences{ReplaySpeed: m.ReplaySpeed, Theme: styles.CurrentName()}
+	if m.Dashboard != nil {
+		prefs.CenterMode = m.Dashboard.CenterMode
+		prefs.RightMode = m.Dashboard.RightMode
+		prefs.ActivePanel = m.Dashboard.ActivePanel
+	}
+	prefs.FocusActive = m.ViewMode == ViewModeFocus
+	prefs.ZenActive = m.ViewMode == ViewModeZen
+	return prefs
+}
+
+// savePrefsOnQuit persists the model's current layout preferences, unless
+// saving was disabled via --no-save-prefs.
+func (m *Model) savePrefsOnQuit() {
+	if !m.SavePrefs || m.PrefsDir == "" {
+		return
+	}
+	_ = savePreferences(m.PrefsDir, m.currentPreferences())
+}
+
+// applyPreferences restores a previously saved layout onto an initialized
+// model (Dashboard and views must already exist).
+func (m *Model) applyPreferences() {
+	if m.Dashboard != nil {
+		m.Dashboard.CenterMode = m.Prefs.CenterMode
+		m.Dashboard.RightMode = m.Prefs.RightMode
+		m.Dashboard.ActivePanel = m.Prefs.ActivePanel
+	}
+	m.ReplaySpeed = m.Prefs.ReplaySpeed
+	styles.SetByName(m.Prefs.Theme)
+	if m.ThemeOverride != "" {
+		// A --theme flag on the command line takes priority over whatever
+		// was saved to the preferences file.
+		styles.SetByName(m.ThemeOverride)
+	}
+	if m.Prefs.ZenActive {
+		m.ToggleZen()
+	} else if m.Prefs.FocusActive {
+		m.ToggleFocus()
+	}
+}
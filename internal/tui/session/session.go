@@ -2,15 +2,26 @@
 package session
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"cooperations/internal/tui/stream"
+	"cooperations/internal/types"
 )
 
+// trashDirName is the subdirectory under SessionDir that holds soft-deleted
+// sessions so Delete is recoverable by default.
+const trashDirName = ".trash"
+
 // Session represents a saved TUI session.
 type Session struct {
 	ID          string         `json:"id"`
@@ -54,8 +65,29 @@ type Manager struct {
 	SessionDir  string
 	Current     *Session
 	EventBuffer []SessionEvent
+
+	// DisableTrash makes Delete remove sessions permanently instead of
+	// moving them to the trash subdirectory. Off by default.
+	DisableTrash bool
+
+	// AutoCheckpoint creates a checkpoint at every handoff, named after the
+	// role transition, so sessions have resume/inspect points without
+	// manual CreateCheckpoint calls. On by default.
+	AutoCheckpoint bool
+
+	// MaxCheckpoints caps how many checkpoints a session keeps; the oldest
+	// are dropped once the cap is exceeded. 0 means unlimited.
+	MaxCheckpoints int
+
+	// decisionCheckpointCount numbers auto-created decision checkpoints
+	// ("Decision 1", "Decision 2", ...) within the current session.
+	decisionCheckpointCount int
 }
 
+// defaultMaxCheckpoints bounds automatic checkpoints so long-running
+// sessions don't accumulate an unbounded checkpoint list.
+const defaultMaxCheckpoints = 20
+
 // NewManager creates a new session manager.
 func NewManager(sessionDir string) (*Manager, error) {
 	// Create session directory if it doesn't exist
@@ -63,8 +95,18 @@ func NewManager(sessionDir string) (*Manager, error) {
 		return nil, fmt.Errorf("create session dir: %w", err)
 	}
 
+	maxCheckpoints := defaultMaxCheckpoints
+	if v := os.Getenv("COOP_SESSION_MAX_CHECKPOINTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			maxCheckpoints = n
+		}
+	}
+
 	return &Manager{
-		SessionDir: sessionDir,
+		SessionDir:     sessionDir,
+		DisableTrash:   os.Getenv("COOP_SESSION_NO_TRASH") != "",
+		AutoCheckpoint: os.Getenv("COOP_SESSION_NO_AUTO_CHECKPOINT") == "",
+		MaxCheckpoints: maxCheckpoints,
 	}, nil
 }
 
@@ -79,12 +121,36 @@ func (m *Manager) NewSession(task string) *Session {
 	}
 	m.Current = session
 	m.EventBuffer = nil
+	m.decisionCheckpointCount = 0
 	return session
 }
 
-// generateSessionID creates a unique session ID.
+// sessionIDCounter makes generateSessionID collision-resistant for IDs
+// minted within the same nanosecond tick, which UnixNano alone can't
+// distinguish when sessions are created in a tight loop.
+var sessionIDCounter uint64
+
+// generateSessionID creates a unique, time-sortable session ID: a
+// nanosecond timestamp followed by a monotonic counter and a short random
+// suffix, so two IDs minted in the same tick never collide. IDs are opaque
+// strings, never parsed back into a timestamp, so existing "session_<int>"
+// IDs on disk stay perfectly loadable alongside new ones.
 func generateSessionID() string {
-	return fmt.Sprintf("session_%d", time.Now().UnixNano())
+	seq := atomic.AddUint64(&sessionIDCounter, 1)
+	return fmt.Sprintf("session_%d-%d-%s", time.Now().UnixNano(), seq, randomSessionIDSuffix())
+}
+
+// randomSessionIDSuffix returns a short random hex string for
+// generateSessionID and CreateCheckpoint's IDs.
+func randomSessionIDSuffix() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand failing means the OS entropy source is broken; the
+		// counter above still guarantees uniqueness, so degrade instead of
+		// failing ID generation outright.
+		return "0000"
+	}
+	return hex.EncodeToString(b[:])
 }
 
 // RecordEvent adds an event to the current session.
@@ -116,6 +182,7 @@ func (m *Manager) RecordStreamEvent(event interface{}) {
 		if m.Current != nil {
 			m.Current.Metrics.HandoffCount++
 		}
+		m.autoCheckpoint(e)
 	case stream.CodeUpdate:
 		m.RecordEvent("code", e)
 	case stream.FileDiff:
@@ -129,10 +196,18 @@ func (m *Manager) RecordStreamEvent(event interface{}) {
 			m.Current.Metrics.EstimatedCostUSD = e.EstimatedCostUSD
 			m.Current.Metrics.AgentCycles = e.AgentCycles
 		}
+	case stream.WorkflowSummary:
+		m.RecordEvent("summary", e)
+		if m.Current != nil {
+			m.Current.Metrics.TotalTokens = e.TotalTokens
+			m.Current.Metrics.EstimatedCostUSD = e.EstimatedCostUSD
+			m.Current.Metrics.Duration = e.ElapsedTime
+		}
 	case stream.ToastNotification:
 		m.RecordEvent("toast", e)
 	case stream.DecisionRequest:
 		m.RecordEvent("decision", e)
+		m.autoCheckpointDecision(e)
 	}
 }
 
@@ -143,7 +218,7 @@ func (m *Manager) CreateCheckpoint(name, description string) *Checkpoint {
 	}
 
 	checkpoint := Checkpoint{
-		ID:          fmt.Sprintf("cp_%d", time.Now().UnixNano()),
+		ID:          fmt.Sprintf("cp_%d-%d-%s", time.Now().UnixNano(), atomic.AddUint64(&sessionIDCounter, 1), randomSessionIDSuffix()),
 		Name:        name,
 		Timestamp:   time.Now(),
 		EventIndex:  len(m.Current.Events),
@@ -154,6 +229,140 @@ func (m *Manager) CreateCheckpoint(name, description string) *Checkpoint {
 	return &checkpoint
 }
 
+// autoCheckpoint creates a checkpoint named after a handoff's role
+// transition when AutoCheckpoint is enabled, then trims the oldest
+// checkpoints beyond MaxCheckpoints.
+func (m *Manager) autoCheckpoint(e stream.HandoffEvent) {
+	if !m.AutoCheckpoint || m.Current == nil {
+		return
+	}
+
+	m.CreateCheckpoint(fmt.Sprintf("%s->%s", e.From, e.To), e.Reason.String())
+	m.trimCheckpoints()
+}
+
+// autoCheckpointDecision creates a checkpoint named "Decision N" (N being
+// the decision's ordinal within the session) when a human decision point
+// is reached, so a run can later be replayed from "Decision 1" the same
+// way it can from a role handoff. Off unless AutoCheckpoint is enabled.
+func (m *Manager) autoCheckpointDecision(e stream.DecisionRequest) {
+	if !m.AutoCheckpoint || m.Current == nil {
+		return
+	}
+
+	m.decisionCheckpointCount++
+	m.CreateCheckpoint(fmt.Sprintf("Decision %d", m.decisionCheckpointCount), e.Title)
+	m.trimCheckpoints()
+}
+
+// trimCheckpoints drops the oldest checkpoints once the session exceeds
+// MaxCheckpoints, bounding how large a long-running session's checkpoint
+// list (and the file it's persisted to) can grow.
+func (m *Manager) trimCheckpoints() {
+	if m.MaxCheckpoints > 0 && len(m.Current.Checkpoints) > m.MaxCheckpoints {
+		excess := len(m.Current.Checkpoints) - m.MaxCheckpoints
+		m.Current.Checkpoints = m.Current.Checkpoints[excess:]
+	}
+}
+
+// CheckpointDiff summarizes the events recorded between two checkpoints.
+type CheckpointDiff struct {
+	FilesChanged []string
+	TokensSpent  int
+	Handoffs     int
+	EventCount   int
+}
+
+// BetweenCheckpoints summarizes the events in [a.EventIndex, b.EventIndex)
+// of the current session (the range is normalized if b precedes a): files
+// touched, tokens spent, and handoffs that occurred in that window.
+func (m *Manager) BetweenCheckpoints(a, b Checkpoint) (CheckpointDiff, error) {
+	if m.Current == nil {
+		return CheckpointDiff{}, fmt.Errorf("no current session")
+	}
+
+	start, end := a.EventIndex, b.EventIndex
+	if start > end {
+		start, end = end, start
+	}
+	if start < 0 || end > len(m.Current.Events) {
+		return CheckpointDiff{}, fmt.Errorf("checkpoint event range [%d, %d) out of bounds for %d events", start, end, len(m.Current.Events))
+	}
+
+	diff := CheckpointDiff{}
+	seenFiles := make(map[string]bool)
+	tokensBefore := m.lastTotalTokensBefore(start)
+	tokensAfter := tokensBefore
+
+	for _, event := range m.Current.Events[start:end] {
+		diff.EventCount++
+		switch event.Type {
+		case "code", "diff":
+			if path := eventPath(event.Data); path != "" && !seenFiles[path] {
+				seenFiles[path] = true
+				diff.FilesChanged = append(diff.FilesChanged, path)
+			}
+		case "handoff":
+			diff.Handoffs++
+		case "metrics":
+			if total, ok := eventTotalTokens(event.Data); ok {
+				tokensAfter = total
+			}
+		}
+	}
+
+	diff.TokensSpent = tokensAfter - tokensBefore
+	if diff.TokensSpent < 0 {
+		diff.TokensSpent = 0
+	}
+	return diff, nil
+}
+
+// lastTotalTokensBefore returns the TotalTokens of the last "metrics" event
+// before index, or 0 if there is none.
+func (m *Manager) lastTotalTokensBefore(index int) int {
+	for i := index - 1; i >= 0; i-- {
+		event := m.Current.Events[i]
+		if event.Type != "metrics" {
+			continue
+		}
+		if total, ok := eventTotalTokens(event.Data); ok {
+			return total
+		}
+	}
+	return 0
+}
+
+// eventPath extracts a file path from a recorded "code" or "diff" event,
+// whether Data is still the original typed stream event or has been
+// round-tripped through JSON into a map.
+func eventPath(data interface{}) string {
+	switch v := data.(type) {
+	case stream.CodeUpdate:
+		return v.Path
+	case stream.FileDiff:
+		return v.Path
+	case map[string]interface{}:
+		return getString(v, "path")
+	}
+	return ""
+}
+
+// eventTotalTokens extracts the TotalTokens field from a recorded "metrics"
+// event, whether Data is still the original typed stream event or has been
+// round-tripped through JSON into a map.
+func eventTotalTokens(data interface{}) (int, bool) {
+	switch v := data.(type) {
+	case stream.MetricsSnapshot:
+		return v.TotalTokens, true
+	case map[string]interface{}:
+		if _, ok := v["total_tokens"]; ok {
+			return getInt(v, "total_tokens"), true
+		}
+	}
+	return 0, false
+}
+
 // Save persists the current session to disk.
 func (m *Manager) Save() error {
 	if m.Current == nil {
@@ -193,6 +402,59 @@ func (m *Manager) Load(sessionID string) (*Session, error) {
 	return &session, nil
 }
 
+// recordedEvent mirrors the shape orchestrator.EventRecorder writes: one
+// JSON object per line with a timestamp, a broker event kind, and its
+// payload. It's declared here (rather than importing the orchestrator
+// package, which would create an import cycle) since only the JSON shape
+// is needed.
+type recordedEvent struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Kind      string      `json:"kind"`
+	Payload   interface{} `json:"payload"`
+}
+
+// LoadEventLogFile reads a JSONL event log written by
+// orchestrator.EventRecorder and adapts it into a Session so it can be
+// replayed with Replay, reusing the same pacing and event-dispatch logic
+// as a saved TUI session. Lines with a kind Replay doesn't recognize are
+// kept in the resulting Session (replayEvent's switch silently ignores
+// them), so a log containing newer event types than this build knows
+// about still replays everything it does understand.
+func LoadEventLogFile(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read event log: %w", err)
+	}
+
+	session := &Session{
+		ID:     strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Status: "complete",
+	}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var rec recordedEvent
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			return nil, fmt.Errorf("parse event log line %d: %w", i+1, err)
+		}
+		session.Events = append(session.Events, SessionEvent{
+			Timestamp: rec.Timestamp,
+			Type:      rec.Kind,
+			Data:      rec.Payload,
+		})
+	}
+
+	if len(session.Events) > 0 {
+		session.CreatedAt = session.Events[0].Timestamp
+		session.UpdatedAt = session.Events[len(session.Events)-1].Timestamp
+	}
+
+	return session, nil
+}
+
 // List returns all saved sessions.
 func (m *Manager) List() ([]Session, error) {
 	entries, err := os.ReadDir(m.SessionDir)
@@ -222,10 +484,108 @@ func (m *Manager) List() ([]Session, error) {
 	return sessions, nil
 }
 
-// Delete removes a session from disk.
-func (m *Manager) Delete(sessionID string) error {
-	filename := filepath.Join(m.SessionDir, sessionID+".json")
-	return os.Remove(filename)
+// ResolveSessionID finds the full session ID matching prefix. An exact
+// match always wins; otherwise prefix must identify exactly one session,
+// or an error is returned (not found, or ambiguous with the candidates
+// listed).
+func (m *Manager) ResolveSessionID(prefix string) (string, error) {
+	if _, err := os.Stat(filepath.Join(m.SessionDir, prefix+".json")); err == nil {
+		return prefix, nil
+	}
+
+	entries, err := os.ReadDir(m.SessionDir)
+	if err != nil {
+		return "", fmt.Errorf("read session dir: %w", err)
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		id := strings.TrimSuffix(entry.Name(), ".json")
+		if strings.HasPrefix(id, prefix) {
+			matches = append(matches, id)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no session found matching %q", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", fmt.Errorf("ambiguous session id %q matches: %s", prefix, strings.Join(matches, ", "))
+	}
+}
+
+// Delete removes a session, resolving sessionIDOrPrefix by exact ID or
+// unambiguous prefix. By default the session file is moved to a trash
+// subdirectory so it can be recovered; set DisableTrash to hard-delete
+// instead.
+func (m *Manager) Delete(sessionIDOrPrefix string) error {
+	id, err := m.ResolveSessionID(sessionIDOrPrefix)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(m.SessionDir, id+".json")
+
+	if m.DisableTrash {
+		if err := os.Remove(filename); err != nil {
+			return err
+		}
+	} else {
+		trashDir := filepath.Join(m.SessionDir, trashDirName)
+		if err := os.MkdirAll(trashDir, 0755); err != nil {
+			return fmt.Errorf("create trash dir: %w", err)
+		}
+		dest := filepath.Join(trashDir, fmt.Sprintf("%s.%d.json", id, time.Now().Unix()))
+		if err := os.Rename(filename, dest); err != nil {
+			return fmt.Errorf("move session to trash: %w", err)
+		}
+	}
+
+	if m.Current != nil && m.Current.ID == id {
+		m.Current = nil
+		m.EventBuffer = nil
+	}
+
+	return nil
+}
+
+// Rename updates a session's display name and re-saves it, resolving
+// sessionIDOrPrefix by exact ID or unambiguous prefix. If the session is
+// currently loaded, Manager.Current is updated in place so the rename is
+// reflected immediately without reloading.
+func (m *Manager) Rename(sessionIDOrPrefix, name string) error {
+	id, err := m.ResolveSessionID(sessionIDOrPrefix)
+	if err != nil {
+		return err
+	}
+
+	if m.Current != nil && m.Current.ID == id {
+		m.Current.Name = name
+		return m.Save()
+	}
+
+	s, err := m.Load(id)
+	if err != nil {
+		return err
+	}
+	s.Name = name
+	s.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal session: %w", err)
+	}
+	filename := filepath.Join(m.SessionDir, id+".json")
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("write session: %w", err)
+	}
+	return nil
 }
 
 // SetStatus updates the current session status.
@@ -236,6 +596,30 @@ func (m *Manager) SetStatus(status string) {
 	}
 }
 
+// FinalCodeArtifacts returns the last recorded content for each file path
+// touched by a "code" event in the session, i.e. the final state of every
+// generated file by the time the session ended. This is a package-level
+// function rather than a Manager method since it only reads the session
+// argument, mirroring LoadEventLogFile's standalone shape.
+func FinalCodeArtifacts(s *Session) map[string]string {
+	artifacts := make(map[string]string)
+	for _, event := range s.Events {
+		if event.Type != "code" {
+			continue
+		}
+		data, ok := event.Data.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path := getString(data, "path")
+		if path == "" {
+			continue
+		}
+		artifacts[path] = getString(data, "content")
+	}
+	return artifacts
+}
+
 // Replay replays events from a session to a stream.
 func (m *Manager) Replay(session *Session, s *stream.WorkflowStream, speed float64) error {
 	if len(session.Events) == 0 {
@@ -294,7 +678,7 @@ func (m *Manager) replayEvent(event SessionEvent, s *stream.WorkflowStream) {
 			s.SendHandoff(stream.HandoffEvent{
 				From:   getString(data, "from"),
 				To:     getString(data, "to"),
-				Reason: getString(data, "reason"),
+				Reason: getReason(data, "reason"),
 			})
 		}
 
@@ -364,3 +748,17 @@ func getBool(data map[string]interface{}, key string) bool {
 	}
 	return false
 }
+
+// getReason reconstructs a structured HandoffReason from a replayed event's
+// decoded JSON, which stores it as a nested {"kind": ..., "detail": ...}
+// object rather than a Go struct.
+func getReason(data map[string]interface{}, key string) types.HandoffReason {
+	nested, ok := data[key].(map[string]interface{})
+	if !ok {
+		return types.HandoffReason{}
+	}
+	return types.HandoffReason{
+		Kind:   types.HandoffReasonKind(getString(nested, "kind")),
+		Detail: getString(nested, "detail"),
+	}
+}
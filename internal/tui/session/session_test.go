@@ -0,0 +1,166 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cooperations/internal/tui/stream"
+)
+
+func TestManager_BetweenCheckpoints(t *testing.T) {
+	m := &Manager{}
+	m.Current = &Session{ID: "test"}
+
+	record := func(eventType string, data interface{}) {
+		m.Current.Events = append(m.Current.Events, SessionEvent{Type: eventType, Data: data})
+	}
+
+	cpStart := Checkpoint{EventIndex: len(m.Current.Events)}
+	record("handoff", stream.HandoffEvent{From: "architect", To: "implementer"})
+	record("code", stream.CodeUpdate{Path: "main.go"})
+	record("metrics", stream.MetricsSnapshot{TotalTokens: 100})
+	record("code", stream.CodeUpdate{Path: "main.go"}) // same file touched twice
+	record("code", stream.CodeUpdate{Path: "util.go"})
+	record("handoff", stream.HandoffEvent{From: "implementer", To: "reviewer"})
+	record("metrics", stream.MetricsSnapshot{TotalTokens: 250})
+	cpEnd := Checkpoint{EventIndex: len(m.Current.Events)}
+	record("code", stream.CodeUpdate{Path: "ignored.go"}) // outside the range
+
+	diff, err := m.BetweenCheckpoints(cpStart, cpEnd)
+	if err != nil {
+		t.Fatalf("BetweenCheckpoints returned error: %v", err)
+	}
+
+	if diff.EventCount != 7 {
+		t.Errorf("EventCount = %d, want 7", diff.EventCount)
+	}
+	if diff.Handoffs != 2 {
+		t.Errorf("Handoffs = %d, want 2", diff.Handoffs)
+	}
+	if diff.TokensSpent != 250 {
+		t.Errorf("TokensSpent = %d, want 250", diff.TokensSpent)
+	}
+	wantFiles := []string{"main.go", "util.go"}
+	if len(diff.FilesChanged) != len(wantFiles) {
+		t.Fatalf("FilesChanged = %v, want %v", diff.FilesChanged, wantFiles)
+	}
+	for i, f := range wantFiles {
+		if diff.FilesChanged[i] != f {
+			t.Errorf("FilesChanged[%d] = %q, want %q", i, diff.FilesChanged[i], f)
+		}
+	}
+}
+
+func TestManager_BetweenCheckpoints_ReversedOrder(t *testing.T) {
+	m := &Manager{}
+	m.Current = &Session{ID: "test"}
+
+	cpEnd := Checkpoint{EventIndex: 0}
+	m.Current.Events = append(m.Current.Events, SessionEvent{Type: "handoff", Data: stream.HandoffEvent{}})
+	cpStart := Checkpoint{EventIndex: len(m.Current.Events)}
+
+	diff, err := m.BetweenCheckpoints(cpStart, cpEnd)
+	if err != nil {
+		t.Fatalf("BetweenCheckpoints returned error: %v", err)
+	}
+	if diff.Handoffs != 1 {
+		t.Errorf("Handoffs = %d, want 1 (order of arguments should not matter)", diff.Handoffs)
+	}
+}
+
+func TestManager_BetweenCheckpoints_NoCurrentSession(t *testing.T) {
+	m := &Manager{}
+	if _, err := m.BetweenCheckpoints(Checkpoint{}, Checkpoint{}); err == nil {
+		t.Error("expected error when there is no current session")
+	}
+}
+
+func TestManager_BetweenCheckpoints_OutOfRange(t *testing.T) {
+	m := &Manager{}
+	m.Current = &Session{ID: "test"}
+	if _, err := m.BetweenCheckpoints(Checkpoint{EventIndex: 0}, Checkpoint{EventIndex: 5}); err == nil {
+		t.Error("expected error when the range exceeds the recorded events")
+	}
+}
+
+func TestManager_RecordStreamEvent_AutoCheckpoint(t *testing.T) {
+	m := &Manager{AutoCheckpoint: true, MaxCheckpoints: 2}
+	m.Current = &Session{ID: "test"}
+
+	m.RecordStreamEvent(stream.HandoffEvent{From: "architect", To: "implementer"})
+	m.RecordStreamEvent(stream.HandoffEvent{From: "implementer", To: "reviewer"})
+	m.RecordStreamEvent(stream.HandoffEvent{From: "reviewer", To: "navigator"})
+
+	if len(m.Current.Checkpoints) != 2 {
+		t.Fatalf("Checkpoints = %d, want 2 (capped by MaxCheckpoints)", len(m.Current.Checkpoints))
+	}
+	// The oldest checkpoint (architect->implementer) should have been trimmed.
+	if got := m.Current.Checkpoints[0].Name; got != "implementer->reviewer" {
+		t.Errorf("oldest surviving checkpoint = %q, want %q", got, "implementer->reviewer")
+	}
+	if got := m.Current.Checkpoints[1].Name; got != "reviewer->navigator" {
+		t.Errorf("newest checkpoint = %q, want %q", got, "reviewer->navigator")
+	}
+}
+
+func TestManager_RecordStreamEvent_AutoCheckpointDisabled(t *testing.T) {
+	m := &Manager{AutoCheckpoint: false}
+	m.Current = &Session{ID: "test"}
+
+	m.RecordStreamEvent(stream.HandoffEvent{From: "architect", To: "implementer"})
+
+	if len(m.Current.Checkpoints) != 0 {
+		t.Errorf("Checkpoints = %d, want 0 when AutoCheckpoint is disabled", len(m.Current.Checkpoints))
+	}
+}
+
+func TestManager_RecordStreamEvent_AutoCheckpointDecision(t *testing.T) {
+	m := &Manager{AutoCheckpoint: true}
+	m.Current = &Session{ID: "test"}
+
+	m.RecordStreamEvent(stream.DecisionRequest{ID: "d1", Title: "Budget exceeded, continue?"})
+	m.RecordStreamEvent(stream.DecisionRequest{ID: "d2", Title: "Apply changes?"})
+
+	if len(m.Current.Checkpoints) != 2 {
+		t.Fatalf("Checkpoints = %d, want 2", len(m.Current.Checkpoints))
+	}
+	if got := m.Current.Checkpoints[0].Name; got != "Decision 1" {
+		t.Errorf("first checkpoint name = %q, want %q", got, "Decision 1")
+	}
+	if got := m.Current.Checkpoints[1].Name; got != "Decision 2" {
+		t.Errorf("second checkpoint name = %q, want %q", got, "Decision 2")
+	}
+}
+
+func TestLoadEventLogFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "task1.jsonl")
+	content := `{"timestamp":"2026-01-01T00:00:00Z","kind":"progress","payload":{"stage":"Starting"}}
+{"timestamp":"2026-01-01T00:00:01Z","kind":"handoff","payload":{"from":"user","to":"architect"}}
+{"timestamp":"2026-01-01T00:00:02Z","kind":"some_future_event","payload":{"anything":"goes"}}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadEventLogFile(path)
+	if err != nil {
+		t.Fatalf("LoadEventLogFile: %v", err)
+	}
+	if len(got.Events) != 3 {
+		t.Fatalf("Events = %d, want 3", len(got.Events))
+	}
+	if got.Events[0].Type != "progress" || got.Events[2].Type != "some_future_event" {
+		t.Errorf("unexpected event types: %+v", got.Events)
+	}
+
+	// Replaying must not fail even though the third event's kind is
+	// unknown - it should just be skipped by replayEvent's switch.
+	m := &Manager{}
+	s := stream.NewWorkflowStream()
+	defer s.Close()
+	if err := m.Replay(got, s, 1000); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package stream
+
+import (
+	"context"
+	"sync"
+)
+
+// BackpressurePolicy controls what a Broker subscriber does when its
+// buffer fills up.
+type BackpressurePolicy int
+
+const (
+	// PolicyDropOldest discards the subscriber's oldest buffered event to
+	// make room for the new one. The subscriber sees gaps under load but
+	// never stalls the publisher.
+	PolicyDropOldest BackpressurePolicy = iota
+	// PolicyBlock waits for the subscriber to make room, applying
+	// backpressure to the publisher instead of dropping anything. Publish
+	// unblocks early if its context is canceled.
+	PolicyBlock
+)
+
+// Event wraps one stream payload with the field name it would have been
+// sent on, so a single subscriber channel can carry every event type.
+type Event struct {
+	Kind    string
+	Payload any
+}
+
+// Broker fans an event out to any number of independent subscribers, each
+// with its own buffer and backpressure policy. Unlike WorkflowStream's
+// shared channels, one slow subscriber can't cause another to miss events.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[int]*brokerSubscriber
+	nextID      int
+}
+
+type brokerSubscriber struct {
+	mu     sync.Mutex
+	ch     chan Event
+	policy BackpressurePolicy
+	closed bool
+}
+
+// NewBroker creates an empty event broker.
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[int]*brokerSubscriber)}
+}
+
+// Subscribe registers a new consumer with the given buffer size and
+// backpressure policy. The returned unsubscribe function closes the
+// consumer's channel and must be called exactly once when it's done
+// reading. It's safe to call concurrently with an in-flight Publish: the
+// close is serialized against any send with sub's own mutex, so a
+// subscriber disconnecting mid-publish can't race a send on its channel.
+func (b *Broker) Subscribe(bufferSize int, policy BackpressurePolicy) (<-chan Event, func()) {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	sub := &brokerSubscriber{ch: make(chan Event, bufferSize), policy: policy}
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if s, ok := b.subscribers[id]; ok {
+			delete(b.subscribers, id)
+			s.close()
+		}
+	}
+	return sub.ch, unsubscribe
+}
+
+// Publish fans an event out to every current subscriber according to its
+// own backpressure policy. ctx cancellation unblocks any PolicyBlock
+// subscriber that isn't draining, so a stuck consumer can't hang a
+// publisher forever.
+func (b *Broker) Publish(ctx context.Context, kind string, payload any) {
+	b.mu.Lock()
+	subs := make([]*brokerSubscriber, 0, len(b.subscribers))
+	for _, s := range b.subscribers {
+		subs = append(subs, s)
+	}
+	b.mu.Unlock()
+
+	event := Event{Kind: kind, Payload: payload}
+	for _, s := range subs {
+		s.send(ctx, event)
+	}
+}
+
+// send delivers event to s.ch under s.mu, the same lock close uses, so a
+// concurrent unsubscribe can't close s.ch while a send is in flight.
+func (s *brokerSubscriber) send(ctx context.Context, event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	switch s.policy {
+	case PolicyBlock:
+		select {
+		case s.ch <- event:
+		case <-ctx.Done():
+		}
+	default: // PolicyDropOldest
+		for {
+			select {
+			case s.ch <- event:
+				return
+			default:
+			}
+			select {
+			case <-s.ch:
+			default:
+				return
+			}
+		}
+	}
+}
+
+// close closes s.ch exactly once, under s.mu, so it can't run concurrently
+// with a send.
+func (s *brokerSubscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
@@ -0,0 +1,56 @@
+package stream
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestBrokerPublishUnsubscribeRace exercises a subscriber unsubscribing
+// concurrently with an in-flight Publish. Before send and close shared a
+// lock, this reliably panicked with "send on closed channel" under
+// go test -race.
+func TestBrokerPublishUnsubscribeRace(t *testing.T) {
+	b := NewBroker()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		events, unsubscribe := b.Subscribe(1, PolicyDropOldest)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range events {
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			time.Sleep(time.Millisecond)
+			unsubscribe()
+		}()
+	}
+
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		b.Publish(context.Background(), "progress", "tick")
+	}
+
+	wg.Wait()
+}
+
+func TestBrokerSubscribeUnsubscribeDelivers(t *testing.T) {
+	b := NewBroker()
+	events, unsubscribe := b.Subscribe(1, PolicyDropOldest)
+	defer unsubscribe()
+
+	b.Publish(context.Background(), "progress", "hello")
+
+	select {
+	case evt := <-events:
+		if evt.Kind != "progress" || evt.Payload != "hello" {
+			t.Errorf("event = %+v, want Kind=progress Payload=hello", evt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
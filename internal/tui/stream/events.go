@@ -1,7 +1,12 @@
 // Package stream provides event types for TUI workflow streaming.
 package stream
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"cooperations/internal/types"
+)
 
 // TokenChunk represents a single token or small chunk from AI streaming response.
 type TokenChunk struct {
@@ -25,12 +30,14 @@ type ProgressUpdate struct {
 	Message string  `json:"message"`
 }
 
-// HandoffEvent represents a transition between agents.
+// HandoffEvent represents a transition between agents. Reason carries the
+// same structured classification as types.Handoff, rather than free-text
+// prose; call Reason.String() for display.
 type HandoffEvent struct {
-	From      string    `json:"from"`
-	To        string    `json:"to"`
-	Reason    string    `json:"reason"`
-	Timestamp time.Time `json:"timestamp"`
+	From      string              `json:"from"`
+	To        string              `json:"to"`
+	Reason    types.HandoffReason `json:"reason"`
+	Timestamp time.Time           `json:"timestamp"`
 }
 
 // AgentLogEntry is a detailed log entry from an agent.
@@ -92,6 +99,44 @@ type MetricsSnapshot struct {
 	CurrentAgent     string        `json:"current_agent"`
 }
 
+// AgentCompletion reports one agent call finishing, for consumers that
+// need per-call granularity (e.g. a duration histogram) beyond what
+// MetricsSnapshot's running totals give.
+type AgentCompletion struct {
+	Role       string `json:"role"`
+	DurationMS int64  `json:"duration_ms"`
+	TokensUsed int    `json:"tokens_used"`
+}
+
+// RoleStats aggregates one role's activity across a workflow run.
+type RoleStats struct {
+	Calls      int   `json:"calls"`
+	Tokens     int   `json:"tokens"`
+	DurationMS int64 `json:"duration_ms"`
+	Errors     int   `json:"errors"`
+}
+
+// PlanUpdate carries a workflow's previewed role sequence and cost/duration
+// estimate, sent once before the first agent runs so the TUI can show the
+// upcoming steps as pending ahead of time. See Orchestrator.Plan.
+type PlanUpdate struct {
+	Plan types.WorkflowPlan `json:"plan"`
+}
+
+// WorkflowSummary is emitted once when a workflow finishes, aggregating the
+// whole run beyond what the last MetricsSnapshot captured.
+type WorkflowSummary struct {
+	TotalTokens      int                  `json:"total_tokens"`
+	PromptTokens     int                  `json:"prompt_tokens"`
+	CompletionTokens int                  `json:"completion_tokens"`
+	EstimatedCostUSD float64              `json:"estimated_cost_usd"`
+	ElapsedTime      time.Duration        `json:"elapsed_time"`
+	ReviewCycles     int                  `json:"review_cycles"`
+	FilesChanged     int                  `json:"files_changed"`
+	RoleBreakdown    map[string]RoleStats `json:"role_breakdown,omitempty"`
+	Success          bool                 `json:"success"`
+}
+
 // ToastNotification is a non-blocking notification.
 type ToastNotification struct {
 	ID       string        `json:"id"`
@@ -103,10 +148,35 @@ type ToastNotification struct {
 
 // DecisionRequest asks a human to make a decision.
 type DecisionRequest struct {
-	ID      string   `json:"id"`
-	Title   string   `json:"title"`
-	Prompt  string   `json:"prompt"`
-	Options []string `json:"options"`
+	ID      string               `json:"id"`
+	Title   string               `json:"title"`
+	Prompt  string               `json:"prompt"`
+	Options []DecisionOptionSpec `json:"options"`
+
+	// Timeout, when non-zero, auto-selects DefaultAction if no human
+	// response arrives in time, so unattended runs don't block forever.
+	Timeout       time.Duration  `json:"timeout,omitempty"`
+	DefaultAction DecisionAction `json:"default_action,omitempty"`
+}
+
+// DecisionOptionSpec describes one choice offered in a DecisionRequest,
+// carrying enough detail for the TUI to render help text and warn before
+// destructive actions.
+type DecisionOptionSpec struct {
+	Key         string `json:"key,omitempty"`
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+	Danger      bool   `json:"danger,omitempty"`
+}
+
+// PlainOptions builds DecisionOptionSpecs from bare labels for callers that
+// don't need per-option descriptions or danger flags, keyed 1, 2, 3, ...
+func PlainOptions(labels ...string) []DecisionOptionSpec {
+	specs := make([]DecisionOptionSpec, len(labels))
+	for i, label := range labels {
+		specs[i] = DecisionOptionSpec{Key: fmt.Sprintf("%d", i+1), Label: label}
+	}
+	return specs
 }
 
 // DecisionAction describes what action was taken.
@@ -187,10 +257,10 @@ type RVREvent struct {
 
 // RVRResultEvent represents final RVR results for TUI display.
 type RVRResultEvent struct {
-	TaskType   string              `json:"task_type"`
-	Overall    float64             `json:"overall"`
-	Breakdown  []RVRBreakdownItem  `json:"breakdown"`
-	Caveats    []string            `json:"caveats"`
+	TaskType  string             `json:"task_type"`
+	Overall   float64            `json:"overall"`
+	Breakdown []RVRBreakdownItem `json:"breakdown"`
+	Caveats   []string           `json:"caveats"`
 }
 
 // RVRBreakdownItem represents a single chunk's RVR result.
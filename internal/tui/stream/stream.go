@@ -1,17 +1,29 @@
 package stream
 
 import (
+	"context"
 	"sync"
 	"time"
 )
 
 // WorkflowStream provides channels for streaming workflow events to TUI.
+//
+// Most Send* methods are best-effort: they use a non-blocking select and
+// silently drop the event if the channel's buffer is full, which is fine
+// for throwaway signals like Progress ticks and Thinking updates that are
+// superseded by the next one anyway. Tokens, Code, and Handoffs carry
+// output a caller can't afford to lose, so they also have SendXCtx
+// variants (SendTokenCtx, SendCodeCtx, SendHandoffCtx) that block until
+// there's room, unblocking early if the passed context is canceled.
+// Callers with a context in scope (the orchestrator's workflow loop)
+// should prefer those over the plain, lossy Send methods.
 type WorkflowStream struct {
 	// Real-time streaming
 	Tokens   chan TokenChunk
 	Thinking chan ThinkingUpdate
 
 	// Workflow events
+	Plan     chan PlanUpdate
 	Progress chan ProgressUpdate
 	Handoffs chan HandoffEvent
 	AgentLog chan AgentLogEntry
@@ -23,6 +35,7 @@ type WorkflowStream struct {
 
 	// Metrics
 	Metrics chan MetricsSnapshot
+	Summary chan WorkflowSummary
 
 	// Interaction
 	Decision chan DecisionRequest
@@ -36,10 +49,10 @@ type WorkflowStream struct {
 	Done       chan struct{}
 	Error      chan error
 	Pause      chan bool
-	Control    chan ControlEvent      // Bidirectional control signals
-	HookNotify chan HookNotification  // Hook state notifications
-	RVR        chan RVREvent          // RVR processing events
-	RVRResult  chan RVRResultEvent    // RVR final results
+	Control    chan ControlEvent     // Bidirectional control signals
+	HookNotify chan HookNotification // Hook state notifications
+	RVR        chan RVREvent         // RVR processing events
+	RVRResult  chan RVRResultEvent   // RVR final results
 
 	closeOnce sync.Once
 }
@@ -51,6 +64,7 @@ func NewWorkflowStream() *WorkflowStream {
 		Tokens:   make(chan TokenChunk, 100),
 		Thinking: make(chan ThinkingUpdate, 10),
 
+		Plan:     make(chan PlanUpdate, 1),
 		Progress: make(chan ProgressUpdate, 20),
 		Handoffs: make(chan HandoffEvent, 10),
 		AgentLog: make(chan AgentLogEntry, 50),
@@ -60,6 +74,7 @@ func NewWorkflowStream() *WorkflowStream {
 		FileTree: make(chan FileTreeUpdate, 20),
 
 		Metrics: make(chan MetricsSnapshot, 10),
+		Summary: make(chan WorkflowSummary, 1),
 
 		Decision: make(chan DecisionRequest, 1),
 		Response: make(chan HumanDecision, 1),
@@ -92,6 +107,7 @@ func (s *WorkflowStream) Close() {
 		close(s.FileDiff)
 		close(s.FileTree)
 		close(s.Metrics)
+		close(s.Summary)
 		close(s.Decision)
 		close(s.Response)
 		close(s.Toast)
@@ -114,6 +130,14 @@ func (s *WorkflowStream) SendToken(chunk TokenChunk) {
 	}
 }
 
+// SendPlan sends a workflow's previewed step sequence, non-blocking.
+func (s *WorkflowStream) SendPlan(p PlanUpdate) {
+	select {
+	case s.Plan <- p:
+	default:
+	}
+}
+
 // SendProgress sends a progress update, non-blocking.
 func (s *WorkflowStream) SendProgress(p ProgressUpdate) {
 	select {
@@ -138,6 +162,36 @@ func (s *WorkflowStream) SendCode(c CodeUpdate) {
 	}
 }
 
+// SendTokenCtx sends a token chunk, blocking until there's room in the
+// buffer or ctx is canceled. Use this instead of SendToken when losing a
+// chunk of streamed output would be user-visible.
+func (s *WorkflowStream) SendTokenCtx(ctx context.Context, chunk TokenChunk) {
+	select {
+	case s.Tokens <- chunk:
+	case <-ctx.Done():
+	}
+}
+
+// SendCodeCtx sends a code update, blocking until there's room in the
+// buffer or ctx is canceled. Use this instead of SendCode when the
+// generated code itself must not be dropped.
+func (s *WorkflowStream) SendCodeCtx(ctx context.Context, c CodeUpdate) {
+	select {
+	case s.Code <- c:
+	case <-ctx.Done():
+	}
+}
+
+// SendHandoffCtx sends a handoff event, blocking until there's room in the
+// buffer or ctx is canceled. Use this instead of SendHandoff when the
+// handoff history must stay complete for diagrams and audits.
+func (s *WorkflowStream) SendHandoffCtx(ctx context.Context, h HandoffEvent) {
+	select {
+	case s.Handoffs <- h:
+	case <-ctx.Done():
+	}
+}
+
 // SendMetrics sends a metrics snapshot, non-blocking.
 func (s *WorkflowStream) SendMetrics(m MetricsSnapshot) {
 	select {
@@ -146,6 +200,14 @@ func (s *WorkflowStream) SendMetrics(m MetricsSnapshot) {
 	}
 }
 
+// SendSummary sends the final workflow summary, non-blocking.
+func (s *WorkflowStream) SendSummary(sum WorkflowSummary) {
+	select {
+	case s.Summary <- sum:
+	default:
+	}
+}
+
 // SendToast sends a toast notification, non-blocking.
 func (s *WorkflowStream) SendToast(t ToastNotification) {
 	select {
@@ -162,10 +224,26 @@ func (s *WorkflowStream) SendLog(l AgentLogEntry) {
 	}
 }
 
-// RequestDecision sends a decision request and waits for response.
+// RequestDecision sends a decision request and waits for response. If d
+// has a Timeout set and no response arrives in time, it auto-selects
+// d.DefaultAction (defaulting to DecisionApprove) rather than blocking
+// forever.
 func (s *WorkflowStream) RequestDecision(d DecisionRequest) HumanDecision {
 	s.Decision <- d
-	return <-s.Response
+	if d.Timeout <= 0 {
+		return <-s.Response
+	}
+
+	select {
+	case resp := <-s.Response:
+		return resp
+	case <-time.After(d.Timeout):
+		action := d.DefaultAction
+		if action == "" {
+			action = DecisionApprove
+		}
+		return HumanDecision{RequestID: d.ID, Action: action, Comment: "timed out, auto-selected default"}
+	}
 }
 
 // SignalDone signals workflow completion.
@@ -0,0 +1,124 @@
+package stream
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSendCodeCtxNoLossUnderFlood floods the Code channel far past its
+// buffer size using SendCodeCtx while a slow consumer drains it, and
+// asserts every update arrives - unlike SendCode, which would silently
+// drop updates once the buffer filled.
+func TestSendCodeCtxNoLossUnderFlood(t *testing.T) {
+	s := NewWorkflowStream()
+	const total = 500
+
+	received := make([]CodeUpdate, 0, total)
+	var mu sync.Mutex
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for i := 0; i < total; i++ {
+			u := <-s.Code
+			mu.Lock()
+			received = append(received, u)
+			mu.Unlock()
+		}
+	}()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.SendCodeCtx(ctx, CodeUpdate{Path: "file.go", Content: string(rune(i))})
+		}(i)
+	}
+	wg.Wait()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for consumer to drain all code updates")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != total {
+		t.Fatalf("expected %d code updates, got %d", total, len(received))
+	}
+}
+
+// TestSendHandoffCtxNoLossUnderFlood is the same flood scenario for
+// handoff events, which diagrams and audits rely on being complete.
+func TestSendHandoffCtxNoLossUnderFlood(t *testing.T) {
+	s := NewWorkflowStream()
+	const total = 500
+
+	count := 0
+	var mu sync.Mutex
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for i := 0; i < total; i++ {
+			<-s.Handoffs
+			mu.Lock()
+			count++
+			mu.Unlock()
+		}
+	}()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.SendHandoffCtx(ctx, HandoffEvent{From: "a", To: "b"})
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for consumer to drain all handoff events")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if count != total {
+		t.Fatalf("expected %d handoff events, got %d", total, count)
+	}
+}
+
+// TestSendCodeCtxRespectsCancellation confirms a blocked send gives up
+// promptly once its context is canceled, rather than hanging forever
+// when nobody is draining the channel.
+func TestSendCodeCtxRespectsCancellation(t *testing.T) {
+	s := NewWorkflowStream()
+	// Fill the buffer so the next send would block.
+	for i := 0; i < cap(s.Code); i++ {
+		s.Code <- CodeUpdate{}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.SendCodeCtx(ctx, CodeUpdate{})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("SendCodeCtx did not return after context cancellation")
+	}
+}
@@ -0,0 +1,130 @@
+// Package streamlog writes workflow stream events to an NDJSON file for
+// offline timeline reconstruction and other external analysis.
+package streamlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"cooperations/internal/tui/stream"
+)
+
+// envelopeVersion is bumped whenever the on-disk record shape changes so
+// downstream consumers can detect incompatible logs.
+const envelopeVersion = 1
+
+// header is the first line written to the log, recording the run's start
+// time once so every later record's ElapsedMS can be recomputed without
+// re-reading the whole file.
+type header struct {
+	Version  int       `json:"version"`
+	Type     string    `json:"type"`
+	RunStart time.Time `json:"run_start"`
+}
+
+// record is a single NDJSON line for one stream event.
+type record struct {
+	Version   int       `json:"version"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	ElapsedMS int64     `json:"elapsed_ms"`
+	Data      any       `json:"data"`
+}
+
+// Writer appends stream events to an NDJSON file, one JSON object per line.
+type Writer struct {
+	mu       sync.Mutex
+	f        *os.File
+	enc      *json.Encoder
+	runStart time.Time
+}
+
+// New creates (or truncates) the file at path and writes the run-start
+// header as its first line.
+func New(path string) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open stream log: %w", err)
+	}
+
+	runStart := time.Now()
+	w := &Writer{f: f, enc: json.NewEncoder(f), runStart: runStart}
+	if err := w.enc.Encode(header{Version: envelopeVersion, Type: "run_start", RunStart: runStart}); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("write stream log header: %w", err)
+	}
+	return w, nil
+}
+
+// Log appends event, tagged with its stream type name, the absolute
+// timestamp, and the milliseconds elapsed since the run started.
+func (w *Writer) Log(event any) {
+	if w == nil {
+		return
+	}
+
+	now := time.Now()
+	rec := record{
+		Version:   envelopeVersion,
+		Type:      eventType(event),
+		Timestamp: now,
+		ElapsedMS: now.Sub(w.runStart).Milliseconds(),
+		Data:      event,
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_ = w.enc.Encode(rec)
+}
+
+// Close flushes and closes the underlying file.
+func (w *Writer) Close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
+
+// eventType maps a stream event to the short type name used in session.go's
+// RecordStreamEvent, so the two logs stay consistent.
+func eventType(event any) string {
+	switch event.(type) {
+	case stream.TokenChunk:
+		return "token"
+	case stream.ProgressUpdate:
+		return "progress"
+	case stream.HandoffEvent:
+		return "handoff"
+	case stream.CodeUpdate:
+		return "code"
+	case stream.FileDiff:
+		return "diff"
+	case stream.FileTreeUpdate:
+		return "file_tree"
+	case stream.AgentLogEntry:
+		return "log"
+	case stream.MetricsSnapshot:
+		return "metrics"
+	case stream.ThinkingUpdate:
+		return "thinking"
+	case stream.ToastNotification:
+		return "toast"
+	case stream.DecisionRequest:
+		return "decision"
+	case stream.SessionEvent:
+		return "session"
+	case stream.HookNotification:
+		return "hook"
+	case stream.RVREvent:
+		return "rvr"
+	case stream.RVRResultEvent:
+		return "rvr_result"
+	default:
+		return "unknown"
+	}
+}
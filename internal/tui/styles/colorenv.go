@@ -0,0 +1,50 @@
+package styles
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// noColor mirrors the NO_COLOR convention (https://no-color.org): when the
+// env var is set to any non-empty value, all styling collapses to plain
+// text, which helps when piping TUI output or running it in CI logs.
+var noColor = os.Getenv("NO_COLOR") != ""
+
+// NoColorEnabled reports whether NO_COLOR disabled styling.
+func NoColorEnabled() bool {
+	return noColor
+}
+
+// Names returns the registered theme names, in Registry order.
+func Names() []string {
+	names := make([]string, len(Registry))
+	for i, nt := range Registry {
+		names[i] = nt.Name
+	}
+	return names
+}
+
+// DetectThemeName guesses a starting theme from the COLORFGBG env var some
+// terminals set to describe their default foreground/background colors
+// (e.g. "15;0" for light-on-dark, "0;15" for dark-on-light). It falls back
+// to the first registered theme ("dark") when COLORFGBG is unset or
+// unparseable.
+func DetectThemeName() string {
+	fgbg := os.Getenv("COLORFGBG")
+	parts := strings.Split(fgbg, ";")
+	if len(parts) < 2 {
+		return Registry[0].Name
+	}
+
+	bg, err := strconv.Atoi(strings.TrimSpace(parts[len(parts)-1]))
+	if err != nil {
+		return Registry[0].Name
+	}
+
+	// ANSI colors 7 (white) and 15 (bright white) are light backgrounds.
+	if bg == 7 || bg == 15 {
+		return "light"
+	}
+	return Registry[0].Name
+}
@@ -2,72 +2,170 @@ package styles
 
 import "github.com/charmbracelet/lipgloss"
 
-// Pre-built styles using the current theme.
+// Pre-built styles using the current theme. rebuild (called from init and
+// whenever the theme changes via SetByName/Cycle) reassigns all of these,
+// so callers can keep referencing e.g. styles.TitleStyle without needing to
+// re-fetch it after a theme switch.
 var (
 	// Base styles
-	BaseStyle = lipgloss.NewStyle().
-			Background(Current.Background).
-			Foreground(Current.Foreground)
+	BaseStyle lipgloss.Style
 
 	// Title and headers
+	TitleStyle     lipgloss.Style
+	HeaderStyle    lipgloss.Style
+	SubHeaderStyle lipgloss.Style
+
+	// Panel styles
+	PanelStyle       lipgloss.Style
+	ActivePanelStyle lipgloss.Style
+
+	// Status styles
+	StatusRunning  lipgloss.Style
+	StatusComplete lipgloss.Style
+	StatusError    lipgloss.Style
+	StatusWaiting  lipgloss.Style
+
+	// Text styles
+	MutedStyle     lipgloss.Style
+	AccentStyle    lipgloss.Style
+	PrimaryStyle   lipgloss.Style
+	SecondaryStyle lipgloss.Style
+
+	// Log level styles
+	LogInfo  lipgloss.Style
+	LogWarn  lipgloss.Style
+	LogError lipgloss.Style
+	LogDebug lipgloss.Style
+
+	// Diff styles
+	DiffAdd     lipgloss.Style
+	DiffRemove  lipgloss.Style
+	DiffContext lipgloss.Style
+
+	// Agent styles
+	AgentArchitectStyle   lipgloss.Style
+	AgentImplementerStyle lipgloss.Style
+	AgentReviewerStyle    lipgloss.Style
+	AgentNavigatorStyle   lipgloss.Style
+
+	// Button styles
+	ButtonStyle       lipgloss.Style
+	ButtonActiveStyle lipgloss.Style
+
+	// Help style
+	HelpKeyStyle  lipgloss.Style
+	HelpDescStyle lipgloss.Style
+
+	// Toast styles
+	ToastInfo    lipgloss.Style
+	ToastSuccess lipgloss.Style
+	ToastWarning lipgloss.Style
+	ToastError   lipgloss.Style
+)
+
+// rebuild recomputes every pre-built style above from Current. It must run
+// once at package init and again any time Current changes.
+//
+// When NO_COLOR is set, every var above is instead reset to a bare,
+// unstyled lipgloss.Style so rendering produces plain text - no color, no
+// bold, no borders - which keeps piped TUI output and CI logs readable.
+func rebuild() {
+	if noColor {
+		plain := lipgloss.NewStyle()
+		BaseStyle = plain
+		TitleStyle = plain
+		HeaderStyle = plain
+		SubHeaderStyle = plain
+		PanelStyle = plain
+		ActivePanelStyle = plain
+		StatusRunning = plain
+		StatusComplete = plain
+		StatusError = plain
+		StatusWaiting = plain
+		MutedStyle = plain
+		AccentStyle = plain
+		PrimaryStyle = plain
+		SecondaryStyle = plain
+		LogInfo = plain
+		LogWarn = plain
+		LogError = plain
+		LogDebug = plain
+		DiffAdd = plain
+		DiffRemove = plain
+		DiffContext = plain
+		AgentArchitectStyle = plain
+		AgentImplementerStyle = plain
+		AgentReviewerStyle = plain
+		AgentNavigatorStyle = plain
+		ButtonStyle = plain
+		ButtonActiveStyle = plain
+		HelpKeyStyle = plain
+		HelpDescStyle = plain
+		ToastInfo = plain
+		ToastSuccess = plain
+		ToastWarning = plain
+		ToastError = plain
+		return
+	}
+
+	BaseStyle = lipgloss.NewStyle().
+		Background(Current.Background).
+		Foreground(Current.Foreground)
+
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Current.Primary).
-			Padding(0, 1)
+		Bold(true).
+		Foreground(Current.Primary).
+		Padding(0, 1)
 
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Current.Primary).
-			BorderBottom(true).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(Current.Border)
+		Bold(true).
+		Foreground(Current.Primary).
+		BorderBottom(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(Current.Border)
 
 	SubHeaderStyle = lipgloss.NewStyle().
-			Foreground(Current.Secondary).
-			Bold(true)
+		Foreground(Current.Secondary).
+		Bold(true)
 
-	// Panel styles
 	PanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Current.Border).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Current.Border).
+		Padding(0, 1)
 
 	ActivePanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(Current.Primary).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Current.Primary).
+		Padding(0, 1)
 
-	// Status styles
 	StatusRunning = lipgloss.NewStyle().
-			Foreground(Current.Primary).
-			Bold(true)
+		Foreground(Current.Primary).
+		Bold(true)
 
 	StatusComplete = lipgloss.NewStyle().
-			Foreground(Current.Success).
-			Bold(true)
+		Foreground(Current.Success).
+		Bold(true)
 
 	StatusError = lipgloss.NewStyle().
-			Foreground(Current.Error).
-			Bold(true)
+		Foreground(Current.Error).
+		Bold(true)
 
 	StatusWaiting = lipgloss.NewStyle().
-			Foreground(Current.Warning).
-			Bold(true)
+		Foreground(Current.Warning).
+		Bold(true)
 
-	// Text styles
 	MutedStyle = lipgloss.NewStyle().
-			Foreground(Current.Muted)
+		Foreground(Current.Muted)
 
 	AccentStyle = lipgloss.NewStyle().
-			Foreground(Current.Accent)
+		Foreground(Current.Accent)
 
 	PrimaryStyle = lipgloss.NewStyle().
-			Foreground(Current.Primary)
+		Foreground(Current.Primary)
 
 	SecondaryStyle = lipgloss.NewStyle().
-			Foreground(Current.Secondary)
+		Foreground(Current.Secondary)
 
-	// Log level styles
 	LogInfo = lipgloss.NewStyle().
 		Foreground(Current.Info)
 
@@ -80,7 +178,6 @@ var (
 	LogDebug = lipgloss.NewStyle().
 		Foreground(Current.Muted)
 
-	// Diff styles
 	DiffAdd = lipgloss.NewStyle().
 		Foreground(Current.Success)
 
@@ -88,68 +185,64 @@ var (
 		Foreground(Current.Error)
 
 	DiffContext = lipgloss.NewStyle().
-			Foreground(Current.Muted)
+		Foreground(Current.Muted)
 
-	// Agent styles
 	AgentArchitectStyle = lipgloss.NewStyle().
-				Foreground(Current.AgentArchitect).
-				Bold(true)
+		Foreground(Current.AgentArchitect).
+		Bold(true)
 
 	AgentImplementerStyle = lipgloss.NewStyle().
-				Foreground(Current.AgentImplementer).
-				Bold(true)
+		Foreground(Current.AgentImplementer).
+		Bold(true)
 
 	AgentReviewerStyle = lipgloss.NewStyle().
-				Foreground(Current.AgentReviewer).
-				Bold(true)
+		Foreground(Current.AgentReviewer).
+		Bold(true)
 
 	AgentNavigatorStyle = lipgloss.NewStyle().
-				Foreground(Current.AgentNavigator).
-				Bold(true)
+		Foreground(Current.AgentNavigator).
+		Bold(true)
 
-	// Button styles
 	ButtonStyle = lipgloss.NewStyle().
-			Foreground(Current.Foreground).
-			Background(Current.Border).
-			Padding(0, 2).
-			Margin(0, 1)
+		Foreground(Current.Foreground).
+		Background(Current.Border).
+		Padding(0, 2).
+		Margin(0, 1)
 
 	ButtonActiveStyle = lipgloss.NewStyle().
-				Foreground(Current.Background).
-				Background(Current.Primary).
-				Padding(0, 2).
-				Margin(0, 1).
-				Bold(true)
+		Foreground(Current.Background).
+		Background(Current.Primary).
+		Padding(0, 2).
+		Margin(0, 1).
+		Bold(true)
 
-	// Help style
 	HelpKeyStyle = lipgloss.NewStyle().
-			Foreground(Current.Primary).
-			Bold(true)
+		Foreground(Current.Primary).
+		Bold(true)
 
 	HelpDescStyle = lipgloss.NewStyle().
-			Foreground(Current.Muted)
+		Foreground(Current.Muted)
 
-	// Toast styles
 	ToastInfo = lipgloss.NewStyle().
 		Foreground(Current.Foreground).
 		Background(Current.Info).
 		Padding(0, 1)
 
 	ToastSuccess = lipgloss.NewStyle().
-			Foreground(Current.Background).
-			Background(Current.Success).
-			Padding(0, 1)
+		Foreground(Current.Background).
+		Background(Current.Success).
+		Padding(0, 1)
 
 	ToastWarning = lipgloss.NewStyle().
-			Foreground(Current.Background).
-			Background(Current.Warning).
-			Padding(0, 1)
+		Foreground(Current.Background).
+		Background(Current.Warning).
+		Padding(0, 1)
 
 	ToastError = lipgloss.NewStyle().
 		Foreground(Current.Foreground).
 		Background(Current.Error).
 		Padding(0, 1)
-)
+}
 
 // AgentStyle returns the style for a given agent role.
 func AgentStyle(role string) lipgloss.Style {
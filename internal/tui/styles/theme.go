@@ -1,26 +1,30 @@
 // Package styles provides theming for the TUI.
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Theme defines the color scheme and styles for TUI.
 type Theme struct {
 	// Base colors
-	Background    lipgloss.Color
-	Foreground    lipgloss.Color
-	Muted         lipgloss.Color
-	Border        lipgloss.Color
+	Background lipgloss.Color
+	Foreground lipgloss.Color
+	Muted      lipgloss.Color
+	Border     lipgloss.Color
 
 	// Accent colors
-	Primary       lipgloss.Color
-	Secondary     lipgloss.Color
-	Accent        lipgloss.Color
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Accent    lipgloss.Color
 
 	// Status colors
-	Success       lipgloss.Color
-	Warning       lipgloss.Color
-	Error         lipgloss.Color
-	Info          lipgloss.Color
+	Success lipgloss.Color
+	Warning lipgloss.Color
+	Error   lipgloss.Color
+	Info    lipgloss.Color
 
 	// Agent colors
 	AgentArchitect   lipgloss.Color
@@ -31,19 +35,19 @@ type Theme struct {
 
 // Neon is the default cyberpunk neon theme.
 var Neon = Theme{
-	Background:    lipgloss.Color("#0a0e17"),
-	Foreground:    lipgloss.Color("#e0e0e0"),
-	Muted:         lipgloss.Color("#4a5568"),
-	Border:        lipgloss.Color("#1a2332"),
+	Background: lipgloss.Color("#0a0e17"),
+	Foreground: lipgloss.Color("#e0e0e0"),
+	Muted:      lipgloss.Color("#4a5568"),
+	Border:     lipgloss.Color("#1a2332"),
 
-	Primary:       lipgloss.Color("#00ffff"), // Cyan
-	Secondary:     lipgloss.Color("#ff00ff"), // Magenta
-	Accent:        lipgloss.Color("#00ff88"), // Neon green
+	Primary:   lipgloss.Color("#00ffff"), // Cyan
+	Secondary: lipgloss.Color("#ff00ff"), // Magenta
+	Accent:    lipgloss.Color("#00ff88"), // Neon green
 
-	Success:       lipgloss.Color("#00ff88"),
-	Warning:       lipgloss.Color("#ffaa00"),
-	Error:         lipgloss.Color("#ff4466"),
-	Info:          lipgloss.Color("#00aaff"),
+	Success: lipgloss.Color("#00ff88"),
+	Warning: lipgloss.Color("#ffaa00"),
+	Error:   lipgloss.Color("#ff4466"),
+	Info:    lipgloss.Color("#00aaff"),
 
 	AgentArchitect:   lipgloss.Color("#00ffff"),
 	AgentImplementer: lipgloss.Color("#00ff88"),
@@ -51,5 +55,103 @@ var Neon = Theme{
 	AgentNavigator:   lipgloss.Color("#ff00ff"),
 }
 
-// Current is the active theme.
+// Light is a light-background theme for terminals where the neon palette
+// renders poorly. It keeps roughly the same accent hues as Neon, darkened
+// for contrast against a light background, so diff/status colors stay
+// recognizable across both themes.
+var Light = Theme{
+	Background: lipgloss.Color("#f5f5f5"),
+	Foreground: lipgloss.Color("#1a1a1a"),
+	Muted:      lipgloss.Color("#6b7280"),
+	Border:     lipgloss.Color("#c7ccd1"),
+
+	Primary:   lipgloss.Color("#0086b3"),
+	Secondary: lipgloss.Color("#a3009c"),
+	Accent:    lipgloss.Color("#0a8f4f"),
+
+	Success: lipgloss.Color("#0a8f4f"),
+	Warning: lipgloss.Color("#a15c00"),
+	Error:   lipgloss.Color("#cc2222"),
+	Info:    lipgloss.Color("#0067c2"),
+
+	AgentArchitect:   lipgloss.Color("#0086b3"),
+	AgentImplementer: lipgloss.Color("#0a8f4f"),
+	AgentReviewer:    lipgloss.Color("#a15c00"),
+	AgentNavigator:   lipgloss.Color("#a3009c"),
+}
+
+// NamedTheme pairs a Theme with the name used to select it from the CLI
+// flag, the preferences file, or the in-app cycling shortcut.
+type NamedTheme struct {
+	Name  string
+	Theme Theme
+}
+
+// Registry lists the themes selectable by name, in the order Cycle steps
+// through them.
+var Registry = []NamedTheme{
+	{Name: "dark", Theme: Neon},
+	{Name: "light", Theme: Light},
+}
+
+// Current is the active theme. Prefer SetByName or Cycle over assigning
+// this directly so the pre-built styles in styles.go stay in sync.
 var Current = Neon
+
+// currentName tracks which Registry entry Current came from.
+var currentName = "dark"
+
+func init() {
+	rebuild()
+}
+
+// ByName returns the registered theme with the given name, matched
+// case-insensitively.
+func ByName(name string) (Theme, bool) {
+	for _, nt := range Registry {
+		if strings.EqualFold(nt.Name, name) {
+			return nt.Theme, true
+		}
+	}
+	return Theme{}, false
+}
+
+// SetByName switches Current to the named theme and rebuilds the
+// pre-built styles to match. It reports whether name was recognized;
+// unknown names leave the active theme unchanged.
+func SetByName(name string) bool {
+	t, ok := ByName(name)
+	if !ok {
+		return false
+	}
+	Current = t
+	currentName = strings.ToLower(name)
+	rebuild()
+	return true
+}
+
+// Cycle advances Current to the next theme in Registry, wrapping around,
+// and returns the name of the newly active theme.
+func Cycle() string {
+	for i, nt := range Registry {
+		if nt.Name == currentName {
+			next := Registry[(i+1)%len(Registry)]
+			Current = next.Theme
+			currentName = next.Name
+			rebuild()
+			return next.Name
+		}
+	}
+	// currentName isn't a registered theme (e.g. Current was assigned
+	// directly); fall back to the first registry entry.
+	first := Registry[0]
+	Current = first.Theme
+	currentName = first.Name
+	rebuild()
+	return first.Name
+}
+
+// CurrentName returns the name of the active theme.
+func CurrentName() string {
+	return currentName
+}
@@ -9,11 +9,28 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Below narrowWidthThreshold columns the three-panel 25/50/25 layout has no
+// room to breathe, so the dashboard stacks to a single full-width panel and
+// shows only the active one. Below shortHeightThreshold rows the agent
+// panel collapses each card to a single line so the workflow steps above it
+// stay visible.
+const (
+	narrowWidthThreshold = 100
+	shortHeightThreshold = 20
+)
+
 // DashboardView is the main dashboard view with three panels.
 type DashboardView struct {
 	Width  int
 	Height int
 
+	// Narrow is true when the terminal is too narrow for three side-by-side
+	// panels; the view stacks to a single full-width panel instead.
+	Narrow bool
+	// Short is true when the terminal is too short for full agent cards;
+	// the agent panel collapses to one line per agent.
+	Short bool
+
 	// Left panel - workflow and agents
 	WorkflowSteps *widgets.WorkflowSteps
 	AgentPanel    *widgets.AgentPanel
@@ -29,9 +46,9 @@ type DashboardView struct {
 	Metrics     *widgets.MetricsPanel
 
 	// Footer
-	ToastStack    *widgets.ToastStack
-	ProgressBar   *widgets.ProgressBar
-	CostTracker   *widgets.CostTracker
+	ToastStack  *widgets.ToastStack
+	ProgressBar *widgets.ProgressBar
+	CostTracker *widgets.CostTracker
 
 	// State
 	ActivePanel  int // 0=left, 1=center, 2=right
@@ -40,12 +57,24 @@ type DashboardView struct {
 	ShowProgress bool
 }
 
+// columnWidths returns the left/center/right panel widths for width. In
+// narrow mode only one panel is ever shown at a time, so each gets the
+// full width rather than a quarter of it.
+func columnWidths(width int, narrow bool) (left, center, right int) {
+	if narrow {
+		return width, width, width
+	}
+	left = width / 4
+	right = width / 4
+	center = width - left - right
+	return
+}
+
 // NewDashboardView creates a new dashboard view.
 func NewDashboardView(width, height int) *DashboardView {
-	// Calculate panel widths (25% - 50% - 25%)
-	leftWidth := width / 4
-	rightWidth := width / 4
-	centerWidth := width - leftWidth - rightWidth
+	narrow := width < narrowWidthThreshold
+	short := height < shortHeightThreshold
+	leftWidth, centerWidth, rightWidth := columnWidths(width, narrow)
 
 	// Calculate heights (subtract header and footer)
 	contentHeight := height - 4 // header + footer
@@ -53,6 +82,7 @@ func NewDashboardView(width, height int) *DashboardView {
 	// Create widgets
 	workflowSteps := widgets.NewWorkflowSteps(leftWidth - 2)
 	agentPanel := widgets.NewAgentPanel(leftWidth-2, contentHeight/2, 1)
+	agentPanel.Compact = short
 	agentPanel.InitAgents()
 
 	streamingText := widgets.NewStreamingText(centerWidth-4, contentHeight-2)
@@ -70,6 +100,8 @@ func NewDashboardView(width, height int) *DashboardView {
 	return &DashboardView{
 		Width:         width,
 		Height:        height,
+		Narrow:        narrow,
+		Short:         short,
 		WorkflowSteps: &workflowSteps,
 		AgentPanel:    &agentPanel,
 		StreamingText: &streamingText,
@@ -88,17 +120,18 @@ func NewDashboardView(width, height int) *DashboardView {
 func (d *DashboardView) Resize(width, height int) {
 	d.Width = width
 	d.Height = height
+	d.Narrow = width < narrowWidthThreshold
+	d.Short = height < shortHeightThreshold
 
 	// Recalculate panel dimensions
-	leftWidth := width / 4
-	rightWidth := width / 4
-	centerWidth := width - leftWidth - rightWidth
+	leftWidth, centerWidth, rightWidth := columnWidths(width, d.Narrow)
 	contentHeight := height - 4
 
 	// Update widget dimensions
 	d.WorkflowSteps.Width = leftWidth - 2
 	d.AgentPanel.Width = leftWidth - 2
 	d.AgentPanel.Height = contentHeight / 2
+	d.AgentPanel.Compact = d.Short
 
 	d.StreamingText.Width = centerWidth - 4
 	d.StreamingText.Height = contentHeight - 2
@@ -149,12 +182,17 @@ func (d *DashboardView) View() string {
 	result.WriteString(d.renderHeader())
 	result.WriteString("\n")
 
-	// Main content (3 panels)
-	leftPanel := d.renderLeftPanel()
-	centerPanel := d.renderCenterPanel()
-	rightPanel := d.renderRightPanel()
-
-	content := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, centerPanel, rightPanel)
+	// Main content: three side-by-side panels normally, or just the active
+	// one stacked full-width when the terminal is too narrow for all three.
+	var content string
+	if d.Narrow {
+		content = d.renderActivePanel() + "\n" + d.renderPanelTabs()
+	} else {
+		leftPanel := d.renderLeftPanel()
+		centerPanel := d.renderCenterPanel()
+		rightPanel := d.renderRightPanel()
+		content = lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, centerPanel, rightPanel)
+	}
 	result.WriteString(content)
 	result.WriteString("\n")
 
@@ -191,9 +229,38 @@ func (d *DashboardView) renderHeader() string {
 	return title + strings.Repeat(" ", padding) + badgeBar.View()
 }
 
+// renderActivePanel renders whichever panel is focused, at full width. Used
+// in narrow mode where there's no room to show all three side by side.
+func (d *DashboardView) renderActivePanel() string {
+	switch d.ActivePanel {
+	case 0:
+		return d.renderLeftPanel()
+	case 2:
+		return d.renderRightPanel()
+	default:
+		return d.renderCenterPanel()
+	}
+}
+
+// renderPanelTabs renders a tab-like indicator of which panel is active, so
+// the h/l/Tab navigation that switches panels still makes sense when only
+// one panel is visible at a time.
+func (d *DashboardView) renderPanelTabs() string {
+	names := []string{"Workflow", "Content", "Activity"}
+	var tabs []string
+	for i, name := range names {
+		if i == d.ActivePanel {
+			tabs = append(tabs, styles.PrimaryStyle.Render("["+name+"]"))
+		} else {
+			tabs = append(tabs, styles.MutedStyle.Render(name))
+		}
+	}
+	return strings.Join(tabs, " ")
+}
+
 // renderLeftPanel renders the left panel.
 func (d *DashboardView) renderLeftPanel() string {
-	width := d.Width / 4
+	width, _, _ := columnWidths(d.Width, d.Narrow)
 	height := d.Height - 4
 
 	var panelStyle lipgloss.Style
@@ -219,7 +286,7 @@ func (d *DashboardView) renderLeftPanel() string {
 
 // renderCenterPanel renders the center panel.
 func (d *DashboardView) renderCenterPanel() string {
-	width := d.Width - (d.Width/4)*2
+	_, width, _ := columnWidths(d.Width, d.Narrow)
 	height := d.Height - 4
 
 	var panelStyle lipgloss.Style
@@ -262,7 +329,7 @@ func (d *DashboardView) renderCenterPanel() string {
 
 // renderRightPanel renders the right panel.
 func (d *DashboardView) renderRightPanel() string {
-	width := d.Width / 4
+	_, _, width := columnWidths(d.Width, d.Narrow)
 	height := d.Height - 4
 
 	var panelStyle lipgloss.Style
@@ -279,6 +346,9 @@ func (d *DashboardView) renderRightPanel() string {
 	switch d.RightMode {
 	case 0:
 		header = styles.SubHeaderStyle.Render("Activity")
+		if label := d.ActivityLog.FilterLabel(); label != "" {
+			header += " " + styles.MutedStyle.Render("("+label+")")
+		}
 		content = d.ActivityLog.View()
 	case 1:
 		header = styles.SubHeaderStyle.Render("Files")
@@ -33,7 +33,7 @@ type FocusView struct {
 	ActivityLog   *widgets.ActivityLog
 
 	// Agent info
-	ActiveAgent string
+	ActiveAgent  string
 	AgentSpinner *widgets.AgentSpinner
 
 	// Progress
@@ -42,6 +42,14 @@ type FocusView struct {
 	// Mini metrics
 	TokenCount int
 	Duration   string
+
+	// Visual-line selection in code mode, started with 'V' and extended with
+	// the normal up/down bindings. SelectAnchor is the line where selection
+	// started; SelectCursor is the line it currently extends to. Both are
+	// 1-based line numbers matching CodeBlock.StartLine's numbering.
+	Selecting    bool
+	SelectAnchor int
+	SelectCursor int
 }
 
 // NewFocusView creates a new focus view.
@@ -89,6 +97,44 @@ func (f *FocusView) SetMode(mode FocusMode) {
 	f.Mode = mode
 }
 
+// StartSelection begins a visual-line selection anchored at line, replacing
+// any prior selection.
+func (f *FocusView) StartSelection(line int) {
+	f.Selecting = true
+	f.SelectAnchor = line
+	f.SelectCursor = line
+}
+
+// ExtendSelection moves the selection cursor by delta lines, clamped to
+// [1, maxLine].
+func (f *FocusView) ExtendSelection(delta, maxLine int) {
+	if !f.Selecting {
+		return
+	}
+	f.SelectCursor += delta
+	if f.SelectCursor < 1 {
+		f.SelectCursor = 1
+	}
+	if maxLine > 0 && f.SelectCursor > maxLine {
+		f.SelectCursor = maxLine
+	}
+}
+
+// ClearSelection ends the current visual-line selection.
+func (f *FocusView) ClearSelection() {
+	f.Selecting = false
+	f.SelectAnchor = 0
+	f.SelectCursor = 0
+}
+
+// SelectedRange returns the selection bounds in ascending order.
+func (f *FocusView) SelectedRange() (lo, hi int) {
+	if f.SelectAnchor <= f.SelectCursor {
+		return f.SelectAnchor, f.SelectCursor
+	}
+	return f.SelectCursor, f.SelectAnchor
+}
+
 // SetActiveAgent sets the currently active agent.
 func (f *FocusView) SetActiveAgent(role string) {
 	f.ActiveAgent = role
@@ -235,7 +281,7 @@ func (f *FocusView) renderFooter() string {
 	progress := f.ProgressBar.View()
 
 	// Help hint
-	helpHint := styles.MutedStyle.Render("Esc: exit focus  c: code  d: diff  j/k: scroll")
+	helpHint := styles.MutedStyle.Render("Esc: exit focus  c: code  d: diff  j/k: scroll  V: select  Y: copy")
 
 	padding := f.Width - lipgloss.Width(progress) - lipgloss.Width(helpHint) - 4
 	if padding < 0 {
@@ -245,6 +291,11 @@ func (f *FocusView) renderFooter() string {
 	return progress + strings.Repeat(" ", padding) + helpHint
 }
 
+// defaultZenMaxLines caps how many lines ZenView.Content retains before
+// trimming the oldest, mirroring StreamingText's cap so a long-running
+// stream displayed in zen mode doesn't grow memory unbounded.
+const defaultZenMaxLines = 5000
+
 // ZenView is a minimal distraction-free view.
 type ZenView struct {
 	Width  int
@@ -254,6 +305,11 @@ type ZenView struct {
 	AgentRole     string
 	ShowCursor    bool
 	CursorVisible bool
+
+	// MaxLines caps how many lines Content may hold; once exceeded, the
+	// oldest lines are dropped and replaced with a trimmed marker. 0
+	// disables trimming.
+	MaxLines int
 }
 
 // NewZenView creates a new zen view.
@@ -262,7 +318,23 @@ func NewZenView(width, height int) *ZenView {
 		Width:      width,
 		Height:     height,
 		ShowCursor: true,
+		MaxLines:   defaultZenMaxLines,
+	}
+}
+
+// Append adds text to the content, trimming the oldest lines if MaxLines is
+// exceeded.
+func (z *ZenView) Append(text string) {
+	z.Content += text
+	if z.MaxLines <= 0 {
+		return
+	}
+	lines := strings.Split(z.Content, "\n")
+	if len(lines) <= z.MaxLines {
+		return
 	}
+	kept := lines[len(lines)-z.MaxLines:]
+	z.Content = "… earlier output trimmed …\n" + strings.Join(kept, "\n")
 }
 
 // Resize adjusts the view dimensions.
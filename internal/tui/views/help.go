@@ -2,6 +2,7 @@
 package views
 
 import (
+	"fmt"
 	"strings"
 
 	"cooperations/internal/tui/styles"
@@ -16,69 +17,51 @@ type KeyBinding struct {
 }
 
 // HelpView displays keyboard shortcuts and help information.
+//
+// Bindings is populated from the live KeyMap (see tui.helpBindings and
+// tui.focusHelpBindings) rather than hand-maintained here, so the help
+// screen can't drift from what the keys actually do.
 type HelpView struct {
-	Width    int
-	Height   int
-	Bindings []KeyBinding
-	Scroll   int
+	Width     int
+	Height    int
+	Bindings  []KeyBinding
+	Scroll    int
+	Query     string // filter text; empty shows everything
+	Filtering bool   // true while the user is actively typing a filter
 }
 
-// NewHelpView creates a new help view with default keybindings.
+// NewHelpView creates a new help view. Bindings are populated separately by
+// the caller, since they depend on the live KeyMap.
 func NewHelpView(width, height int) *HelpView {
-	h := &HelpView{
+	return &HelpView{
 		Width:  width,
 		Height: height,
 	}
-	h.initBindings()
-	return h
 }
 
-// initBindings sets up the default keybindings.
-func (h *HelpView) initBindings() {
-	h.Bindings = []KeyBinding{
-		// Navigation
-		{Key: "h/←", Description: "Focus left panel", Category: "Navigation"},
-		{Key: "l/→", Description: "Focus right panel", Category: "Navigation"},
-		{Key: "j/↓", Description: "Scroll down / Move down", Category: "Navigation"},
-		{Key: "k/↑", Description: "Scroll up / Move up", Category: "Navigation"},
-		{Key: "Tab", Description: "Cycle panel focus", Category: "Navigation"},
-		{Key: "1-3", Description: "Jump to panel 1-3", Category: "Navigation"},
-
-		// View modes
-		{Key: "c", Description: "Toggle center panel mode", Category: "Views"},
-		{Key: "r", Description: "Toggle right panel mode", Category: "Views"},
-		{Key: "f", Description: "Toggle focus mode", Category: "Views"},
-		{Key: "m", Description: "Toggle metrics view", Category: "Views"},
-		{Key: "d", Description: "Toggle diff view", Category: "Views"},
-
-		// Workflow control
-		{Key: "Space", Description: "Pause/Resume workflow", Category: "Workflow"},
-		{Key: "Enter", Description: "Confirm decision", Category: "Workflow"},
-		{Key: "Esc", Description: "Cancel / Close dialog", Category: "Workflow"},
-		{Key: "n", Description: "Next step (when paused)", Category: "Workflow"},
-		{Key: "s", Description: "Skip current step", Category: "Workflow"},
-
-		// File tree
-		{Key: "o", Description: "Open/Expand file or folder", Category: "Files"},
-		{Key: "e", Description: "Edit file (external)", Category: "Files"},
-		{Key: "y", Description: "Copy file path", Category: "Files"},
-
-		// Search
-		{Key: "/", Description: "Search in current view", Category: "Search"},
-		{Key: "n", Description: "Next search result", Category: "Search"},
-		{Key: "N", Description: "Previous search result", Category: "Search"},
-		{Key: "Esc", Description: "Clear search", Category: "Search"},
-
-		// Session
-		{Key: "Ctrl+s", Description: "Save session", Category: "Session"},
-		{Key: "Ctrl+o", Description: "Open session", Category: "Session"},
-		{Key: "Ctrl+r", Description: "Replay session", Category: "Session"},
-
-		// General
-		{Key: "?", Description: "Toggle help", Category: "General"},
-		{Key: "q", Description: "Quit", Category: "General"},
-		{Key: "Ctrl+c", Description: "Force quit", Category: "General"},
+// SetQuery updates the filter text used to narrow the displayed bindings.
+func (h *HelpView) SetQuery(q string) {
+	h.Query = q
+	h.Scroll = 0
+}
+
+// filteredBindings returns Bindings matching Query (case-insensitive
+// substring over key, description, and category), or all bindings when
+// Query is empty.
+func (h *HelpView) filteredBindings() []KeyBinding {
+	if h.Query == "" {
+		return h.Bindings
 	}
+	q := strings.ToLower(h.Query)
+	var out []KeyBinding
+	for _, b := range h.Bindings {
+		if strings.Contains(strings.ToLower(b.Key), q) ||
+			strings.Contains(strings.ToLower(b.Description), q) ||
+			strings.Contains(strings.ToLower(b.Category), q) {
+			out = append(out, b)
+		}
+	}
+	return out
 }
 
 // Resize adjusts the view dimensions.
@@ -130,25 +113,40 @@ func (h *HelpView) View() string {
 
 	var lines []string
 	lines = append(lines, titleStyle.Render("⌨️  Keyboard Shortcuts"))
+	if h.Filtering || h.Query != "" {
+		cursor := ""
+		if h.Filtering {
+			cursor = "▏"
+		}
+		lines = append(lines, styles.MutedStyle.Render("Filter: "+h.Query+cursor))
+	}
 	lines = append(lines, "")
 
-	// Group by category
-	categories := []string{"Navigation", "Views", "Workflow", "Files", "Search", "Session", "General"}
-	categoryBindings := make(map[string][]KeyBinding)
+	bindings := h.filteredBindings()
 
-	for _, b := range h.Bindings {
+	// Group by category, preserving the order categories first appear in
+	// (which mirrors CategorizedHelp's grouping) rather than a separately
+	// maintained list that can fall out of sync with it.
+	var categories []string
+	seenCategory := make(map[string]bool)
+	categoryBindings := make(map[string][]KeyBinding)
+	for _, b := range bindings {
+		if !seenCategory[b.Category] {
+			seenCategory[b.Category] = true
+			categories = append(categories, b.Category)
+		}
 		categoryBindings[b.Category] = append(categoryBindings[b.Category], b)
 	}
 
-	for _, cat := range categories {
-		bindings := categoryBindings[cat]
-		if len(bindings) == 0 {
-			continue
-		}
+	if len(bindings) == 0 {
+		lines = append(lines, styles.MutedStyle.Render(fmt.Sprintf("No bindings match %q", h.Query)))
+	}
 
+	for _, cat := range categories {
+		catBindings := categoryBindings[cat]
 		lines = append(lines, categoryStyle.Render("─── "+cat+" ───"))
 
-		for _, b := range bindings {
+		for _, b := range catBindings {
 			// Pad key to consistent width
 			key := b.Key
 			padding := 12 - len(key)
@@ -176,7 +174,15 @@ func (h *HelpView) View() string {
 	visibleLines := lines[start:end]
 
 	// Footer
-	footer := styles.MutedStyle.Render("\nPress ? or Esc to close")
+	var footer string
+	switch {
+	case h.Filtering:
+		footer = styles.MutedStyle.Render("\nType to filter  Enter/Esc: stop editing filter")
+	case h.Query != "":
+		footer = styles.MutedStyle.Render("\n/: edit filter  Esc: clear filter  ?: close")
+	default:
+		footer = styles.MutedStyle.Render("\nPress ? or Esc to close  /: filter")
+	}
 
 	return containerStyle.Render(strings.Join(visibleLines, "\n") + footer)
 }
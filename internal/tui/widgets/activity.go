@@ -37,6 +37,9 @@ type ActivityLog struct {
 	ScrollPos  int
 	ShowTime   bool
 	ShowAgent  bool
+
+	MinLevel   LogLevel // Entries below this level are hidden by View
+	RoleFilter string   // If non-empty, only entries from this agent are shown
 }
 
 // NewActivityLog creates a new activity log widget.
@@ -68,8 +71,8 @@ func (a *ActivityLog) Add(level LogLevel, agent, message string) {
 	}
 
 	// Auto-scroll to bottom
-	if len(a.Entries) > a.Height {
-		a.ScrollPos = len(a.Entries) - a.Height
+	if n := len(a.filtered()); n > a.Height {
+		a.ScrollPos = n - a.Height
 	}
 }
 
@@ -79,6 +82,83 @@ func (a *ActivityLog) Clear() {
 	a.ScrollPos = 0
 }
 
+// filtered returns the entries currently passing MinLevel and RoleFilter.
+// Add keeps storing everything in Entries; only View (and scrolling) look at
+// this subset.
+func (a *ActivityLog) filtered() []LogEntry {
+	if a.MinLevel == LogDebug && a.RoleFilter == "" {
+		return a.Entries
+	}
+	out := make([]LogEntry, 0, len(a.Entries))
+	for _, e := range a.Entries {
+		if e.Level < a.MinLevel {
+			continue
+		}
+		if a.RoleFilter != "" && e.Agent != a.RoleFilter {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// CycleMinLevel advances the minimum level shown (debug -> info -> warn ->
+// error -> debug) and returns the level now active.
+func (a *ActivityLog) CycleMinLevel() LogLevel {
+	a.MinLevel = (a.MinLevel + 1) % 4
+	a.ScrollPos = 0
+	return a.MinLevel
+}
+
+// CycleAgentFilter advances RoleFilter through the distinct agent roles seen
+// so far, in first-seen order, wrapping back to "all roles" ("") after the
+// last one. It returns the role now active.
+func (a *ActivityLog) CycleAgentFilter() string {
+	var roles []string
+	seen := make(map[string]bool)
+	for _, e := range a.Entries {
+		if e.Agent != "" && !seen[e.Agent] {
+			seen[e.Agent] = true
+			roles = append(roles, e.Agent)
+		}
+	}
+	if len(roles) == 0 {
+		a.RoleFilter = ""
+		return ""
+	}
+
+	idx := -1
+	for i, r := range roles {
+		if r == a.RoleFilter {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 || idx == len(roles)-1 {
+		a.RoleFilter = ""
+	} else {
+		a.RoleFilter = roles[idx+1]
+	}
+	a.ScrollPos = 0
+	return a.RoleFilter
+}
+
+// FilterLabel returns a short description of the active filter for display
+// in the panel header, or "" if no filter is active.
+func (a *ActivityLog) FilterLabel() string {
+	if a.MinLevel == LogDebug && a.RoleFilter == "" {
+		return ""
+	}
+	var parts []string
+	if a.MinLevel != LogDebug {
+		parts = append(parts, "min="+levelPrefix(a.MinLevel))
+	}
+	if a.RoleFilter != "" {
+		parts = append(parts, "role="+a.RoleFilter)
+	}
+	return strings.Join(parts, " ")
+}
+
 // AddInfo adds an info level log.
 func (a *ActivityLog) AddInfo(agent, message string) {
 	a.Add(LogInfo, agent, message)
@@ -109,7 +189,7 @@ func (a *ActivityLog) ScrollUp(lines int) {
 
 // ScrollDown scrolls the log down.
 func (a *ActivityLog) ScrollDown(lines int) {
-	maxScroll := len(a.Entries) - a.Height
+	maxScroll := len(a.filtered()) - a.Height
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -126,7 +206,7 @@ func (a *ActivityLog) ScrollToTop() {
 
 // ScrollToBottom jumps to the bottom of the log.
 func (a *ActivityLog) ScrollToBottom() {
-	maxScroll := len(a.Entries) - a.Height
+	maxScroll := len(a.filtered()) - a.Height
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -170,8 +250,12 @@ func (a ActivityLog) View() string {
 	if a.Width <= 0 || a.Height <= 0 {
 		return ""
 	}
-	if len(a.Entries) == 0 {
-		return styles.MutedStyle.Render("No activity yet...")
+	entries := a.filtered()
+	if len(entries) == 0 {
+		if len(a.Entries) == 0 {
+			return styles.MutedStyle.Render("No activity yet...")
+		}
+		return styles.MutedStyle.Render("No entries match the active filter")
 	}
 
 	var lines []string
@@ -179,12 +263,12 @@ func (a ActivityLog) View() string {
 	// Calculate visible range
 	start := a.ScrollPos
 	end := start + a.Height
-	if end > len(a.Entries) {
-		end = len(a.Entries)
+	if end > len(entries) {
+		end = len(entries)
 	}
 
 	for i := start; i < end; i++ {
-		entry := a.Entries[i]
+		entry := entries[i]
 		var parts []string
 
 		// Timestamp
@@ -222,8 +306,8 @@ func (a ActivityLog) View() string {
 	}
 
 	// Show scroll indicator if needed
-	if len(a.Entries) > a.Height {
-		scrollInfo := fmt.Sprintf(" [%d/%d]", a.ScrollPos+1, len(a.Entries)-a.Height+1)
+	if len(entries) > a.Height {
+		scrollInfo := fmt.Sprintf(" [%d/%d]", a.ScrollPos+1, len(entries)-a.Height+1)
 		lines = append(lines, styles.MutedStyle.Render(scrollInfo))
 	}
 
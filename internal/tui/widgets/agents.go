@@ -4,6 +4,7 @@ package widgets
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"cooperations/internal/tui/styles"
 	"github.com/charmbracelet/lipgloss"
@@ -29,6 +30,17 @@ type AgentInfo struct {
 	TokensUsed  int
 	Duration    string
 	LastMessage string
+	// StartedAt marks when the agent most recently entered AgentThinking or
+	// AgentWorking, so the card can show a live elapsed timer instead of the
+	// static Duration (which is only populated once metrics arrive at the
+	// end of a step).
+	StartedAt time.Time
+}
+
+// isActive reports whether status counts as the agent actively doing work,
+// i.e. the states a live elapsed timer applies to.
+func isActive(status AgentStatus) bool {
+	return status == AgentThinking || status == AgentWorking
 }
 
 // AgentCard displays a single agent's status.
@@ -52,12 +64,27 @@ func NewAgentCard(role string, width int) AgentCard {
 	}
 }
 
-// SetStatus updates the agent status.
+// SetStatus updates the agent status. Entering AgentThinking or AgentWorking
+// from a non-active status resets the elapsed-time clock, so a card that
+// goes idle-then-busy again starts counting from zero rather than carrying
+// over an old start time.
 func (a *AgentCard) SetStatus(status AgentStatus, task string) {
+	if isActive(status) && !isActive(a.Info.Status) {
+		a.Info.StartedAt = time.Now()
+	}
 	a.Info.Status = status
 	a.Info.CurrentTask = task
 }
 
+// Elapsed returns how long the agent has been in its current active
+// (thinking/working) phase, or 0 if it isn't currently active.
+func (a AgentCard) Elapsed() time.Duration {
+	if !isActive(a.Info.Status) || a.Info.StartedAt.IsZero() {
+		return 0
+	}
+	return time.Since(a.Info.StartedAt)
+}
+
 // SetMetrics updates agent metrics.
 func (a *AgentCard) SetMetrics(tokens int, duration string) {
 	a.Info.TokensUsed = tokens
@@ -135,11 +162,14 @@ func (a AgentCard) View() string {
 	// Header: icon + role + status
 	header := agentIcon(a.Info.Role) + " " + roleStyle.Render(a.Info.Role)
 
-	// Add spinner or status
+	// Add spinner, status, and elapsed time
 	if a.Info.Status == AgentWorking || a.Info.Status == AgentThinking {
 		if a.Spinner != nil {
 			header += " " + a.Spinner.View()
 		}
+		if elapsed := a.Elapsed(); elapsed > 0 {
+			header += " " + styles.MutedStyle.Render(elapsed.Round(time.Second).String())
+		}
 	} else {
 		statusStyle := styles.MutedStyle
 		switch a.Info.Status {
@@ -159,8 +189,8 @@ func (a AgentCard) View() string {
 	if a.Info.CurrentTask != "" {
 		taskStyle := lipgloss.NewStyle().Foreground(styles.Current.Foreground)
 		task := a.Info.CurrentTask
-		if len(task) > a.Width-4 {
-			task = task[:a.Width-7] + "..."
+		if lipgloss.Width(task) > a.Width-4 {
+			task = truncateDisplay(task, "...", a.Width-4)
 		}
 		lines = append(lines, taskStyle.Render(task))
 	}
@@ -178,8 +208,8 @@ func (a AgentCard) View() string {
 		}
 		if a.Info.LastMessage != "" {
 			msg := a.Info.LastMessage
-			if len(msg) > a.Width-4 {
-				msg = msg[:a.Width-7] + "..."
+			if lipgloss.Width(msg) > a.Width-4 {
+				msg = truncateDisplay(msg, "...", a.Width-4)
 			}
 			lines = append(lines, detailStyle.Render("Last: "+msg))
 		}
@@ -196,6 +226,10 @@ type AgentPanel struct {
 	Height   int
 	Columns  int
 	Expanded bool
+	// Compact collapses each agent to a single line instead of a bordered
+	// card, for terminals too short to fit full cards under the workflow
+	// steps above them.
+	Compact bool
 }
 
 // NewAgentPanel creates a new agent panel.
@@ -243,6 +277,10 @@ func (p AgentPanel) View() string {
 		return styles.MutedStyle.Render("No agents")
 	}
 
+	if p.Compact {
+		return p.viewCompact()
+	}
+
 	var rows []string
 
 	for i := 0; i < len(p.Order); i += p.Columns {
@@ -262,6 +300,25 @@ func (p AgentPanel) View() string {
 	return strings.Join(rows, "\n")
 }
 
+// viewCompact renders each agent as a single line: icon, role, and status.
+func (p AgentPanel) viewCompact() string {
+	var lines []string
+	for _, role := range p.Order {
+		agent, ok := p.Agents[role]
+		if !ok {
+			continue
+		}
+		roleStyle := styles.AgentStyle(role)
+		status := statusText(agent.Info.Status)
+		if elapsed := agent.Elapsed(); elapsed > 0 {
+			status += " " + elapsed.Round(time.Second).String()
+		}
+		line := agentIcon(role) + " " + roleStyle.Render(role) + " " + styles.MutedStyle.Render(status)
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // ActiveAgents returns a list of currently active agent roles.
 func (p AgentPanel) ActiveAgents() []string {
 	var active []string
@@ -17,9 +17,12 @@ type CodeBlock struct {
 	Width      int
 	Height     int
 	ScrollPos  int
+	HScrollPos int // horizontal column offset; gutter/line numbers stay fixed
 	ShowLines  bool
 	StartLine  int
 	Highlights []int // Lines to highlight
+
+	tokenized [][]codeSegment // cached per-line syntax highlighting, from SetContent
 }
 
 // NewCodeBlock creates a new code block widget.
@@ -32,12 +35,30 @@ func NewCodeBlock(width, height int) CodeBlock {
 	}
 }
 
-// SetContent sets the code content.
+// SetContent sets the code content. Syntax highlighting is tokenized once
+// here and cached so scrolling doesn't re-run the lexer per frame.
 func (c *CodeBlock) SetContent(content, language, filename string) {
 	c.Content = content
 	c.Language = language
 	c.Filename = filename
 	c.ScrollPos = 0
+	c.HScrollPos = 0
+	c.tokenized = highlightLines(content, language)
+}
+
+// ScrollLeft shifts the horizontal column window left.
+func (c *CodeBlock) ScrollLeft(cols int) {
+	c.HScrollPos -= cols
+	if c.HScrollPos < 0 {
+		c.HScrollPos = 0
+	}
+}
+
+// ScrollRight shifts the horizontal column window right. There's no fixed
+// upper bound: the longest line in view naturally caps how far right is
+// useful, since scrolling past it just shows blank space.
+func (c *CodeBlock) ScrollRight(cols int) {
+	c.HScrollPos += cols
 }
 
 // ScrollUp scrolls code up.
@@ -109,6 +130,53 @@ func (c *CodeBlock) ClearHighlights() {
 	c.Highlights = nil
 }
 
+// renderLine renders a single (already width-truncated) line of code,
+// applying cached syntax-highlight colors per segment and, when the line
+// matched a search, an additional background so both highlights are visible
+// at once.
+func (c CodeBlock) renderLine(lineIdx int, truncated string, searched bool, searchBG lipgloss.Color) string {
+	segments := c.segmentsFor(lineIdx)
+	if len(segments) == 0 {
+		style := lipgloss.NewStyle().Foreground(styles.Current.Foreground)
+		if searched {
+			style = style.Background(searchBG)
+		}
+		return style.Render(truncated)
+	}
+
+	var b strings.Builder
+	remaining := truncated
+	for _, seg := range segments {
+		if remaining == "" {
+			break
+		}
+		text := seg.text
+		if len(text) > len(remaining) {
+			text = remaining
+		}
+		if !strings.HasPrefix(remaining, text) {
+			// Truncation split a segment mid-way; take what's left verbatim.
+			text = remaining
+		}
+		style := lipgloss.NewStyle().Foreground(seg.color)
+		if searched {
+			style = style.Background(searchBG)
+		}
+		b.WriteString(style.Render(text))
+		remaining = remaining[len(text):]
+	}
+	return b.String()
+}
+
+// segmentsFor returns the cached syntax-highlighted segments for a raw
+// (untruncated) line index, if any were computed for the current content.
+func (c CodeBlock) segmentsFor(lineIdx int) []codeSegment {
+	if lineIdx < 0 || lineIdx >= len(c.tokenized) {
+		return nil
+	}
+	return c.tokenized[lineIdx]
+}
+
 // isHighlighted checks if a line should be highlighted.
 func (c *CodeBlock) isHighlighted(line int) bool {
 	for _, h := range c.Highlights {
@@ -161,26 +229,27 @@ func (c CodeBlock) View() string {
 	lineNumWidth := len(fmt.Sprintf("%d", maxLineNum))
 
 	lineNumStyle := lipgloss.NewStyle().Foreground(styles.Current.Muted)
-	codeStyle := lipgloss.NewStyle().Foreground(styles.Current.Foreground)
-	highlightStyle := lipgloss.NewStyle().
-		Foreground(styles.Current.Foreground).
-		Background(lipgloss.Color("#1a2332"))
+	searchBG := lipgloss.Color("#1a2332")
 
 	for i := start; i < end; i++ {
 		lineNum := c.StartLine + i
 		line := lines[i]
+		searched := c.isHighlighted(lineNum)
+
+		// Shift the visible column window right by HScrollPos before
+		// truncating, so the gutter stays fixed while content scrolls.
+		scrolled := c.HScrollPos > 0
+		if scrolled {
+			line = hscrollLine(line, c.HScrollPos)
+		}
 
 		// Truncate long lines
 		availWidth := c.Width - lineNumWidth - 3 // " │ "
 		if availWidth < 1 {
 			availWidth = 1
 		}
-		if len(line) > availWidth {
-			if availWidth <= 1 {
-				line = "…"
-			} else {
-				line = line[:availWidth-1] + "…"
-			}
+		if lipgloss.Width(line) > availWidth {
+			line = truncateDisplay(line, "…", availWidth)
 		}
 
 		// Apply styles
@@ -190,10 +259,17 @@ func (c CodeBlock) View() string {
 			styledLine = lineNumStyle.Render(numStr) + " │ "
 		}
 
-		if c.isHighlighted(lineNum) {
-			styledLine += highlightStyle.Render(line)
+		if scrolled {
+			// Syntax-highlight segments are indexed from column 0 of the
+			// unscrolled line, so they no longer line up once a column
+			// offset is applied; fall back to plain foreground styling.
+			style := lipgloss.NewStyle().Foreground(styles.Current.Foreground)
+			if searched {
+				style = style.Background(searchBG)
+			}
+			styledLine += style.Render(line)
 		} else {
-			styledLine += codeStyle.Render(line)
+			styledLine += c.renderLine(i, line, searched, searchBG)
 		}
 
 		result.WriteString(styledLine)
@@ -203,23 +279,44 @@ func (c CodeBlock) View() string {
 	}
 
 	// Scroll indicator
-	if len(lines) > c.Height-2 {
-		scrollInfo := fmt.Sprintf("\n%s", styles.MutedStyle.Render(
-			fmt.Sprintf("Lines %d-%d of %d", start+1, end, len(lines)),
-		))
-		result.WriteString(scrollInfo)
+	if len(lines) > c.Height-2 || c.HScrollPos > 0 {
+		info := fmt.Sprintf("Lines %d-%d of %d", start+1, end, len(lines))
+		if c.HScrollPos > 0 {
+			info += fmt.Sprintf("  Col %d+", c.HScrollPos+1)
+		}
+		result.WriteString("\n" + styles.MutedStyle.Render(info))
 	}
 
 	return result.String()
 }
 
+// hscrollLine drops offset display-columns from the left of s, cutting on
+// rune boundaries so multibyte characters aren't split.
+func hscrollLine(s string, offset int) string {
+	if offset <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	w, idx := 0, 0
+	for idx < len(runes) {
+		rw := lipgloss.Width(string(runes[idx]))
+		if w+rw > offset {
+			break
+		}
+		w += rw
+		idx++
+	}
+	return string(runes[idx:])
+}
+
 // DiffBlock displays a unified diff with colors.
 type DiffBlock struct {
-	Content   string
-	Filename  string
-	Width     int
-	Height    int
-	ScrollPos int
+	Content        string
+	Filename       string
+	Width          int
+	Height         int
+	ScrollPos      int
+	HScrollPos     int // horizontal column offset; gutter/line numbers stay fixed
 	HighlightLines []int
 }
 
@@ -236,6 +333,20 @@ func (d *DiffBlock) SetContent(content, filename string) {
 	d.Content = content
 	d.Filename = filename
 	d.ScrollPos = 0
+	d.HScrollPos = 0
+}
+
+// ScrollLeft shifts the horizontal column window left.
+func (d *DiffBlock) ScrollLeft(cols int) {
+	d.HScrollPos -= cols
+	if d.HScrollPos < 0 {
+		d.HScrollPos = 0
+	}
+}
+
+// ScrollRight shifts the horizontal column window right.
+func (d *DiffBlock) ScrollRight(cols int) {
+	d.HScrollPos += cols
 }
 
 // ScrollUp scrolls diff up.
@@ -343,23 +454,41 @@ func (d DiffBlock) View() string {
 	}
 
 	for i := start; i < end; i++ {
-		line := lines[i]
+		raw := lines[i]
+
+		// Keep the +/-/@@ marker as a fixed gutter and only shift the
+		// content after it, so horizontal scroll doesn't disturb line typing.
+		prefixLen := 0
+		switch {
+		case strings.HasPrefix(raw, "@@"):
+			prefixLen = 2
+		case strings.HasPrefix(raw, "+"), strings.HasPrefix(raw, "-"):
+			prefixLen = 1
+		}
+		prefix := raw[:prefixLen]
+		content := raw[prefixLen:]
 
-		// Truncate
-		if d.Width <= 1 {
-			line = ""
-		} else if len(line) > d.Width {
-			line = line[:d.Width-1] + "…"
+		if d.HScrollPos > 0 {
+			content = hscrollLine(content, d.HScrollPos)
 		}
 
+		availWidth := d.Width - prefixLen
+		if availWidth < 1 {
+			availWidth = 1
+		}
+		if lipgloss.Width(content) > availWidth {
+			content = truncateDisplay(content, "…", availWidth)
+		}
+		line := prefix + content
+
 		// Color based on prefix
 		var styledLine string
 		switch {
-		case strings.HasPrefix(line, "+"):
+		case strings.HasPrefix(raw, "+"):
 			styledLine = styles.DiffAdd.Render(line)
-		case strings.HasPrefix(line, "-"):
+		case strings.HasPrefix(raw, "-"):
 			styledLine = styles.DiffRemove.Render(line)
-		case strings.HasPrefix(line, "@@"):
+		case strings.HasPrefix(raw, "@@"):
 			styledLine = styles.SecondaryStyle.Render(line)
 		default:
 			styledLine = styles.DiffContext.Render(line)
@@ -378,5 +507,9 @@ func (d DiffBlock) View() string {
 		}
 	}
 
+	if d.HScrollPos > 0 {
+		result.WriteString("\n" + styles.MutedStyle.Render(fmt.Sprintf("Col %d+", d.HScrollPos+1)))
+	}
+
 	return result.String()
 }
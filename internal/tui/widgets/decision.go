@@ -2,7 +2,9 @@
 package widgets
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"cooperations/internal/tui/styles"
 	"github.com/charmbracelet/lipgloss"
@@ -24,6 +26,25 @@ type DecisionDialog struct {
 	Selected int
 	Width    int
 	ShowHelp bool
+
+	// Deadline, when set, is the time the dialog auto-selects DefaultLabel.
+	// The countdown is display-only; the actual auto-select is driven by
+	// the model's tick loop.
+	Deadline     time.Time
+	DefaultLabel string
+}
+
+// Remaining returns the time left before Deadline, or 0 if there is no
+// deadline or it has already passed.
+func (d DecisionDialog) Remaining() time.Duration {
+	if d.Deadline.IsZero() {
+		return 0
+	}
+	remaining := time.Until(d.Deadline)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
 }
 
 // NewDecisionDialog creates a new decision dialog.
@@ -156,6 +177,18 @@ func (d DecisionDialog) View() string {
 		}
 	}
 
+	// Countdown
+	if !d.Deadline.IsZero() {
+		lines = append(lines, "")
+		countdownStyle := lipgloss.NewStyle().Foreground(styles.Current.Warning).Italic(true)
+		seconds := int(d.Remaining().Round(time.Second) / time.Second)
+		label := d.DefaultLabel
+		if label == "" {
+			label = "default"
+		}
+		lines = append(lines, countdownStyle.Render(fmt.Sprintf("Auto-selecting %q in %ds", label, seconds)))
+	}
+
 	// Help
 	if d.ShowHelp {
 		lines = append(lines, "")
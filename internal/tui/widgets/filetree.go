@@ -32,6 +32,42 @@ type FileNode struct {
 	Depth    int
 }
 
+// FileTreeEntry is one file's path, kind, and status, as returned by
+// Snapshot for callers that need the tree's contents without walking
+// FileNode pointers directly (e.g. to refresh statuses against disk).
+type FileTreeEntry struct {
+	Path   string
+	IsDir  bool
+	Status FileStatus
+}
+
+// Snapshot returns every file and directory currently in the tree, in the
+// same order they'd flatten to in View.
+func (t *FileTree) Snapshot() []FileTreeEntry {
+	if t.Root == nil {
+		return nil
+	}
+	var entries []FileTreeEntry
+	var walk func(node *FileNode)
+	walk = func(node *FileNode) {
+		for _, child := range node.Children {
+			entries = append(entries, FileTreeEntry{Path: child.Path, IsDir: child.IsDir, Status: child.Status})
+			walk(child)
+		}
+	}
+	walk(t.Root)
+	return entries
+}
+
+// FileTreeFilterMode names a filter preset cycled with CycleFilter.
+type FileTreeFilterMode int
+
+const (
+	FileTreeFilterAll FileTreeFilterMode = iota
+	FileTreeFilterModifiedOrAdded
+	FileTreeFilterAddedOnly
+)
+
 // FileTree displays a hierarchical file tree.
 type FileTree struct {
 	Root      *FileNode
@@ -41,6 +77,9 @@ type FileTree struct {
 	Selected  int
 	ShowIcons bool
 	flat      []*FileNode // Flattened visible nodes
+
+	filter     func(*FileNode) bool // nil shows everything
+	filterMode FileTreeFilterMode
 }
 
 // NewFileTree creates a new file tree widget.
@@ -163,9 +202,14 @@ func (t *FileTree) flatten() {
 	t.flattenNode(t.Root)
 }
 
-// flattenNode recursively flattens the tree.
+// flattenNode recursively flattens the tree, skipping nodes hidden by the
+// installed filter. A directory is kept visible as long as at least one of
+// its descendants matches.
 func (t *FileTree) flattenNode(node *FileNode) {
 	if node != t.Root {
+		if !t.matches(node) {
+			return
+		}
 		t.flat = append(t.flat, node)
 	}
 
@@ -176,6 +220,90 @@ func (t *FileTree) flattenNode(node *FileNode) {
 	}
 }
 
+// matches reports whether node should be shown under the current filter.
+// Directories match if any descendant file matches.
+func (t *FileTree) matches(node *FileNode) bool {
+	if t.filter == nil {
+		return true
+	}
+	if !node.IsDir {
+		return t.filter(node)
+	}
+	for _, child := range node.Children {
+		if t.matches(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFilter installs a predicate controlling which files appear in the
+// flattened view; pass nil to show everything. Directories remain visible as
+// long as they contain at least one matching descendant.
+func (t *FileTree) SetFilter(filter func(*FileNode) bool) {
+	t.filter = filter
+	t.flatten()
+	t.Selected = 0
+	t.ScrollPos = 0
+}
+
+// CycleFilter advances to the next filter preset (all -> modified+added ->
+// added only -> all) and returns the mode now active.
+func (t *FileTree) CycleFilter() FileTreeFilterMode {
+	t.filterMode = (t.filterMode + 1) % 3
+	switch t.filterMode {
+	case FileTreeFilterModifiedOrAdded:
+		t.SetFilter(func(n *FileNode) bool {
+			return n.Status == FileStatusModified || n.Status == FileStatusAdded
+		})
+	case FileTreeFilterAddedOnly:
+		t.SetFilter(func(n *FileNode) bool {
+			return n.Status == FileStatusAdded
+		})
+	default:
+		t.SetFilter(nil)
+	}
+	return t.filterMode
+}
+
+// FilterModeLabel returns a short label for the active filter preset,
+// suitable for status bars and toasts.
+func (t *FileTree) FilterModeLabel() string {
+	switch t.filterMode {
+	case FileTreeFilterModifiedOrAdded:
+		return "modified+added"
+	case FileTreeFilterAddedOnly:
+		return "added only"
+	default:
+		return "all"
+	}
+}
+
+// CollapseAll collapses every directory in the tree.
+func (t *FileTree) CollapseAll() {
+	setExpandedRecursive(t.Root, false)
+	t.Root.Expanded = true
+	t.flatten()
+	t.Selected = 0
+	t.ScrollPos = 0
+}
+
+// ExpandAll expands every directory in the tree.
+func (t *FileTree) ExpandAll() {
+	setExpandedRecursive(t.Root, true)
+	t.flatten()
+}
+
+// setExpandedRecursive sets Expanded on every directory descendant of node.
+func setExpandedRecursive(node *FileNode, expanded bool) {
+	for _, child := range node.Children {
+		if child.IsDir {
+			child.Expanded = expanded
+			setExpandedRecursive(child, expanded)
+		}
+	}
+}
+
 // Toggle expands or collapses the selected node.
 func (t *FileTree) Toggle() {
 	if t.Selected >= 0 && t.Selected < len(t.flat) {
@@ -232,6 +360,15 @@ func (t *FileTree) ScrollToBottom() {
 	}
 }
 
+// GetSelectedNode returns the currently selected node, or nil if nothing is
+// selected.
+func (t *FileTree) GetSelectedNode() *FileNode {
+	if t.Selected >= 0 && t.Selected < len(t.flat) {
+		return t.flat[t.Selected]
+	}
+	return nil
+}
+
 // GetSelected returns the currently selected path.
 func (t *FileTree) GetSelected() string {
 	if t.Selected >= 0 && t.Selected < len(t.flat) {
@@ -351,11 +488,7 @@ func (t FileTree) View() string {
 
 		// Truncate if too wide
 		if lipgloss.Width(line) > t.Width {
-			if t.Width <= 3 {
-				line = line[:maxInt(t.Width, 0)]
-			} else {
-				line = line[:t.Width-3] + "..."
-			}
+			line = truncateDisplay(line, "...", t.Width)
 		}
 
 		lines = append(lines, line)
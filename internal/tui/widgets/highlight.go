@@ -0,0 +1,104 @@
+package widgets
+
+import (
+	"os"
+	"strings"
+
+	"cooperations/internal/tui/styles"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// syntaxHighlightEnabled reports whether chroma-based highlighting should run.
+// It can be disabled with COOP_NO_SYNTAX_HIGHLIGHT for terminals that don't
+// render 256-color output well.
+func syntaxHighlightEnabled() bool {
+	return os.Getenv("COOP_NO_SYNTAX_HIGHLIGHT") == ""
+}
+
+// codeSegment is a run of text sharing a single token color.
+type codeSegment struct {
+	text  string
+	color lipgloss.Color
+}
+
+// highlightLines tokenizes content with chroma and groups the resulting
+// tokens into per-line segments, keyed by the given language name.
+// It falls back to lexer analysis of the content when language is unknown,
+// and to a single plain segment per line when no lexer matches.
+func highlightLines(content, language string) [][]codeSegment {
+	lines := strings.Split(content, "\n")
+	plain := make([][]codeSegment, len(lines))
+	for i, line := range lines {
+		plain[i] = []codeSegment{{text: line, color: styles.Current.Foreground}}
+	}
+
+	if !syntaxHighlightEnabled() {
+		return plain
+	}
+
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		lexer = lexers.Analyse(content)
+	}
+	if lexer == nil {
+		return plain
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	iterator, err := lexer.Tokenise(nil, content)
+	if err != nil {
+		return plain
+	}
+
+	result := make([][]codeSegment, len(lines))
+	lineIdx := 0
+	for _, tok := range iterator.Tokens() {
+		color := tokenColor(tok.Type)
+		parts := strings.Split(tok.Value, "\n")
+		for i, part := range parts {
+			if part != "" {
+				result[lineIdx] = append(result[lineIdx], codeSegment{text: part, color: color})
+			}
+			if i < len(parts)-1 {
+				lineIdx++
+				if lineIdx >= len(result) {
+					// Trailing newline emitted past the last real line; stop.
+					return result
+				}
+			}
+		}
+	}
+
+	// Any line the tokenizer never touched (e.g. trailing blank line) stays empty.
+	for i := range result {
+		if result[i] == nil {
+			result[i] = []codeSegment{{text: "", color: styles.Current.Foreground}}
+		}
+	}
+
+	return result
+}
+
+// tokenColor maps a chroma token type to a theme color.
+func tokenColor(t chroma.TokenType) lipgloss.Color {
+	switch {
+	case t.InCategory(chroma.Comment):
+		return styles.Current.Muted
+	case t.InCategory(chroma.Keyword):
+		return styles.Current.Secondary
+	case t.InCategory(chroma.LiteralString):
+		return styles.Current.Success
+	case t.InCategory(chroma.LiteralNumber):
+		return styles.Current.Warning
+	case t.InCategory(chroma.NameFunction) || t.InCategory(chroma.NameClass):
+		return styles.Current.Primary
+	case t.InCategory(chroma.NameBuiltin) || t.InCategory(chroma.NameDecorator):
+		return styles.Current.Accent
+	case t.InCategory(chroma.Operator) || t.InCategory(chroma.Punctuation):
+		return styles.Current.Foreground
+	default:
+		return styles.Current.Foreground
+	}
+}
@@ -121,13 +121,13 @@ func (p MetricsPanel) View() string {
 
 // CostTracker tracks and displays cost information.
 type CostTracker struct {
-	TotalTokens    int
-	InputTokens    int
-	OutputTokens   int
-	EstimatedCost  float64
-	CostPerMToken  float64
-	SessionBudget  float64
-	Width          int
+	TotalTokens   int
+	InputTokens   int
+	OutputTokens  int
+	EstimatedCost float64
+	CostPerMToken float64
+	SessionBudget float64
+	Width         int
 }
 
 // NewCostTracker creates a new cost tracker.
@@ -146,6 +146,17 @@ func (c *CostTracker) Update(input, output int) {
 	c.EstimatedCost = float64(c.TotalTokens) / 1_000_000 * c.CostPerMToken
 }
 
+// SetSnapshot replaces the tracked totals with an authoritative snapshot
+// (e.g. from a stream.MetricsSnapshot or stream.WorkflowSummary), rather
+// than adding to them the way Update does. Use this when the caller
+// already has running totals and would otherwise double-count them.
+func (c *CostTracker) SetSnapshot(promptTokens, completionTokens int, estimatedCostUSD float64) {
+	c.InputTokens = promptTokens
+	c.OutputTokens = completionTokens
+	c.TotalTokens = promptTokens + completionTokens
+	c.EstimatedCost = estimatedCostUSD
+}
+
 // View renders the cost tracker.
 func (c CostTracker) View() string {
 	tokenStyle := lipgloss.NewStyle().Foreground(styles.Current.Info)
@@ -206,12 +217,12 @@ func formatNumber(n int) string {
 
 // AgentMetrics displays metrics for a specific agent.
 type AgentMetrics struct {
-	Role       string
-	Calls      int
-	Tokens     int
-	Duration   string
-	Success    int
-	Errors     int
+	Role     string
+	Calls    int
+	Tokens   int
+	Duration string
+	Success  int
+	Errors   int
 }
 
 // View renders agent metrics.
@@ -0,0 +1,112 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"cooperations/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SessionPickerItem is a display-only summary of a saved session, decoupled
+// from the session package's storage format so this widget doesn't need to
+// import it.
+type SessionPickerItem struct {
+	ID          string
+	Task        string
+	Status      string
+	UpdatedAt   time.Time
+	TotalTokens int
+}
+
+// SessionPickerDialog lets the user pick a saved session with arrow keys,
+// reusing the up/down-and-confirm navigation style of DecisionDialog.
+type SessionPickerDialog struct {
+	Title    string
+	Items    []SessionPickerItem
+	Selected int
+	Width    int
+}
+
+// NewSessionPickerDialog creates a new session picker dialog.
+func NewSessionPickerDialog(title string, items []SessionPickerItem, width int) SessionPickerDialog {
+	return SessionPickerDialog{
+		Title: title,
+		Items: items,
+		Width: width,
+	}
+}
+
+// MoveUp moves the selection up.
+func (d *SessionPickerDialog) MoveUp() {
+	if d.Selected > 0 {
+		d.Selected--
+	}
+}
+
+// MoveDown moves the selection down.
+func (d *SessionPickerDialog) MoveDown() {
+	if d.Selected < len(d.Items)-1 {
+		d.Selected++
+	}
+}
+
+// GetSelected returns the currently selected item, or nil if the list is
+// empty.
+func (d *SessionPickerDialog) GetSelected() *SessionPickerItem {
+	if d.Selected >= 0 && d.Selected < len(d.Items) {
+		return &d.Items[d.Selected]
+	}
+	return nil
+}
+
+// View renders the session picker dialog.
+func (d SessionPickerDialog) View() string {
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(styles.Current.Secondary).
+		Padding(1, 2).
+		Width(d.Width)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.Current.Secondary).
+		Bold(true).
+		Underline(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(d.Title))
+	lines = append(lines, "")
+
+	if len(d.Items) == 0 {
+		lines = append(lines, styles.MutedStyle.Render("No saved sessions"))
+	}
+
+	for i, item := range d.Items {
+		var rowStyle lipgloss.Style
+		var prefix string
+		if i == d.Selected {
+			prefix = "▶ "
+			rowStyle = lipgloss.NewStyle().
+				Foreground(styles.Current.Primary).
+				Bold(true).
+				Reverse(true)
+		} else {
+			prefix = "  "
+			rowStyle = lipgloss.NewStyle().Foreground(styles.Current.Foreground)
+		}
+
+		task := item.Task
+		if task == "" {
+			task = "(no task)"
+		}
+		row := fmt.Sprintf("%-24s  %-10s  %s  %d tok",
+			truncateDisplay(task, "…", 24), item.Status, item.UpdatedAt.Format("2006-01-02 15:04"), item.TotalTokens)
+		lines = append(lines, prefix+rowStyle.Render(row))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, styles.MutedStyle.Render("↑/↓: navigate  Enter: open  d: delete  r: rename  Esc: cancel"))
+
+	return containerStyle.Render(strings.Join(lines, "\n"))
+}
@@ -10,22 +10,22 @@ import (
 
 // SpinnerFrames defines different spinner animations.
 var SpinnerFrames = map[string][]string{
-	"dots": {"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
-	"line": {"-", "\\", "|", "/"},
-	"arc":  {"◜", "◠", "◝", "◞", "◡", "◟"},
-	"neon": {"◐", "◓", "◑", "◒"},
+	"dots":  {"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+	"line":  {"-", "\\", "|", "/"},
+	"arc":   {"◜", "◠", "◝", "◞", "◡", "◟"},
+	"neon":  {"◐", "◓", "◑", "◒"},
 	"pulse": {"█", "▓", "▒", "░", "▒", "▓"},
-	"wave": {"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃", "▂"},
+	"wave":  {"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃", "▂"},
 }
 
 // Spinner is an animated spinner widget.
 type Spinner struct {
-	Frames    []string
-	Frame     int
-	Color     lipgloss.Color
-	Label     string
-	LastTick  time.Time
-	Interval  time.Duration
+	Frames   []string
+	Frame    int
+	Color    lipgloss.Color
+	Label    string
+	LastTick time.Time
+	Interval time.Duration
 }
 
 // NewSpinner creates a new spinner with default neon style.
@@ -8,17 +8,30 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// defaultMaxStreamLines caps how many lines StreamingText retains before
+// trimming the oldest, so a very long-running stream doesn't grow memory
+// and render time unbounded. High enough that normal runs never hit it.
+const defaultMaxStreamLines = 5000
+
+// trimmedMarker replaces trimmed-away lines at the top of the buffer.
+const trimmedMarker = "… earlier output trimmed …"
+
 // StreamingText displays real-time streaming text with cursor.
 type StreamingText struct {
-	Content     string
-	Width       int
-	Height      int
-	ScrollPos   int
-	ShowCursor  bool
-	CursorChar  string
-	AgentRole   string
-	IsStreaming bool
+	Content        string
+	Width          int
+	Height         int
+	ScrollPos      int
+	ShowCursor     bool
+	CursorChar     string
+	AgentRole      string
+	IsStreaming    bool
 	HighlightLines []int
+
+	// MaxLines caps how many lines Content may hold; once exceeded, the
+	// oldest lines are dropped and replaced with trimmedMarker. 0 disables
+	// trimming.
+	MaxLines int
 }
 
 // NewStreamingText creates a new streaming text widget.
@@ -28,6 +41,7 @@ func NewStreamingText(width, height int) StreamingText {
 		Height:     height,
 		ShowCursor: true,
 		CursorChar: "▌",
+		MaxLines:   defaultMaxStreamLines,
 	}
 }
 
@@ -35,17 +49,35 @@ func NewStreamingText(width, height int) StreamingText {
 func (s *StreamingText) Append(text string) {
 	s.Content += text
 	s.IsStreaming = true
-
-	// Auto-scroll to bottom
-	lines := strings.Split(s.Content, "\n")
-	if len(lines) > s.Height {
-		s.ScrollPos = len(lines) - s.Height
-	}
+	s.trim()
+	s.scrollToBottomOnAppend()
 }
 
 // SetContent replaces all content.
 func (s *StreamingText) SetContent(content string) {
 	s.Content = content
+	s.trim()
+	s.scrollToBottomOnAppend()
+}
+
+// trim drops the oldest lines once Content exceeds MaxLines, prefixing the
+// remainder with trimmedMarker. Existing highlight indices are invalidated
+// by the shift, so they're cleared rather than left pointing at the wrong
+// lines.
+func (s *StreamingText) trim() {
+	if s.MaxLines <= 0 {
+		return
+	}
+	lines := strings.Split(s.Content, "\n")
+	if len(lines) <= s.MaxLines {
+		return
+	}
+	kept := lines[len(lines)-s.MaxLines:]
+	s.Content = trimmedMarker + "\n" + strings.Join(kept, "\n")
+	s.ClearHighlights()
+}
+
+func (s *StreamingText) scrollToBottomOnAppend() {
 	lines := strings.Split(s.Content, "\n")
 	if len(lines) > s.Height {
 		s.ScrollPos = len(lines) - s.Height
@@ -0,0 +1,99 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"cooperations/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SummaryDialog is the end-of-run overlay shown when a workflow completes:
+// an actionable recap of what happened, rather than a silent return to the
+// dashboard. Populated from a stream.WorkflowSummary.
+type SummaryDialog struct {
+	Success bool
+	Stats   []string // Pre-formatted "label: value" lines, e.g. "Tokens: 1234"
+	Files   []string // Changed file paths, relative to the workspace root
+	Width   int
+}
+
+// NewSummaryDialog creates a new completion summary dialog.
+func NewSummaryDialog(success bool, stats, files []string, width int) SummaryDialog {
+	return SummaryDialog{
+		Success: success,
+		Stats:   stats,
+		Files:   files,
+		Width:   width,
+	}
+}
+
+// PlainText renders the dialog's content as plain text, for copying to the
+// clipboard without box-drawing or color codes.
+func (s SummaryDialog) PlainText() string {
+	var lines []string
+	if s.Success {
+		lines = append(lines, "Workflow completed successfully")
+	} else {
+		lines = append(lines, "Workflow aborted")
+	}
+	lines = append(lines, "")
+	lines = append(lines, s.Stats...)
+	if len(s.Files) > 0 {
+		lines = append(lines, "", "Files changed:")
+		for _, f := range s.Files {
+			lines = append(lines, "  "+f)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View renders the completion summary dialog.
+func (s SummaryDialog) View() string {
+	accent := styles.Current.Success
+	title := "✓ Workflow complete"
+	if !s.Success {
+		accent = styles.Current.Error
+		title = "✗ Workflow aborted"
+	}
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accent).
+		Padding(1, 2).
+		Width(s.Width)
+
+	titleStyle := lipgloss.NewStyle().Foreground(accent).Bold(true)
+	statStyle := lipgloss.NewStyle().Foreground(styles.Current.Foreground)
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Current.Muted).Bold(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(title))
+	lines = append(lines, "")
+	for _, stat := range s.Stats {
+		lines = append(lines, statStyle.Render(stat))
+	}
+
+	if len(s.Files) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, labelStyle.Render("Files changed:"))
+		const maxShown = 8
+		shown := s.Files
+		truncated := 0
+		if len(shown) > maxShown {
+			truncated = len(shown) - maxShown
+			shown = shown[:maxShown]
+		}
+		for _, f := range shown {
+			lines = append(lines, statStyle.Render("  "+f))
+		}
+		if truncated > 0 {
+			lines = append(lines, styles.MutedStyle.Render(fmt.Sprintf("  ... and %d more", truncated)))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, styles.MutedStyle.Render("f: file tree  c: copy summary  esc/enter: dismiss"))
+
+	return containerStyle.Render(strings.Join(lines, "\n"))
+}
@@ -1,9 +1,43 @@
 // Package widgets provides TUI components.
 package widgets
 
+import "github.com/charmbracelet/lipgloss"
+
 func maxInt(a, b int) int {
 	if a > b {
 		return a
 	}
 	return b
 }
+
+// truncateDisplay shortens s to fit within width display columns, as
+// measured by lipgloss.Width (which accounts for wide runes like CJK
+// characters and emoji), appending ellipsis when truncated. Cutting
+// rune-by-rune rather than by byte index keeps multibyte characters intact
+// instead of splitting them into invalid UTF-8.
+func truncateDisplay(s, ellipsis string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+
+	target := width - lipgloss.Width(ellipsis)
+	if target <= 0 {
+		return ellipsis
+	}
+
+	runes := []rune(s)
+	cut := 0
+	w := 0
+	for _, r := range runes {
+		rw := lipgloss.Width(string(r))
+		if w+rw > target {
+			break
+		}
+		w += rw
+		cut++
+	}
+	return string(runes[:cut]) + ellipsis
+}
@@ -0,0 +1,63 @@
+package widgets
+
+import "testing"
+
+func TestTruncateDisplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		ellipsis string
+		width    int
+		want     string
+	}{
+		{
+			name:     "fits",
+			input:    "hello",
+			ellipsis: "...",
+			width:    10,
+			want:     "hello",
+		},
+		{
+			name:     "ascii truncated",
+			input:    "hello world",
+			ellipsis: "...",
+			width:    8,
+			want:     "hello...",
+		},
+		{
+			name:     "CJK truncated keeps whole runes",
+			input:    "你好世界，这是一个测试",
+			ellipsis: "...",
+			width:    9,
+			want:     "你好世...",
+		},
+		{
+			name:     "emoji truncated keeps whole runes",
+			input:    "🎉🎉🎉🎉🎉 party",
+			ellipsis: "...",
+			width:    8,
+			want:     "🎉🎉...",
+		},
+		{
+			name:     "zero width",
+			input:    "hello",
+			ellipsis: "...",
+			width:    0,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateDisplay(tt.input, tt.ellipsis, tt.width)
+			if got != tt.want {
+				t.Errorf("truncateDisplay(%q, %q, %d) = %q, want %q", tt.input, tt.ellipsis, tt.width, got, tt.want)
+			}
+			for _, r := range got {
+				if r == 0xFFFD {
+					t.Errorf("truncateDisplay(%q, %q, %d) produced invalid UTF-8: %q", tt.input, tt.ellipsis, tt.width, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+// AbortReasonKind classifies why a workflow run didn't complete, so callers
+// can branch on category (e.g. distinct CLI exit codes) instead of matching
+// on the free-text Error string.
+type AbortReasonKind string
+
+const (
+	AbortReasonKilled            AbortReasonKind = "killed"
+	AbortReasonTimeout           AbortReasonKind = "timeout"
+	AbortReasonContextCanceled   AbortReasonKind = "context_canceled"
+	AbortReasonAgentError        AbortReasonKind = "agent_error"
+	AbortReasonMaxCyclesExceeded AbortReasonKind = "max_cycles_exceeded"
+	AbortReasonNoAgent           AbortReasonKind = "no_agent"
+	AbortReasonBudgetExceeded    AbortReasonKind = "budget_exceeded"
+)
+
+// AbortReason is the structured reason carried on an aborted WorkflowResult,
+// pairing a filterable Kind with the underlying error's message as Detail.
+// A zero-value AbortReason means the run succeeded (or failed before this
+// taxonomy could classify it).
+type AbortReason struct {
+	Kind   AbortReasonKind `json:"kind,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+}
+
+// String renders a human-readable form for display: Detail when present,
+// otherwise a fixed label for the Kind, or the empty string for a
+// zero-value reason.
+func (r AbortReason) String() string {
+	if r.Detail != "" {
+		return r.Detail
+	}
+	switch r.Kind {
+	case "":
+		return ""
+	case AbortReasonKilled:
+		return "Killed"
+	case AbortReasonTimeout:
+		return "Timed out"
+	case AbortReasonContextCanceled:
+		return "Canceled"
+	case AbortReasonAgentError:
+		return "Agent error"
+	case AbortReasonMaxCyclesExceeded:
+		return "Exceeded max review cycles"
+	case AbortReasonNoAgent:
+		return "No agent for role"
+	case AbortReasonBudgetExceeded:
+		return "Budget exceeded"
+	default:
+		return string(r.Kind)
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToMermaid renders the task's handoff history as a Mermaid flowchart, with
+// one node per role stop and each edge labeled with the tokens used for
+// that step. Node IDs are indexed by position rather than role name, since a
+// role can appear more than once across review cycles.
+func (r WorkflowResult) ToMermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	for i, h := range r.Handoffs {
+		fromID := diagramNodeID(i, h.FromRole)
+		toID := diagramNodeID(i+1, h.ToRole)
+		b.WriteString(fmt.Sprintf("    %s[%s] -->|%s| %s[%s]\n",
+			fromID, string(h.FromRole), diagramEdgeLabel(h), toID, string(h.ToRole)))
+	}
+	return b.String()
+}
+
+// ToGraphviz renders the same handoff history as a Graphviz DOT digraph.
+func (r WorkflowResult) ToGraphviz() string {
+	var b strings.Builder
+	b.WriteString("digraph workflow {\n")
+	b.WriteString("    rankdir=LR;\n")
+	for i, h := range r.Handoffs {
+		fromID := diagramNodeID(i, h.FromRole)
+		toID := diagramNodeID(i+1, h.ToRole)
+		b.WriteString(fmt.Sprintf("    %s [label=%q];\n", fromID, string(h.FromRole)))
+		b.WriteString(fmt.Sprintf("    %s [label=%q];\n", toID, string(h.ToRole)))
+		b.WriteString(fmt.Sprintf("    %s -> %s [label=%q];\n", fromID, toID, diagramEdgeLabel(h)))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// diagramNodeID builds a stable, syntax-safe node identifier for the ith
+// role stop in a handoff chain.
+func diagramNodeID(step int, role Role) string {
+	return fmt.Sprintf("n%d_%s", step, role)
+}
+
+// diagramEdgeLabel summarizes one handoff for display on a diagram edge,
+// combining its structured reason (when set) with the tokens used.
+func diagramEdgeLabel(h Handoff) string {
+	reason := h.Reason.String()
+	if reason == "" {
+		return fmt.Sprintf("%d tokens", h.Metadata.TokensUsed)
+	}
+	return fmt.Sprintf("%s (%d tokens)", reason, h.Metadata.TokensUsed)
+}
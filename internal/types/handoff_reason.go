@@ -0,0 +1,44 @@
+package types
+
+// HandoffReasonKind categorizes why a handoff happened, so history and
+// diagrams can filter or group transitions instead of matching on
+// free-text prose.
+type HandoffReasonKind string
+
+const (
+	HandoffReasonInitialRouting  HandoffReasonKind = "initial_routing"
+	HandoffReasonSkipped         HandoffReasonKind = "skipped"
+	HandoffReasonReviewRequested HandoffReasonKind = "review_requested"
+	HandoffReasonRoleTransition  HandoffReasonKind = "role_transition"
+)
+
+// HandoffReason is the structured reason carried on a Handoff (and its
+// streamed counterpart), pairing a filterable Kind with an optional
+// free-text Detail for anything a fixed kind can't capture.
+type HandoffReason struct {
+	Kind   HandoffReasonKind `json:"kind,omitempty"`
+	Detail string            `json:"detail,omitempty"`
+}
+
+// String renders a human-readable form for display: Detail when present,
+// otherwise a fixed label for the Kind, or the empty string for a
+// zero-value reason.
+func (r HandoffReason) String() string {
+	if r.Detail != "" {
+		return r.Detail
+	}
+	switch r.Kind {
+	case "":
+		return ""
+	case HandoffReasonInitialRouting:
+		return "Initial routing"
+	case HandoffReasonSkipped:
+		return "Skipped to next agent"
+	case HandoffReasonReviewRequested:
+		return "Review requested"
+	case HandoffReasonRoleTransition:
+		return "Role transition"
+	default:
+		return string(r.Kind)
+	}
+}
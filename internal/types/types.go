@@ -1,6 +1,8 @@
 // Package types defines shared types for the cooperations orchestrator.
 package types
 
+import "time"
+
 // Role represents an agent role in the mob programming workflow.
 type Role string
 
@@ -22,10 +24,12 @@ const (
 
 // Task represents a unit of work in the system.
 type Task struct {
-	ID          string `json:"id"`
-	Description string `json:"description"`
-	CreatedAt   string `json:"created_at"`
-	Status      string `json:"status"` // pending, in_progress, completed, failed
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+	Status      string   `json:"status"` // pending, in_progress, completed, failed
+	Tags        []string `json:"tags,omitempty"`
 }
 
 // TaskStatus constants.
@@ -38,21 +42,34 @@ const (
 
 // Handoff represents a context transfer between agents.
 type Handoff struct {
-	TaskID    string     `json:"task_id" validate:"required"`
-	Timestamp string     `json:"timestamp" validate:"required"`
-	FromRole  Role       `json:"from_role" validate:"required"`
-	ToRole    Role       `json:"to_role" validate:"required"`
-	Context   HContext   `json:"context" validate:"required"`
-	Artifacts HArtifacts `json:"artifacts"`
-	Metadata  HMetadata  `json:"metadata" validate:"required"`
+	TaskID    string        `json:"task_id" validate:"required"`
+	Timestamp string        `json:"timestamp" validate:"required"`
+	FromRole  Role          `json:"from_role" validate:"required"`
+	ToRole    Role          `json:"to_role" validate:"required"`
+	Reason    HandoffReason `json:"reason,omitempty"`
+	Context   HContext      `json:"context" validate:"required"`
+	Artifacts HArtifacts    `json:"artifacts"`
+	Metadata  HMetadata     `json:"metadata" validate:"required"`
 }
 
 // HContext holds the task context passed between agents.
 type HContext struct {
+	// OriginalTaskDescription is the task description as given at workflow
+	// start. It's set once and never overwritten, so it stays available
+	// even after TaskDescription is replaced by an agent's prose.
+	OriginalTaskDescription string `json:"original_task_description,omitempty"`
+
+	// TaskDescription is replaced with the previous agent's response
+	// content after each handoff.
 	TaskDescription string   `json:"task_description"`
 	Requirements    []string `json:"requirements"`
 	Constraints     []string `json:"constraints"`
 	FilesInScope    []string `json:"files_in_scope"`
+
+	// ReferenceContext holds the concatenated contents of any
+	// WorkflowConfig.ContextFiles, prepended to prompts as background
+	// material the agent wouldn't otherwise see. Empty when unconfigured.
+	ReferenceContext string `json:"reference_context,omitempty"`
 }
 
 // HArtifacts holds outputs produced by agents.
@@ -76,8 +93,13 @@ type AgentResponse struct {
 	Content    string         `json:"content"`
 	Artifacts  map[string]any `json:"artifacts"`
 	TokensUsed int            `json:"tokens_used"`
-	DurationMS int64          `json:"duration_ms"`
-	NextRole   *Role          `json:"next_role,omitempty"`
+	// PromptTokens and CompletionTokens carry the real input/output split
+	// reported by the underlying CLI, when available. Zero means the
+	// adapter didn't report a split; callers fall back to an estimate.
+	PromptTokens     int   `json:"prompt_tokens,omitempty"`
+	CompletionTokens int   `json:"completion_tokens,omitempty"`
+	DurationMS       int64 `json:"duration_ms"`
+	NextRole         *Role `json:"next_role,omitempty"`
 }
 
 // WorkflowState tracks the current state of a workflow execution.
@@ -86,15 +108,65 @@ type WorkflowState struct {
 	Handoffs     []Handoff `json:"handoffs"`
 	CurrentRole  Role      `json:"current_role"`
 	ReviewCycles int       `json:"review_cycles"`
+
+	// PrevImplementerOutput holds the implementer's raw output from its
+	// previous turn, so the workflow can detect when consecutive turns
+	// converge and stop looping through reviews early.
+	PrevImplementerOutput string `json:"prev_implementer_output,omitempty"`
 }
 
 // WorkflowResult is the final output of a workflow execution.
 type WorkflowResult struct {
-	Task      Task      `json:"task"`
-	Handoffs  []Handoff `json:"handoffs"`
-	Success   bool      `json:"success"`
-	Error     string    `json:"error,omitempty"`
-	Artifacts HArtifacts `json:"artifacts"`
+	Task     Task      `json:"task"`
+	Handoffs []Handoff `json:"handoffs"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+
+	// AbortReason classifies why the run didn't complete. It's the zero
+	// value when Success is true.
+	AbortReason  AbortReason     `json:"abort_reason,omitempty"`
+	Artifacts    HArtifacts      `json:"artifacts"`
+	FilesChanged []FileChange    `json:"files_changed,omitempty"`
+	Metrics      WorkflowMetrics `json:"metrics"`
+}
+
+// FileChange records one workspace write made during a run, so callers can
+// show an accurate "N files changed" summary or undo an aborted run.
+type FileChange struct {
+	Path   string `json:"path"`
+	Action string `json:"action"` // created, modified, or reverted
+}
+
+// WriteLedgerEntry captures a workspace write's pre-write state, so a task's
+// file changes can be undone after the run that made them has already
+// exited. Existed and OldContent are only meaningful together: when Existed
+// is false, OldContent is empty and undoing the write means removing the
+// file rather than restoring old content.
+type WriteLedgerEntry struct {
+	Path       string `json:"path"`
+	Existed    bool   `json:"existed"`
+	OldContent string `json:"old_content,omitempty"`
+}
+
+// WorkflowMetrics aggregates a run's token, cost, and timing totals, so
+// callers get an accurate summary without needing a stream attached.
+type WorkflowMetrics struct {
+	TotalTokens      int                `json:"total_tokens"`
+	PromptTokens     int                `json:"prompt_tokens"`
+	CompletionTokens int                `json:"completion_tokens"`
+	EstimatedCostUSD float64            `json:"estimated_cost_usd"`
+	ElapsedTime      time.Duration      `json:"elapsed_time"`
+	ReviewCycles     int                `json:"review_cycles"`
+	FilesChanged     int                `json:"files_changed"`
+	RoleBreakdown    map[Role]RoleStats `json:"role_breakdown,omitempty"`
+}
+
+// RoleStats aggregates one role's activity across a workflow run.
+type RoleStats struct {
+	Calls      int   `json:"calls"`
+	Tokens     int   `json:"tokens"`
+	DurationMS int64 `json:"duration_ms"`
+	Errors     int   `json:"errors"`
 }
 
 // CLIResponse is the normalized response from a CLI execution.
@@ -102,4 +174,9 @@ type CLIResponse struct {
 	Content    string `json:"content"`
 	TokensUsed int    `json:"tokens_used"`
 	Model      string `json:"model"`
+	// PromptTokens and CompletionTokens carry the real input/output split
+	// reported by the CLI's usage data, when available. Zero means the CLI
+	// didn't report a split; callers fall back to an estimate.
+	PromptTokens     int `json:"prompt_tokens,omitempty"`
+	CompletionTokens int `json:"completion_tokens,omitempty"`
 }
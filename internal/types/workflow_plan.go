@@ -0,0 +1,24 @@
+package types
+
+// WorkflowPlan previews the role sequence a task would take and a rough
+// cost/duration estimate from history, without running anything. See
+// Orchestrator.Plan.
+type WorkflowPlan struct {
+	TaskDescription string             `json:"task_description"`
+	InitialRole     Role               `json:"initial_role"`
+	Confidence      float64            `json:"confidence"`
+	Steps           []WorkflowPlanStep `json:"steps"`
+
+	EstimatedTotalTokens int     `json:"estimated_total_tokens"`
+	EstimatedCostUSD     float64 `json:"estimated_cost_usd"`
+	EstimatedDurationMS  int64   `json:"estimated_duration_ms"`
+}
+
+// WorkflowPlanStep is one role in a WorkflowPlan's projected default
+// progression (it doesn't account for review-cycle detours).
+type WorkflowPlanStep struct {
+	Role            Role  `json:"role"`
+	EstimatedTokens int   `json:"estimated_tokens"`
+	EstimatedMS     int64 `json:"estimated_ms"`
+	HasHistory      bool  `json:"has_history"`
+}